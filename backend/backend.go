@@ -0,0 +1,67 @@
+// Package backend abstracts the WebGL implementation RenderingContext dispatches its calls to.
+//
+// Calls use the WebGL method names and arguments. Arguments are plain Go values: numbers, booleans,
+// strings, slices for typed arrays, nil for null, Values and the object handles of the types package.
+package backend
+
+// A value returned by a backend, mirroring the subset of syscall/js.Value used by the wrapper.
+// Conversions never panic on pure Go backends, mismatched types give zero values.
+type Value interface {
+	Call(method string, args ...interface{}) Value
+	Get(property string) Value
+	Index(i int) Value
+	Length() int
+	Int() int
+	Float() float64
+	Bool() bool
+	String() string
+	IsNull() bool
+	IsUndefined() bool
+}
+
+// Executes the calls of a RenderingContext, the receiver being the WebGL context itself
+type Backend interface {
+	Call(method string, args ...interface{}) Value
+	Get(property string) Value
+}
+
+// Implemented by backends buffering their calls, Flush runs the pending ones
+type Flusher interface {
+	Flush()
+}
+
+// Implemented by backends wrapping another one, like the debug mode of RenderingContext
+type Wrapper interface {
+	Unwrap() Backend
+}
+
+// Returns the innermost backend of a chain of wrappers
+func Root(b Backend) Backend {
+	for {
+		wrapper, ok := b.(Wrapper)
+		if !ok {
+			return b
+		}
+		b = wrapper.Unwrap()
+	}
+}
+
+// Flushes the first backend of a chain of wrappers implementing Flusher, if any
+func Flush(b Backend) {
+	for {
+		if flusher, ok := b.(Flusher); ok {
+			flusher.Flush()
+			return
+		}
+		wrapper, ok := b.(Wrapper)
+		if !ok {
+			return
+		}
+		b = wrapper.Unwrap()
+	}
+}
+
+// Implemented by the WebGL object handles, a nil handle gives a nil value
+type Handle interface {
+	GetValue() Value
+}
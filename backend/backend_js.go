@@ -0,0 +1,167 @@
+package backend
+
+import (
+	"reflect"
+	"runtime"
+	"syscall/js"
+)
+
+// Value backed by a JavaScript value, the WebGL context of a browser is one
+type jsValue struct {
+	js js.Value
+}
+
+// Wraps a JavaScript value, a WebGL rendering context gives the browser backend
+func FromJs(value js.Value) Value {
+	return jsValue{js: value}
+}
+
+// Returns the JavaScript value behind a value, handle or backend created by FromJs or NewCommandBuffer.
+// Anything else gives undefined.
+func ToJs(arg interface{}) js.Value {
+	switch value := arg.(type) {
+	case jsValue:
+		return value.js
+	case *commandObject:
+		return value.js()
+	case *CommandBuffer:
+		return value.context
+	case Handle:
+		if inner := value.GetValue(); inner != nil {
+			return ToJs(inner)
+		}
+		return js.Null()
+	}
+	return js.Undefined()
+}
+
+// Calls writing into a typed array argument, their slices are copied back after the call
+var outputMethods = map[string]bool{
+	"getBufferSubData": true,
+	"readPixels":       true,
+}
+
+// Slices are copied into pooled typed arrays for the duration of the call, WebGL copies
+// what it reads before returning. Buffer uploads read the Go memory directly instead, they
+// return nothing.
+func (v jsValue) Call(method string, args ...interface{}) Value {
+	m := marshaller{zeroCopy: zeroCopyMethods[method]}
+	argsJs := m.args(args)
+	result := js.Undefined()
+	if len(m.buffers) == 0 {
+		result = v.js.Call(method, argsJs...)
+	} else if !m.callWithViews(v.js, method, argsJs) {
+		// The memory grew under the views before the call, it is made with copies instead
+		m.release()
+		m = marshaller{}
+		result = v.js.Call(method, m.args(args)...)
+	}
+	if outputMethods[method] {
+		m.copyBack()
+	}
+	m.release()
+	// Views do not keep their slices alive
+	runtime.KeepAlive(args)
+	return jsValue{js: result}
+}
+
+func (v jsValue) Get(property string) Value {
+	return jsValue{js: v.js.Get(property)}
+}
+
+func (v jsValue) Index(i int) Value {
+	return jsValue{js: v.js.Index(i)}
+}
+
+func (v jsValue) Length() int {
+	return v.js.Length()
+}
+
+// Booleans convert to 0 or 1, like on pure Go values
+func (v jsValue) Int() int {
+	return int(v.Float())
+}
+
+func (v jsValue) Float() float64 {
+	if v.js.Type() == js.TypeBoolean {
+		if v.js.Bool() {
+			return 1
+		}
+		return 0
+	}
+	return v.js.Float()
+}
+
+func (v jsValue) Bool() bool {
+	return v.js.Bool()
+}
+
+func (v jsValue) String() string {
+	return v.js.String()
+}
+
+func (v jsValue) IsNull() bool {
+	return v.js.IsNull()
+}
+
+func (v jsValue) IsUndefined() bool {
+	return v.js.IsUndefined()
+}
+
+func (m *marshaller) args(args []interface{}) []interface{} {
+	argsJs := make([]interface{}, len(args))
+	for i, arg := range args {
+		argsJs[i] = m.toJs(arg)
+	}
+	return argsJs
+}
+
+// Converts a call argument into something js.ValueOf accepts
+func (m *marshaller) toJs(arg interface{}) interface{} {
+	switch value := arg.(type) {
+	case nil:
+		return js.Null()
+	case js.Value:
+		return value
+	case jsValue:
+		return value.js
+	case *commandObject:
+		return value.js()
+	case Handle:
+		return ToJs(value)
+	case bool, string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	case []int8, []int16, []int32, []uint8, []uint16, []uint32, []float32, []float64, []int, []uint:
+		return m.typedArrayOf(value)
+	case []interface{}:
+		return m.args(value)
+	case []string:
+		converted := make([]interface{}, len(value))
+		for i, v := range value {
+			converted[i] = v
+		}
+		return converted
+	}
+
+	// Named types, like types.GLEnum, and slices of them
+	reflected := reflect.ValueOf(arg)
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		converted := make([]interface{}, reflected.Len())
+		for i := range converted {
+			converted[i] = m.toJs(reflected.Index(i).Interface())
+		}
+		return converted
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflected.Uint()
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float()
+	case reflect.Bool:
+		return reflected.Bool()
+	case reflect.String:
+		return reflected.String()
+	}
+	return arg
+}
@@ -0,0 +1,432 @@
+package backend
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall/js"
+	"unsafe"
+)
+
+// Command stream layout, little endian. A command is its method number, its argument count,
+// its flags, the optional result and receiver object numbers, then its arguments. Method numbers
+// are given by define commands, holding the method name, the first time a method is used.
+const (
+	defineMethod = 0xFFFF
+
+	flagResult   = 1
+	flagReceiver = 2
+)
+
+// Argument tags, each one followed by the value of the argument
+const (
+	tagNull = iota
+	tagInt
+	tagFloat
+	tagTrue
+	tagFalse
+	tagString
+	tagObject
+	tagTypedArray
+	tagArray
+)
+
+// Typed array kinds, in the order of the interpreter table
+const (
+	arrayInt8 = iota
+	arrayInt16
+	arrayInt32
+	arrayUint8
+	arrayUint16
+	arrayUint32
+	arrayFloat32
+	arrayFloat64
+)
+
+// How a call runs on the command buffer
+const (
+	callBuffered = iota // Encoded in the stream, its result is not used
+	callCreating        // Encoded in the stream, its result is stored in the object table
+	callDirect          // Run right away after a flush, for queries
+)
+
+// Backend encoding the WebGL calls into a binary stream replayed by a JavaScript interpreter on
+// Flush, so a frame costs a single Go to JavaScript transition instead of one per call.
+//
+// Calls returning nothing are buffered. Created objects are numbered on the Go side and stored in
+// an object table by the interpreter. Queries, like get*, is*, readPixels or clientWaitSync, finish
+// and calls taking JavaScript objects, flush the stream and run right away, so their results are the
+// ones of the direct calls. Objects created by buffered calls are never null.
+type CommandBuffer struct {
+	context js.Value
+	replay  js.Value
+	objects js.Value // Object table of the interpreter, indexed by object numbers
+	methods map[string]uint16
+	stream  []byte
+	nextId  uint32
+	mutex   sync.Mutex
+}
+
+// Object of the interpreter table, created by a buffered call or returned by a direct one
+type commandObject struct {
+	buffer   *CommandBuffer
+	id       uint32   // 0 until the object is referenced by the stream
+	value    js.Value // Read from the table on first use for objects created by buffered calls
+	resolved bool
+}
+
+// Wraps a WebGL context, calls are buffered until Flush or a query
+func NewCommandBuffer(context js.Value) *CommandBuffer {
+	return &CommandBuffer{
+		context: context,
+		replay:  js.Global().Get("Function").New(commandInterpreter).Invoke(),
+		objects: js.Global().Get("Array").New(),
+		methods: make(map[string]uint16),
+	}
+}
+
+func (b *CommandBuffer) Call(method string, args ...interface{}) Value {
+	return b.call(nil, method, args)
+}
+
+func (b *CommandBuffer) Get(property string) Value {
+	return b.wrap(jsValue{js: b.context.Get(property)})
+}
+
+// Replays the buffered calls, to be called once per frame
+func (b *CommandBuffer) Flush() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.flush()
+}
+
+func (b *CommandBuffer) flush() {
+	if len(b.stream) == 0 {
+		return
+	}
+	stream := b.stream
+	b.stream = b.stream[:0]
+
+	// The interpreter reads the stream in the wasm memory, or a copy when the memory cannot be
+	// reached or grew while the call was made
+	if buffer := memoryBuffer(); !buffer.IsUndefined() {
+		address := int(uintptr(unsafe.Pointer(&stream[0])))
+		done := b.replay.Invoke(b.context, b.objects, buffer, address, len(stream)).Bool()
+		runtime.KeepAlive(stream)
+		if done {
+			return
+		}
+	}
+	array := uint8Arrays.acquire(len(stream))
+	defer array.release()
+	js.CopyBytesToJS(array.bytes, stream)
+	b.replay.Invoke(b.context, b.objects, array.array.Get("buffer"), 0, len(stream))
+}
+
+func callKind(method string, args []interface{}) int {
+	switch {
+	case strings.HasPrefix(method, "create"), method == "fenceSync":
+		return callCreating
+	case strings.HasPrefix(method, "get"), strings.HasPrefix(method, "is"), strings.HasPrefix(method, "check"),
+		method == "readPixels", method == "clientWaitSync", method == "finish":
+		return callDirect
+	}
+	for _, arg := range args {
+		if isJsObject(arg) {
+			return callDirect
+		}
+	}
+	return callBuffered
+}
+
+// Whether an argument is a JavaScript object not known to the interpreter, like an image or the
+// handle of another context
+func isJsObject(arg interface{}) bool {
+	var value js.Value
+	switch arg := arg.(type) {
+	case js.Value:
+		value = arg
+	case jsValue:
+		value = arg.js
+	case Handle:
+		inner := arg.GetValue()
+		return inner != nil && isJsObject(inner)
+	default:
+		return false
+	}
+	return value.Type() == js.TypeObject || value.Type() == js.TypeFunction
+}
+
+// Runs a call on the context, or on the receiver object when it is not nil
+func (b *CommandBuffer) call(receiver *commandObject, method string, args []interface{}) Value {
+	switch callKind(method, args) {
+	case callBuffered:
+		b.mutex.Lock()
+		b.encode(receiver, method, args, nil)
+		b.mutex.Unlock()
+		return Undefined()
+	case callCreating:
+		b.mutex.Lock()
+		b.nextId++
+		result := &commandObject{buffer: b, id: b.nextId}
+		b.encode(receiver, method, args, result)
+		b.mutex.Unlock()
+		return result
+	}
+
+	b.Flush()
+	target := b.context
+	if receiver != nil {
+		target = receiver.js()
+	}
+	return b.wrap(jsValue{js: target}.Call(method, args...))
+}
+
+// Objects returned by direct calls are wrapped, so the stream can reference them
+func (b *CommandBuffer) wrap(value Value) Value {
+	jv, ok := value.(jsValue)
+	if !ok || (jv.js.Type() != js.TypeObject && jv.js.Type() != js.TypeFunction) {
+		return value
+	}
+	return &commandObject{buffer: b, value: jv.js, resolved: true}
+}
+
+// Must be called with the mutex held
+func (b *CommandBuffer) encode(receiver *commandObject, method string, args []interface{}, result *commandObject) {
+	number, ok := b.methods[method]
+	if !ok {
+		number = uint16(len(b.methods))
+		b.methods[method] = number
+		b.putUint16(defineMethod)
+		b.putString(method)
+	}
+
+	var flags byte
+	if result != nil {
+		flags |= flagResult
+	}
+	if receiver != nil {
+		flags |= flagReceiver
+	}
+	b.putUint16(number)
+	b.stream = append(b.stream, byte(len(args)), flags)
+	if result != nil {
+		b.putUint32(result.id)
+	}
+	if receiver != nil {
+		b.putUint32(b.ref(receiver))
+	}
+	for _, arg := range args {
+		b.putArg(arg)
+	}
+}
+
+// Returns the number of an object in the table, adding it when needed. Must be called with the mutex held.
+func (b *CommandBuffer) ref(obj *commandObject) uint32 {
+	if obj.id == 0 {
+		b.nextId++
+		obj.id = b.nextId
+		b.objects.SetIndex(int(obj.id), obj.value)
+	}
+	return obj.id
+}
+
+func (b *CommandBuffer) putUint16(value uint16) {
+	b.stream = binary.LittleEndian.AppendUint16(b.stream, value)
+}
+
+func (b *CommandBuffer) putUint32(value uint32) {
+	b.stream = binary.LittleEndian.AppendUint32(b.stream, value)
+}
+
+func (b *CommandBuffer) putString(value string) {
+	b.putUint32(uint32(len(value)))
+	b.stream = append(b.stream, value...)
+}
+
+func (b *CommandBuffer) putNumber(value float64) {
+	if value == math.Trunc(value) && value >= math.MinInt32 && value <= math.MaxInt32 {
+		b.stream = append(b.stream, tagInt)
+		b.putUint32(uint32(int32(value)))
+		return
+	}
+	b.stream = append(b.stream, tagFloat)
+	b.stream = binary.LittleEndian.AppendUint64(b.stream, math.Float64bits(value))
+}
+
+// Typed arrays are aligned on their element size from the start of the stream
+func (b *CommandBuffer) putTypedArray(kind byte, data []byte, elemSize int) {
+	b.stream = append(b.stream, tagTypedArray, kind)
+	b.putUint32(uint32(len(data) / elemSize))
+	for len(b.stream)%elemSize != 0 {
+		b.stream = append(b.stream, 0)
+	}
+	b.stream = append(b.stream, data...)
+}
+
+func (b *CommandBuffer) putArg(arg interface{}) {
+	switch value := arg.(type) {
+	case nil:
+		b.stream = append(b.stream, tagNull)
+	case bool:
+		if value {
+			b.stream = append(b.stream, tagTrue)
+		} else {
+			b.stream = append(b.stream, tagFalse)
+		}
+	case string:
+		b.stream = append(b.stream, tagString)
+		b.putString(value)
+	case *commandObject:
+		b.stream = append(b.stream, tagObject)
+		b.putUint32(b.ref(value))
+	case jsValue:
+		b.putJs(value.js)
+	case js.Value:
+		b.putJs(value)
+	case Handle:
+		if inner := value.GetValue(); inner != nil {
+			b.putArg(inner)
+		} else {
+			b.stream = append(b.stream, tagNull)
+		}
+	case []int8:
+		b.putTypedArray(arrayInt8, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 1), 1)
+	case []int16:
+		b.putTypedArray(arrayInt16, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 2), 2)
+	case []int32:
+		b.putTypedArray(arrayInt32, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4), 4)
+	case []uint8:
+		b.putTypedArray(arrayUint8, value, 1)
+	case []uint16:
+		b.putTypedArray(arrayUint16, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 2), 2)
+	case []uint32:
+		b.putTypedArray(arrayUint32, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4), 4)
+	case []float32:
+		b.putTypedArray(arrayFloat32, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4), 4)
+	case []float64:
+		b.putTypedArray(arrayFloat64, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 8), 8)
+	case []int:
+		converted := make([]int32, len(value))
+		for i, v := range value {
+			converted[i] = int32(v)
+		}
+		b.putArg(converted)
+	case []uint:
+		converted := make([]uint32, len(value))
+		for i, v := range value {
+			converted[i] = uint32(v)
+		}
+		b.putArg(converted)
+	case []interface{}:
+		b.stream = append(b.stream, tagArray)
+		b.putUint32(uint32(len(value)))
+		for _, v := range value {
+			b.putArg(v)
+		}
+	case []string:
+		b.stream = append(b.stream, tagArray)
+		b.putUint32(uint32(len(value)))
+		for _, v := range value {
+			b.putArg(v)
+		}
+	default:
+		b.putReflected(reflect.ValueOf(arg))
+	}
+}
+
+// Numbers of any type, including named ones like types.GLEnum, and slices of them
+func (b *CommandBuffer) putReflected(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		b.stream = append(b.stream, tagArray)
+		b.putUint32(uint32(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			b.putArg(value.Index(i).Interface())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.putNumber(float64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.putNumber(float64(value.Uint()))
+	case reflect.Float32, reflect.Float64:
+		b.putNumber(value.Float())
+	case reflect.Bool:
+		b.putArg(value.Bool())
+	case reflect.String:
+		b.putArg(value.String())
+	default:
+		b.stream = append(b.stream, tagNull)
+	}
+}
+
+// JavaScript objects never reach the stream, their calls run directly
+func (b *CommandBuffer) putJs(value js.Value) {
+	switch value.Type() {
+	case js.TypeBoolean:
+		b.putArg(value.Bool())
+	case js.TypeNumber:
+		b.putNumber(value.Float())
+	case js.TypeString:
+		b.putArg(value.String())
+	default:
+		b.stream = append(b.stream, tagNull)
+	}
+}
+
+// Returns the JavaScript object, replaying the stream first when it has not been created yet
+func (obj *commandObject) js() js.Value {
+	b := obj.buffer
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !obj.resolved {
+		b.flush()
+		obj.value = b.objects.Index(int(obj.id))
+		obj.resolved = true
+	}
+	return obj.value
+}
+
+// Methods of the object, like the ones of an extension, are buffered like the context ones
+func (obj *commandObject) Call(method string, args ...interface{}) Value {
+	return obj.buffer.call(obj, method, args)
+}
+
+func (obj *commandObject) Get(property string) Value {
+	return obj.buffer.wrap(jsValue{js: obj.js().Get(property)})
+}
+
+func (obj *commandObject) Index(i int) Value {
+	return obj.buffer.wrap(jsValue{js: obj.js().Index(i)})
+}
+
+func (obj *commandObject) Length() int {
+	return obj.js().Length()
+}
+
+func (obj *commandObject) Int() int {
+	return jsValue{js: obj.js()}.Int()
+}
+
+func (obj *commandObject) Float() float64 {
+	return jsValue{js: obj.js()}.Float()
+}
+
+func (obj *commandObject) Bool() bool {
+	return obj.js().Truthy()
+}
+
+func (obj *commandObject) String() string {
+	return obj.js().String()
+}
+
+// Objects created by buffered calls are assumed not null, so checking does not flush
+func (obj *commandObject) IsNull() bool {
+	return obj.resolved && obj.value.IsNull()
+}
+
+func (obj *commandObject) IsUndefined() bool {
+	return obj.resolved && obj.value.IsUndefined()
+}
@@ -0,0 +1,138 @@
+package backend
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// Context recording the calls of its methods, fail throws
+func newRecordingContext() js.Value {
+	return js.Global().Get("Function").New(`return {
+		calls: [],
+		fail() { throw new Error("failed"); },
+		first(value) { this.calls.push("first " + value); },
+		second(value) { this.calls.push("second " + value); },
+		createThing(name) { this.calls.push("create " + name); return { name: name }; },
+		getThing(name) { return { name: name }; },
+		use(thing) { this.calls.push("use " + thing.name); },
+		getCallCount() { return this.calls.length; },
+		readPixels(pixels) { this.calls.push("readPixels"); pixels[0] = this.calls.length; },
+		finish() { this.calls.push("finish"); },
+	};`).Invoke()
+}
+
+func recordedCalls(context js.Value) []string {
+	calls := context.Get("calls")
+	recorded := make([]string, calls.Length())
+	for i := range recorded {
+		recorded[i] = calls.Index(i).String()
+	}
+	return recorded
+}
+
+func expectCalls(t *testing.T, context js.Value, expected ...string) {
+	t.Helper()
+	calls := recordedCalls(context)
+	if len(calls) != len(expected) {
+		t.Fatalf("got calls %v, expected %v", calls, expected)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatalf("got calls %v, expected %v", calls, expected)
+		}
+	}
+}
+
+func flushRecovered(b *CommandBuffer) (recovered interface{}) {
+	defer func() {
+		recovered = recover()
+	}()
+	b.Flush()
+	return nil
+}
+
+func TestCommandBufferReplay(t *testing.T) {
+	context := newRecordingContext()
+	b := NewCommandBuffer(context)
+	b.Call("first", 1)
+	b.Call("second", 2)
+	b.Call("first", 3)
+	if calls := recordedCalls(context); len(calls) != 0 {
+		t.Fatalf("calls ran before the flush: %v", calls)
+	}
+	b.Flush()
+	expectCalls(t, context, "first 1", "second 2", "first 3")
+}
+
+// Objects created by buffered calls are numbered before the replay and resolved from the table
+func TestCommandBufferCreatedObjects(t *testing.T) {
+	context := newRecordingContext()
+	b := NewCommandBuffer(context)
+	first := b.Call("createThing", "a")
+	second := b.Call("createThing", "b")
+	b.Call("use", second)
+	b.Call("use", first)
+	if first.IsNull() || first.IsUndefined() {
+		t.Fatal("a buffered object is null before the flush")
+	}
+	expectCalls(t, context)
+	b.Flush()
+	expectCalls(t, context, "create a", "create b", "use b", "use a")
+
+	// Reading an object replays the stream creating it
+	third := b.Call("createThing", "c")
+	if name := third.Get("name").String(); name != "c" {
+		t.Fatalf("got object %q, expected c", name)
+	}
+	expectCalls(t, context, "create a", "create b", "use b", "use a", "create c")
+
+	// Objects returned by direct calls are added to the table when the stream uses them
+	b.Call("use", b.Call("getThing", "d"))
+	b.Call("use", first)
+	b.Flush()
+	expectCalls(t, context, "create a", "create b", "use b", "use a", "create c", "use d", "use a")
+}
+
+// Queries and finish replay the buffered calls before running
+func TestCommandBufferFlushesBeforeQueries(t *testing.T) {
+	context := newRecordingContext()
+	b := NewCommandBuffer(context)
+	b.Call("first", 1)
+	b.Call("second", 2)
+	if count := b.Call("getCallCount").Int(); count != 2 {
+		t.Fatalf("getCallCount saw %d calls, expected the 2 buffered ones", count)
+	}
+
+	b.Call("first", 3)
+	pixels := js.Global().Get("Array").New(1)
+	b.Call("readPixels", pixels)
+	if count := pixels.Index(0).Int(); count != 4 {
+		t.Fatalf("readPixels ran after %d calls, expected 3", count-1)
+	}
+
+	b.Call("second", 4)
+	b.Call("finish")
+	expectCalls(t, context, "first 1", "second 2", "first 3", "readPixels", "second 4", "finish")
+}
+
+// Methods used for the first time after a throwing call are defined for the next flushes
+func TestCommandBufferMethodsAfterException(t *testing.T) {
+	context := newRecordingContext()
+	b := NewCommandBuffer(context)
+	b.Call("fail")
+	b.Call("first", 1)
+	b.Call("second", 2)
+	if recovered := flushRecovered(b); recovered == nil {
+		t.Fatal("the exception of fail was not thrown by Flush")
+	}
+	if calls := recordedCalls(context); len(calls) != 0 {
+		t.Fatalf("calls after the exception ran: %v", calls)
+	}
+
+	b.Call("second", 3)
+	b.Call("first", 4)
+	if recovered := flushRecovered(b); recovered != nil {
+		t.Fatalf("flush after the exception failed: %v", recovered)
+	}
+	expectCalls(t, context, "second 3", "first 4")
+}
@@ -0,0 +1,120 @@
+package backend
+
+// JavaScript replaying command streams, the body of a function returning the replay function.
+// replay(gl, objects, buffer, offset, length) runs the commands stored in buffer at offset, and
+// returns false without running anything when the buffer got detached. Exceptions stop the replay,
+// the remaining commands are dropped once their method definitions are read, then the exception is
+// thrown.
+const commandInterpreter = `
+const methods = [];
+const decoder = new TextDecoder();
+const arrays = [Int8Array, Int16Array, Int32Array, Uint8Array, Uint16Array, Uint32Array, Float32Array, Float64Array];
+
+return function (gl, objects, buffer, offset, length) {
+	if (buffer.byteLength === 0) {
+		return false;
+	}
+	const view = new DataView(buffer, offset, length);
+	let pos = 0;
+
+	function string() {
+		const size = view.getUint32(pos, true);
+		const value = decoder.decode(new Uint8Array(buffer, offset + pos + 4, size));
+		pos += 4 + size;
+		return value;
+	}
+
+	function arg() {
+		const tag = view.getUint8(pos++);
+		switch (tag) {
+		case 0:
+			return null;
+		case 1:
+			pos += 4;
+			return view.getInt32(pos - 4, true);
+		case 2:
+			pos += 8;
+			return view.getFloat64(pos - 8, true);
+		case 3:
+			return true;
+		case 4:
+			return false;
+		case 5:
+			return string();
+		case 6:
+			pos += 4;
+			return objects[view.getUint32(pos - 4, true)];
+		case 7: {
+			const type = arrays[view.getUint8(pos)];
+			const count = view.getUint32(pos + 1, true);
+			const size = type.BYTES_PER_ELEMENT;
+			pos += 5;
+			pos += (size - pos % size) % size;
+			const start = offset + pos;
+			pos += count * size;
+			if (start % size === 0) {
+				return new type(buffer, start, count);
+			}
+			return new type(buffer.slice(start, start + count * size));
+		}
+		case 8: {
+			const count = view.getUint32(pos, true);
+			const values = [];
+			pos += 4;
+			for (let i = 0; i < count; i++) {
+				values.push(arg());
+			}
+			return values;
+		}
+		}
+		throw new Error("webgl: invalid command argument tag " + tag);
+	}
+
+	let failure = null;
+	while (pos < length) {
+		const method = view.getUint16(pos, true);
+		pos += 2;
+		if (method === 0xFFFF) {
+			methods.push(string());
+			continue;
+		}
+
+		const count = view.getUint8(pos);
+		const flags = view.getUint8(pos + 1);
+		pos += 2;
+		let result = 0;
+		let receiver = gl;
+		if (flags & 1) {
+			result = view.getUint32(pos, true);
+			pos += 4;
+		}
+		if (flags & 2) {
+			receiver = objects[view.getUint32(pos, true)];
+			pos += 4;
+		}
+		const args = [];
+		for (let i = 0; i < count; i++) {
+			args.push(arg());
+		}
+		if (failure !== null) {
+			continue;
+		}
+
+		let value;
+		try {
+			value = receiver[methods[method]](...args);
+		} catch (error) {
+			// The exception surfaces on the call flushing the stream, it names the buffered call
+			failure = new Error(methods[method] + ": " + error.message, { cause: error });
+			continue;
+		}
+		if (result !== 0) {
+			objects[result] = value;
+		}
+	}
+	if (failure !== null) {
+		throw failure;
+	}
+	return true;
+};
+`
@@ -0,0 +1,118 @@
+package backend
+
+import "strings"
+
+// WebGL object created by a pure Go backend
+type Object struct {
+	Kind    string // Name used by the create call, like "Buffer" or "Texture"
+	ID      uint32
+	Deleted bool
+}
+
+// Pure Go backend that renders nothing. It hands out object handles, tracks their deletion and
+// answers queries with zero values, so code using a RenderingContext runs natively without a
+// browser. Shaders always compile and programs always link.
+type Headless struct {
+	Width  int
+	Height int
+
+	lastID     uint32
+	attributes map[*Object]map[string]int
+}
+
+func NewHeadless(width, height int) *Headless {
+	return &Headless{
+		Width:      width,
+		Height:     height,
+		attributes: make(map[*Object]map[string]int),
+	}
+}
+
+// Creates a new object handle
+func (h *Headless) NewObject(kind string) *Object {
+	h.lastID++
+	return &Object{
+		Kind: kind,
+		ID:   h.lastID,
+	}
+}
+
+func (h *Headless) Call(method string, args ...interface{}) Value {
+	switch {
+	case strings.HasPrefix(method, "create"):
+		return ValueOf(h.NewObject(strings.TrimPrefix(method, "create")))
+	case method == "fenceSync":
+		return ValueOf(h.NewObject("Sync"))
+	case strings.HasPrefix(method, "delete"):
+		if object, ok := Unwrap(firstArg(args)).(*Object); ok {
+			object.Deleted = true
+		}
+		return undefined
+	case strings.HasPrefix(method, "is") && method != "isEnabled" && method != "isContextLost":
+		object, ok := Unwrap(firstArg(args)).(*Object)
+		return ValueOf(ok && !object.Deleted)
+	}
+
+	switch method {
+	case "getUniformLocation":
+		return ValueOf(h.NewObject("UniformLocation"))
+	case "getAttribLocation":
+		program, _ := Unwrap(args[0]).(*Object)
+		locations, ok := h.attributes[program]
+		if !ok {
+			locations = make(map[string]int)
+			h.attributes[program] = locations
+		}
+		name, _ := args[1].(string)
+		location, ok := locations[name]
+		if !ok {
+			location = len(locations)
+			locations[name] = location
+		}
+		return ValueOf(location)
+	case "getShaderParameter", "getProgramParameter":
+		switch toUint32(args[1]) {
+		case 0x8B81, 0x8B82, 0x8B83: // COMPILE_STATUS, LINK_STATUS, VALIDATE_STATUS
+			return ValueOf(true)
+		}
+		return ValueOf(0)
+	case "getShaderInfoLog", "getProgramInfoLog", "getShaderSource":
+		return ValueOf("")
+	case "getSupportedExtensions", "getAttachedShaders":
+		return ValueOf([]interface{}{})
+	case "getExtension":
+		return null
+	case "checkFramebufferStatus":
+		return ValueOf(0x8CD5) // FRAMEBUFFER_COMPLETE
+	case "clientWaitSync":
+		return ValueOf(0x911A) // ALREADY_SIGNALED
+	case "getError":
+		return ValueOf(0)
+	case "isEnabled", "isContextLost":
+		return ValueOf(false)
+	case "getParameter":
+		return null
+	}
+	return undefined
+}
+
+func (h *Headless) Get(property string) Value {
+	switch property {
+	case "drawingBufferWidth":
+		return ValueOf(h.Width)
+	case "drawingBufferHeight":
+		return ValueOf(h.Height)
+	}
+	return undefined
+}
+
+func firstArg(args []interface{}) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+func toUint32(arg interface{}) uint32 {
+	return uint32(ValueOf(arg).Int())
+}
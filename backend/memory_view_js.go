@@ -0,0 +1,109 @@
+package backend
+
+import (
+	"sync"
+	"syscall/js"
+	"unsafe"
+)
+
+// Calls reading their slices through views onto the wasm memory instead of copies
+var zeroCopyMethods = map[string]bool{
+	"bufferData":    true,
+	"bufferSubData": true,
+}
+
+// ArrayBuffer of the wasm linear memory. Growing the memory detaches it, it is captured again
+// when its length drops to zero.
+var wasmMemory struct {
+	mutex       sync.Mutex
+	buffer      js.Value
+	unsupported bool
+}
+
+// Returns the current memory.buffer of the Go instance, or undefined when the runtime does not let
+// it be reached, in which case slices are copied
+func memoryBuffer() js.Value {
+	wasmMemory.mutex.Lock()
+	defer wasmMemory.mutex.Unlock()
+
+	if wasmMemory.unsupported {
+		return js.Undefined()
+	}
+	if !wasmMemory.buffer.IsUndefined() && wasmMemory.buffer.Get("byteLength").Int() > 0 {
+		return wasmMemory.buffer
+	}
+
+	// CopyBytesToJS calls dst.set with a Uint8Array over the memory at the source slice. A probe
+	// whose set method is Array.prototype.push bound to an array catches that view.
+	captured := js.Global().Get("Array").New()
+	probe := js.Global().Get("Uint8Array").New(1)
+	probe.Set("set", captured.Get("push").Call("bind", captured))
+	js.CopyBytesToJS(probe, []byte{0})
+	if captured.Length() == 0 {
+		wasmMemory.unsupported = true
+		return js.Undefined()
+	}
+	wasmMemory.buffer = captured.Index(0).Get("buffer")
+	return wasmMemory.buffer
+}
+
+// Typed array over the memory of a numeric slice, nil when the slice must be copied instead
+func (m *marshaller) memoryViewOf(slice interface{}) interface{} {
+	var constructor string
+	var data unsafe.Pointer
+	var length, elemSize int
+	switch value := slice.(type) {
+	case []int8:
+		constructor, data, length, elemSize = "Int8Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 1
+	case []int16:
+		constructor, data, length, elemSize = "Int16Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 2
+	case []int32:
+		constructor, data, length, elemSize = "Int32Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 4
+	case []uint8:
+		constructor, data, length, elemSize = "Uint8Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 1
+	case []uint16:
+		constructor, data, length, elemSize = "Uint16Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 2
+	case []uint32:
+		constructor, data, length, elemSize = "Uint32Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 4
+	case []float32:
+		constructor, data, length, elemSize = "Float32Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 4
+	case []float64:
+		constructor, data, length, elemSize = "Float64Array", unsafe.Pointer(unsafe.SliceData(value)), len(value), 8
+	default:
+		// []int and []uint need a conversion to 32 bits
+		return nil
+	}
+
+	address := int(uintptr(data))
+	if length == 0 || address%elemSize != 0 {
+		return nil
+	}
+	buffer := memoryBuffer()
+	if buffer.IsUndefined() {
+		return nil
+	}
+	m.buffers = append(m.buffers, buffer)
+	return js.Global().Get(constructor).New(buffer, address, length)
+}
+
+// Calls a zero copy method unless a memory buffer under its views got detached, returning false
+// then. The memory grows when Go allocates after the views are created, the check runs in
+// JavaScript right before the call so a call never reads views that became empty.
+var callWithViews = js.Global().Get("Function").New("receiver", "method", "buffers", "args", `
+	for (const buffer of buffers) {
+		if (buffer.byteLength === 0) {
+			return false;
+		}
+	}
+	receiver[method](...args);
+	return true;
+`)
+
+// Runs a zero copy call, false when it did not run as the memory grew under its views
+func (m *marshaller) callWithViews(receiver js.Value, method string, argsJs []interface{}) bool {
+	buffers := make([]interface{}, len(m.buffers))
+	for i, buffer := range m.buffers {
+		buffers[i] = buffer
+	}
+	return callWithViews.Invoke(receiver, method, buffers, argsJs).Bool()
+}
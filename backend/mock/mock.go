@@ -0,0 +1,245 @@
+// Package mock provides a recording backend for unit tests. It logs every call made by a
+// RenderingContext with its typed arguments, hands out fake handles and answers queries
+// from configurable state, so rendering code can be tested without a browser:
+//
+//	recorder := mock.New()
+//	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+//	renderer.Draw(gl)
+//	err := recorder.ExpectCalls(
+//		mock.NewCall("useProgram", program),
+//		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 36),
+//	)
+package mock
+
+import (
+	"fmt"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"reflect"
+	"strings"
+)
+
+// A logged call, arguments keep the types they were passed with, like types.GLEnum or the handles
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+func NewCall(method string, args ...interface{}) Call {
+	return Call{
+		Method: method,
+		Args:   args,
+	}
+}
+
+func (call Call) String() string {
+	args := make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = formatArg(arg)
+	}
+	return call.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Compares two calls. Handles match when they wrap the same fake object, other arguments
+// are compared by their numeric value when both are numbers, or deeply otherwise.
+func (call Call) Equal(other Call) bool {
+	if call.Method != other.Method || len(call.Args) != len(other.Args) {
+		return false
+	}
+	for i := range call.Args {
+		if !argEqual(call.Args[i], other.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Backend recording every call. Objects are the fake handles of backend.Headless.
+type Recorder struct {
+	headless *backend.Headless
+
+	calls []Call
+
+	// Values returned by getParameter, missing names give null
+	Parameters map[types.GLEnum]interface{}
+	// Values returned by any other method, checked before the built-in answers
+	Results map[string]interface{}
+
+	// Answers to COMPILE_STATUS, LINK_STATUS and VALIDATE_STATUS, true by default
+	CompileStatus  bool
+	LinkStatus     bool
+	ValidateStatus bool
+
+	ShaderInfoLog  string
+	ProgramInfoLog string
+}
+
+func New() *Recorder {
+	return NewWithSize(300, 150)
+}
+
+// Creates a recorder with the given drawing buffer size
+func NewWithSize(width, height int) *Recorder {
+	return &Recorder{
+		headless:       backend.NewHeadless(width, height),
+		Parameters:     make(map[types.GLEnum]interface{}),
+		Results:        make(map[string]interface{}),
+		CompileStatus:  true,
+		LinkStatus:     true,
+		ValidateStatus: true,
+	}
+}
+
+func (r *Recorder) Call(method string, args ...interface{}) backend.Value {
+	r.calls = append(r.calls, Call{Method: method, Args: copyArgs(args)})
+
+	if result, ok := r.Results[method]; ok {
+		return toValue(result)
+	}
+
+	switch method {
+	case "getParameter":
+		if value, ok := r.Parameters[toEnum(args[0])]; ok {
+			return toValue(value)
+		}
+		return backend.Null()
+	case "getShaderParameter", "getProgramParameter":
+		switch toEnum(args[1]) {
+		case 0x8B81: // COMPILE_STATUS
+			return backend.ValueOf(r.CompileStatus)
+		case 0x8B82: // LINK_STATUS
+			return backend.ValueOf(r.LinkStatus)
+		case 0x8B83: // VALIDATE_STATUS
+			return backend.ValueOf(r.ValidateStatus)
+		}
+	case "getShaderInfoLog":
+		return backend.ValueOf(r.ShaderInfoLog)
+	case "getProgramInfoLog":
+		return backend.ValueOf(r.ProgramInfoLog)
+	}
+	return r.headless.Call(method, args...)
+}
+
+func (r *Recorder) Get(property string) backend.Value {
+	return r.headless.Get(property)
+}
+
+// Returns a copy of the calls recorded so far
+func (r *Recorder) Calls() []Call {
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// Returns the recorded calls to the given methods, in order
+func (r *Recorder) CallsTo(methods ...string) []Call {
+	var calls []Call
+	for _, call := range r.calls {
+		for _, method := range methods {
+			if call.Method == method {
+				calls = append(calls, call)
+				break
+			}
+		}
+	}
+	return calls
+}
+
+// Returns the names of the recorded methods, in order
+func (r *Recorder) Methods() []string {
+	methods := make([]string, len(r.calls))
+	for i, call := range r.calls {
+		methods[i] = call.Method
+	}
+	return methods
+}
+
+// Clears the call log, created objects and configured state are kept
+func (r *Recorder) Reset() {
+	r.calls = nil
+}
+
+// Checks that the recorded calls are exactly the expected ones, in order
+func (r *Recorder) ExpectCalls(expected ...Call) error {
+	return compareCalls(r.calls, expected)
+}
+
+// Checks that the calls to the methods of the expected calls happened in that order,
+// calls to other methods are ignored
+func (r *Recorder) ExpectCallsInOrder(expected ...Call) error {
+	var methods []string
+	for _, call := range expected {
+		methods = append(methods, call.Method)
+	}
+	return compareCalls(r.CallsTo(methods...), expected)
+}
+
+func compareCalls(actual []Call, expected []Call) error {
+	for i := 0; i < len(actual) || i < len(expected); i++ {
+		switch {
+		case i >= len(actual):
+			return fmt.Errorf("call %d: expected %s, got no call", i, expected[i])
+		case i >= len(expected):
+			return fmt.Errorf("call %d: unexpected %s", i, actual[i])
+		case !actual[i].Equal(expected[i]):
+			return fmt.Errorf("call %d: expected %s, got %s", i, expected[i], actual[i])
+		}
+	}
+	return nil
+}
+
+// Slices are copied, so the log keeps the data of the call even if the caller reuses its buffers
+func copyArgs(args []interface{}) []interface{} {
+	copied := make([]interface{}, len(args))
+	for i, arg := range args {
+		value := reflect.ValueOf(arg)
+		if value.Kind() == reflect.Slice && !value.IsNil() {
+			clone := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+			reflect.Copy(clone, value)
+			arg = clone.Interface()
+		}
+		copied[i] = arg
+	}
+	return copied
+}
+
+// Handles given as configured values are returned as the value they wrap
+func toValue(data interface{}) backend.Value {
+	if handle, ok := data.(backend.Handle); ok {
+		if value := handle.GetValue(); value != nil {
+			return value
+		}
+		return backend.Null()
+	}
+	return backend.ValueOf(data)
+}
+
+func toEnum(arg interface{}) types.GLEnum {
+	return types.GLEnum(backend.ValueOf(arg).Int())
+}
+
+func isNumber(arg interface{}) bool {
+	kind := reflect.ValueOf(arg).Kind()
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func argEqual(a, b interface{}) bool {
+	if isNumber(a) && isNumber(b) {
+		return backend.ValueOf(a).Float() == backend.ValueOf(b).Float()
+	}
+	return reflect.DeepEqual(backend.Unwrap(a), backend.Unwrap(b))
+}
+
+func formatArg(arg interface{}) string {
+	switch value := backend.Unwrap(arg).(type) {
+	case nil:
+		return "null"
+	case *backend.Object:
+		return fmt.Sprintf("%s#%d", value.Kind, value.ID)
+	case string:
+		return fmt.Sprintf("%q", value)
+	case types.GLEnum:
+		return fmt.Sprintf("0x%04X", uint32(value))
+	}
+	return fmt.Sprint(arg)
+}
@@ -0,0 +1,162 @@
+package mock_test
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/mock"
+	"testing"
+)
+
+func newContext() (*webgl.RenderingContext, *mock.Recorder) {
+	recorder := mock.New()
+	return webgl.WrapBackend(recorder, webgl.WebGL2), recorder
+}
+
+func expectError(t *testing.T, err error, message string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("got no error, expected %q", message)
+	}
+	if err.Error() != message {
+		t.Fatalf("got error %q, expected %q", err, message)
+	}
+}
+
+func TestExpectCalls(t *testing.T) {
+	gl, recorder := newContext()
+	program := gl.CreateProgram()
+	gl.UseProgram(program)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 36)
+
+	err := recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", program),
+		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 36),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Numbers match whatever their Go type
+	err = recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", program),
+		mock.NewCall("drawArrays", uint32(webgl.TRIANGLES), 0.0, int64(36)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpectCallsMismatches(t *testing.T) {
+	gl, recorder := newContext()
+	program := gl.CreateProgram()
+	gl.UseProgram(program)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 36)
+
+	expectError(t, recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", program),
+		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 3),
+	), "call 2: expected drawArrays(0x0004, 0, 3), got drawArrays(0x0004, 0, 36)")
+	expectError(t, recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", nil),
+	), "call 1: expected useProgram(null), got useProgram(Program#1)")
+	expectError(t, recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", program),
+	), "call 2: unexpected drawArrays(0x0004, 0, 36)")
+	expectError(t, recorder.ExpectCalls(
+		mock.NewCall("createProgram"),
+		mock.NewCall("useProgram", program),
+		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 36),
+		mock.NewCall("flush"),
+	), "call 3: expected flush(), got no call")
+}
+
+func TestExpectCallsInOrder(t *testing.T) {
+	gl, recorder := newContext()
+	buffer := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.Enable(webgl.DEPTH_TEST)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 3)
+	gl.DrawArrays(webgl.LINES, 0, 2)
+
+	err := recorder.ExpectCallsInOrder(
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, buffer),
+		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 3),
+		mock.NewCall("drawArrays", webgl.LINES, 0, 2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectError(t, recorder.ExpectCallsInOrder(
+		mock.NewCall("drawArrays", webgl.LINES, 0, 2),
+		mock.NewCall("drawArrays", webgl.TRIANGLES, 0, 3),
+	), "call 0: expected drawArrays(0x0001, 0, 2), got drawArrays(0x0004, 0, 3)")
+}
+
+func TestCallsToAndReset(t *testing.T) {
+	gl, recorder := newContext()
+	data := []float32{1, 2, 3}
+	gl.BufferData(webgl.ARRAY_BUFFER, data, webgl.STATIC_DRAW)
+	gl.Enable(webgl.BLEND)
+	gl.Disable(webgl.BLEND)
+	// The log keeps the data of the call
+	data[0] = 9
+
+	calls := recorder.CallsTo("bufferData", "disable")
+	if len(calls) != 2 || calls[0].Method != "bufferData" || calls[1].Method != "disable" {
+		t.Fatalf("got calls %v, expected bufferData then disable", calls)
+	}
+	if !calls[0].Equal(mock.NewCall("bufferData", webgl.ARRAY_BUFFER, []float32{1, 2, 3}, webgl.STATIC_DRAW)) {
+		t.Fatalf("got %s, expected the data given to BufferData", calls[0])
+	}
+	if methods := recorder.Methods(); len(methods) != 3 || methods[1] != "enable" {
+		t.Fatalf("got methods %v, expected bufferData, enable and disable", methods)
+	}
+
+	recorder.Reset()
+	if calls := recorder.Calls(); len(calls) != 0 {
+		t.Fatalf("got calls %v after Reset", calls)
+	}
+	// Objects created before the reset keep their handles
+	gl.CreateBuffer()
+	expectError(t, recorder.ExpectCalls(), "call 0: unexpected createBuffer()")
+}
+
+func TestConfiguredAnswers(t *testing.T) {
+	gl, recorder := newContext()
+	recorder.Parameters[webgl.MAX_TEXTURE_SIZE] = 4096
+	recorder.Results["getShaderInfoLog"] = "0:1: syntax error"
+	recorder.LinkStatus = false
+	recorder.ProgramInfoLog = "link failed"
+
+	if size := gl.GetParameterMaxTextureSize(); size != 4096 {
+		t.Errorf("got MAX_TEXTURE_SIZE %d, expected 4096", size)
+	}
+	if size := gl.GetParameterMaxRenderBufferSize(); size != 0 {
+		t.Errorf("got MAX_RENDERBUFFER_SIZE %d without a configured value, expected 0", size)
+	}
+
+	shader := gl.CreateShader(webgl.VERTEX_SHADER)
+	gl.CompileShader(shader)
+	if !gl.GetShaderParameterCompileStatus(shader) {
+		t.Error("shaders did not compile by default")
+	}
+	if log := gl.GetShaderInfoLog(shader); log != "0:1: syntax error" {
+		t.Errorf("got shader info log %q", log)
+	}
+
+	program := gl.CreateProgram()
+	gl.LinkProgram(program)
+	if gl.GetProgramParameterLinkStatus(program) {
+		t.Error("the program linked with LinkStatus false")
+	}
+	if log := gl.GetProgramInfoLog(program); log != "link failed" {
+		t.Errorf("got program info log %q", log)
+	}
+	if !gl.GetProgramParameter(program, webgl.VALIDATE_STATUS).Bool() {
+		t.Error("programs did not validate by default")
+	}
+}
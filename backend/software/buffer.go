@@ -0,0 +1,304 @@
+package software
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/types"
+	"math"
+)
+
+type buffer struct {
+	object
+	data []byte
+}
+
+type vertexAttrib struct {
+	enabled    bool
+	buffer     *buffer
+	size       int
+	dataType   types.GLEnum
+	normalized bool
+	stride     int
+	offset     int
+	divisor    int
+}
+
+type vertexArray struct {
+	object
+	attribs  [maxVertexAttribs]vertexAttrib
+	elements *buffer
+}
+
+func (r *Rasterizer) bindBuffer(target types.GLEnum, obj interface{}) {
+	buf, _ := obj.(*buffer)
+	if target == webgl.ELEMENT_ARRAY_BUFFER {
+		r.vertexArray.elements = buf
+	} else {
+		r.buffers[target] = buf
+	}
+}
+
+func (r *Rasterizer) boundBuffer(target types.GLEnum) *buffer {
+	if target == webgl.ELEMENT_ARRAY_BUFFER {
+		return r.vertexArray.elements
+	}
+	return r.buffers[target]
+}
+
+// Handles bufferData(target, size, usage) and bufferData(target, data, usage[, srcOffset[, length]])
+func (r *Rasterizer) bufferData(args []interface{}) {
+	buf := r.boundBuffer(argEnum(args, 0))
+	if buf == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	if isNumber(args[1]) {
+		buf.data = make([]byte, argInt(args, 1))
+		return
+	}
+	data, elementSize := toBytes(args[1])
+	buf.data = sliceElements(data, elementSize, args[3:])
+}
+
+// Handles bufferSubData(target, dstByteOffset, data[, srcOffset[, length]])
+func (r *Rasterizer) bufferSubData(args []interface{}) {
+	buf := r.boundBuffer(argEnum(args, 0))
+	if buf == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	offset := argInt(args, 1)
+	data, elementSize := toBytes(args[2])
+	data = sliceElements(data, elementSize, args[3:])
+	if offset < 0 || offset+len(data) > len(buf.data) {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	copy(buf.data[offset:], data)
+}
+
+func (r *Rasterizer) getBufferSubData(args []interface{}) {
+	buf := r.boundBuffer(argEnum(args, 0))
+	dst, ok := args[2].([]byte)
+	if buf == nil || !ok {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	offset := argInt(args, 1)
+	if offset < 0 || offset+len(dst) > len(buf.data) {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	copy(dst, buf.data[offset:])
+}
+
+// Applies the optional srcOffset and length arguments, counted in elements
+func sliceElements(data []byte, elementSize int, args []interface{}) []byte {
+	if len(args) > 0 {
+		start := argInt(args, 0) * elementSize
+		if start > len(data) {
+			start = len(data)
+		}
+		data = data[start:]
+	}
+	if len(args) > 1 && argInt(args, 1) > 0 {
+		length := argInt(args, 1) * elementSize
+		if length < len(data) {
+			data = data[:length]
+		}
+	}
+	return data
+}
+
+// Returns the little endian bytes of a typed slice and the size of its elements
+func toBytes(data interface{}) ([]byte, int) {
+	switch values := data.(type) {
+	case nil:
+		return nil, 1
+	case []byte:
+		return values, 1
+	case []int:
+		converted := make([]int32, len(values))
+		for i, value := range values {
+			converted[i] = int32(value)
+		}
+		data = converted
+	case []uint:
+		converted := make([]uint32, len(values))
+		for i, value := range values {
+			converted[i] = uint32(value)
+		}
+		data = converted
+	}
+	var encoded bytes.Buffer
+	if err := binary.Write(&encoded, binary.LittleEndian, data); err != nil {
+		return nil, 1
+	}
+	return encoded.Bytes(), elementSize(data)
+}
+
+func elementSize(data interface{}) int {
+	switch data.(type) {
+	case []int16, []uint16:
+		return 2
+	case []int32, []uint32, []float32:
+		return 4
+	case []float64:
+		return 8
+	}
+	return 1
+}
+
+func (r *Rasterizer) checkAttrib(index int) bool {
+	if index < 0 || index >= maxVertexAttribs {
+		r.setError(webgl.INVALID_VALUE)
+		return false
+	}
+	return true
+}
+
+// Handles vertexAttribPointer(index, size, type, normalized, stride, offset) and
+// vertexAttribIPointer(index, size, type, stride, offset)
+func (r *Rasterizer) vertexAttribPointer(method string, args []interface{}) {
+	index := argInt(args, 0)
+	if !r.checkAttrib(index) {
+		return
+	}
+	buf := r.buffers[webgl.ARRAY_BUFFER]
+	if buf == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	attrib := &r.vertexArray.attribs[index]
+	attrib.buffer = buf
+	attrib.size = argInt(args, 1)
+	attrib.dataType = argEnum(args, 2)
+	if method == "vertexAttribIPointer" {
+		attrib.normalized = false
+		attrib.stride, attrib.offset = argInt(args, 3), argInt(args, 4)
+	} else {
+		attrib.normalized = argBool(args, 3)
+		attrib.stride, attrib.offset = argInt(args, 4), argInt(args, 5)
+	}
+}
+
+// Sets the generic value used by disabled attribute arrays
+func (r *Rasterizer) vertexAttrib(method string, args []interface{}) {
+	index := argInt(args, 0)
+	if !r.checkAttrib(index) {
+		return
+	}
+	value := [4]float32{0, 0, 0, 1}
+	count := int(method[len("vertexAttrib")] - '0')
+	if method[len(method)-1] == 'v' {
+		values, _ := args[1].([]float32)
+		for i := 0; i < count && i < len(values); i++ {
+			value[i] = values[i]
+		}
+	} else {
+		for i := 0; i < count; i++ {
+			value[i] = argFloat(args, i+1)
+		}
+	}
+	r.genericAttribs[index] = value
+}
+
+// Reads the value of an attribute for a vertex, false when it lies outside of its buffer
+func (r *Rasterizer) fetchAttrib(location int, vertex int, instance int) ([4]float32, bool) {
+	attrib := &r.vertexArray.attribs[location]
+	if !attrib.enabled {
+		return r.genericAttribs[location], true
+	}
+
+	componentSize := typeSize(attrib.dataType)
+	stride := attrib.stride
+	if stride == 0 {
+		stride = componentSize * attrib.size
+	}
+	element := vertex
+	if attrib.divisor > 0 {
+		element = instance / attrib.divisor
+	}
+	start := attrib.offset + element*stride
+	if attrib.buffer == nil || start < 0 || start+componentSize*attrib.size > len(attrib.buffer.data) {
+		return [4]float32{}, false
+	}
+
+	value := [4]float32{0, 0, 0, 1}
+	for i := 0; i < attrib.size && i < 4; i++ {
+		value[i] = readComponent(attrib.buffer.data[start+i*componentSize:], attrib.dataType, attrib.normalized)
+	}
+	return value, true
+}
+
+func typeSize(dataType types.GLEnum) int {
+	switch dataType {
+	case webgl.BYTE, webgl.UNSIGNED_BYTE:
+		return 1
+	case webgl.SHORT, webgl.UNSIGNED_SHORT, webgl.HALF_FLOAT, halfFloatOES:
+		return 2
+	}
+	return 4
+}
+
+func readComponent(data []byte, dataType types.GLEnum, normalized bool) float32 {
+	switch dataType {
+	case webgl.BYTE:
+		value := float32(int8(data[0]))
+		if normalized {
+			return float32(math.Max(float64(value)/127, -1))
+		}
+		return value
+	case webgl.UNSIGNED_BYTE:
+		value := float32(data[0])
+		if normalized {
+			return value / 255
+		}
+		return value
+	case webgl.SHORT:
+		value := float32(int16(binary.LittleEndian.Uint16(data)))
+		if normalized {
+			return float32(math.Max(float64(value)/32767, -1))
+		}
+		return value
+	case webgl.UNSIGNED_SHORT:
+		value := float32(binary.LittleEndian.Uint16(data))
+		if normalized {
+			return value / 65535
+		}
+		return value
+	case webgl.INT:
+		value := float32(int32(binary.LittleEndian.Uint32(data)))
+		if normalized {
+			return float32(math.Max(float64(value)/2147483647, -1))
+		}
+		return value
+	case webgl.UNSIGNED_INT:
+		value := float32(binary.LittleEndian.Uint32(data))
+		if normalized {
+			return value / 4294967295
+		}
+		return value
+	case webgl.HALF_FLOAT, halfFloatOES:
+		return halfToFloat(binary.LittleEndian.Uint16(data))
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(data))
+}
+
+func halfToFloat(half uint16) float32 {
+	sign := uint32(half>>15) << 31
+	exponent := uint32(half>>10) & 0x1F
+	mantissa := uint32(half) & 0x3FF
+	switch exponent {
+	case 0:
+		value := float32(mantissa) / 1024 / 16384
+		if sign != 0 {
+			return -value
+		}
+		return value
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mantissa<<13)
+	}
+	return math.Float32frombits(sign | (exponent+112)<<23 | mantissa<<13)
+}
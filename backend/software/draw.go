@@ -0,0 +1,595 @@
+package software
+
+import (
+	"encoding/binary"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/types"
+	"math"
+)
+
+// Smallest w kept by clipping, avoids dividing by zero at the eye position
+const minClipW = 1e-6
+
+// Vertex shader output in clip space
+type clipVertex struct {
+	position  [4]float32
+	pointSize float32
+	varyings  []float32
+}
+
+// Vertex after the perspective division and the viewport transform. Varyings are
+// divided by w, so they interpolate linearly in window space.
+type windowVertex struct {
+	x, y, z   float32
+	invW      float32
+	varyings  []float32
+	pointSize float32
+}
+
+// State shared by the primitives of a draw call
+type pass struct {
+	r        *Rasterizer
+	program  *program
+	uniforms *Uniforms
+	target   renderTarget
+	rect     [4]int // Drawable pixels: x0, y0, x1, y1 exclusive
+}
+
+func (r *Rasterizer) drawArrays(mode types.GLEnum, first, count int, instances int) {
+	if first < 0 || count < 0 || instances < 0 {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = first + i
+	}
+	r.draw(mode, indices, instances)
+}
+
+func (r *Rasterizer) drawElements(mode types.GLEnum, count int, dataType types.GLEnum, offset int, instances int) {
+	elements := r.vertexArray.elements
+	if elements == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	if count < 0 || offset < 0 || instances < 0 {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	size := typeSize(dataType)
+	if offset%size != 0 || offset+count*size > len(elements.data) {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+
+	indices := make([]int, count)
+	for i := range indices {
+		data := elements.data[offset+i*size:]
+		switch dataType {
+		case webgl.UNSIGNED_BYTE:
+			indices[i] = int(data[0])
+		case webgl.UNSIGNED_SHORT:
+			indices[i] = int(binary.LittleEndian.Uint16(data))
+		default:
+			indices[i] = int(binary.LittleEndian.Uint32(data))
+		}
+	}
+	r.draw(mode, indices, instances)
+}
+
+func (r *Rasterizer) draw(mode types.GLEnum, indices []int, instances int) {
+	prog := r.program
+	if prog == nil || !prog.linked {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	target := r.drawTarget()
+	width, height, ok := target.size()
+	if !ok {
+		r.setError(webgl.INVALID_FRAMEBUFFER_OPERATION)
+		return
+	}
+
+	p := &pass{
+		r:        r,
+		program:  prog,
+		uniforms: &Uniforms{r: r, program: prog},
+		target:   target,
+		rect: [4]int{
+			maxInt(0, r.viewport[0]), maxInt(0, r.viewport[1]),
+			minInt(width, r.viewport[0]+r.viewport[2]), minInt(height, r.viewport[1]+r.viewport[3]),
+		},
+	}
+	if scissor := r.scissorRect(); scissor != nil {
+		p.rect[0], p.rect[1] = maxInt(p.rect[0], scissor[0]), maxInt(p.rect[1], scissor[1])
+		p.rect[2], p.rect[3] = minInt(p.rect[2], scissor[0]+scissor[2]), minInt(p.rect[3], scissor[1]+scissor[3])
+	}
+
+	attributes := prog.vertex.Attributes
+	for instance := 0; instance < instances; instance++ {
+		cache := make(map[int]*clipVertex)
+		vertices := make([]*clipVertex, len(indices))
+		for i, index := range indices {
+			vertex, ok := cache[index]
+			if !ok {
+				values := make([][4]float32, len(attributes))
+				for a, name := range attributes {
+					if values[a], ok = r.fetchAttrib(prog.attribs[name], index, instance); !ok {
+						r.setError(webgl.INVALID_OPERATION)
+						return
+					}
+				}
+				output := prog.vertex.Main(values, p.uniforms)
+				vertex = &clipVertex{position: output.Position, pointSize: output.PointSize, varyings: output.Varyings}
+				cache[index] = vertex
+			}
+			vertices[i] = vertex
+		}
+		if !p.assemble(mode, vertices) {
+			r.setError(webgl.INVALID_ENUM)
+			return
+		}
+	}
+}
+
+// Splits the vertices into primitives, false for an unknown mode
+func (p *pass) assemble(mode types.GLEnum, v []*clipVertex) bool {
+	n := len(v)
+	switch mode {
+	case webgl.POINTS:
+		for i := 0; i < n; i++ {
+			p.point(v[i])
+		}
+	case webgl.LINES:
+		for i := 0; i+1 < n; i += 2 {
+			p.line(v[i], v[i+1])
+		}
+	case webgl.LINE_STRIP, webgl.LINE_LOOP:
+		for i := 0; i+1 < n; i++ {
+			p.line(v[i], v[i+1])
+		}
+		if mode == webgl.LINE_LOOP && n > 1 {
+			p.line(v[n-1], v[0])
+		}
+	case webgl.TRIANGLES:
+		for i := 0; i+2 < n; i += 3 {
+			p.triangle(v[i], v[i+1], v[i+2])
+		}
+	case webgl.TRIANGLE_STRIP:
+		for i := 0; i+2 < n; i++ {
+			if i%2 == 0 {
+				p.triangle(v[i], v[i+1], v[i+2])
+			} else {
+				p.triangle(v[i+1], v[i], v[i+2])
+			}
+		}
+	case webgl.TRIANGLE_FAN:
+		for i := 1; i+1 < n; i++ {
+			p.triangle(v[0], v[i], v[i+1])
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// Signed distances to the near, far and w planes, inside when not negative
+func clipDistances(position [4]float32) [3]float32 {
+	return [3]float32{position[2] + position[3], position[3] - position[2], position[3] - minClipW}
+}
+
+func lerpVertex(a, b *clipVertex, t float32) *clipVertex {
+	v := &clipVertex{pointSize: a.pointSize, varyings: make([]float32, len(a.varyings))}
+	for i := range v.position {
+		v.position[i] = a.position[i] + (b.position[i]-a.position[i])*t
+	}
+	for i := range v.varyings {
+		if i < len(b.varyings) {
+			v.varyings[i] = a.varyings[i] + (b.varyings[i]-a.varyings[i])*t
+		}
+	}
+	return v
+}
+
+// Clips a polygon against the near and far planes. Primitives are not clipped on x and y,
+// the drawable rectangle bounds their rasterization instead.
+func clipPolygon(polygon []*clipVertex) []*clipVertex {
+	for plane := 0; plane < 3 && len(polygon) > 0; plane++ {
+		var clipped []*clipVertex
+		for i, current := range polygon {
+			next := polygon[(i+1)%len(polygon)]
+			dc, dn := clipDistances(current.position)[plane], clipDistances(next.position)[plane]
+			if dc >= 0 {
+				clipped = append(clipped, current)
+			}
+			if (dc >= 0) != (dn >= 0) {
+				clipped = append(clipped, lerpVertex(current, next, dc/(dc-dn)))
+			}
+		}
+		polygon = clipped
+	}
+	return polygon
+}
+
+func (p *pass) toWindow(v *clipVertex) *windowVertex {
+	r := p.r
+	invW := 1 / v.position[3]
+	w := &windowVertex{
+		x:         float32(r.viewport[0]) + (v.position[0]*invW+1)*float32(r.viewport[2])/2,
+		y:         float32(r.viewport[1]) + (v.position[1]*invW+1)*float32(r.viewport[3])/2,
+		z:         r.depthRange[0] + (v.position[2]*invW+1)/2*(r.depthRange[1]-r.depthRange[0]),
+		invW:      invW,
+		varyings:  make([]float32, len(v.varyings)),
+		pointSize: v.pointSize,
+	}
+	for i, value := range v.varyings {
+		w.varyings[i] = value * invW
+	}
+	return w
+}
+
+func (p *pass) triangle(a, b, c *clipVertex) {
+	polygon := clipPolygon([]*clipVertex{a, b, c})
+	if len(polygon) < 3 {
+		return
+	}
+	vertices := make([]*windowVertex, len(polygon))
+	for i, v := range polygon {
+		vertices[i] = p.toWindow(v)
+	}
+
+	var area float32
+	for i, v := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		area += v.x*next.y - next.x*v.y
+	}
+	if area == 0 {
+		return
+	}
+	r := p.r
+	front := (area > 0) == (r.frontFace == webgl.CCW)
+	if r.caps[webgl.CULL_FACE] {
+		if r.cullFace == webgl.FRONT_AND_BACK || (r.cullFace == webgl.FRONT) == front {
+			return
+		}
+	}
+	for i := 1; i+1 < len(vertices); i++ {
+		p.fillTriangle(vertices[0], vertices[i], vertices[i+1], front)
+	}
+}
+
+func edge(a, b *windowVertex, x, y float32) float32 {
+	return (b.x-a.x)*(y-a.y) - (b.y-a.y)*(x-a.x)
+}
+
+// Top-left fill rule for counter clockwise triangles with y pointing up
+func isTopLeft(a, b *windowVertex) bool {
+	return (a.y == b.y && b.x < a.x) || b.y < a.y
+}
+
+func (p *pass) fillTriangle(v0, v1, v2 *windowVertex, front bool) {
+	area := edge(v0, v1, v2.x, v2.y)
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		v1, v2 = v2, v1
+		area = -area
+	}
+
+	var offset float32
+	if p.r.caps[webgl.POLYGON_OFFSET_FILL] {
+		dzdx := ((v1.z-v0.z)*(v2.y-v0.y) - (v2.z-v0.z)*(v1.y-v0.y)) / area
+		dzdy := ((v2.z-v0.z)*(v1.x-v0.x) - (v1.z-v0.z)*(v2.x-v0.x)) / area
+		offset = p.r.polygonFactor*float32(math.Max(math.Abs(float64(dzdx)), math.Abs(float64(dzdy)))) +
+			p.r.polygonUnits/(1<<24)
+	}
+
+	minX := maxInt(p.rect[0], int(math.Floor(float64(min3(v0.x, v1.x, v2.x)))))
+	maxX := minInt(p.rect[2]-1, int(math.Ceil(float64(max3(v0.x, v1.x, v2.x)))))
+	minY := maxInt(p.rect[1], int(math.Floor(float64(min3(v0.y, v1.y, v2.y)))))
+	maxY := minInt(p.rect[3]-1, int(math.Ceil(float64(max3(v0.y, v1.y, v2.y)))))
+	topLeft := [3]bool{isTopLeft(v1, v2), isTopLeft(v2, v0), isTopLeft(v0, v1)}
+	varyings := make([]float32, len(v0.varyings))
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			cx, cy := float32(x)+0.5, float32(y)+0.5
+			weights := [3]float32{edge(v1, v2, cx, cy), edge(v2, v0, cx, cy), edge(v0, v1, cx, cy)}
+			inside := true
+			for i, weight := range weights {
+				if weight < 0 || (weight == 0 && !topLeft[i]) {
+					inside = false
+					break
+				}
+			}
+			if !inside {
+				continue
+			}
+
+			l0, l1, l2 := weights[0]/area, weights[1]/area, weights[2]/area
+			invW := l0*v0.invW + l1*v1.invW + l2*v2.invW
+			for i := range varyings {
+				varyings[i] = (l0*v0.varyings[i] + l1*v1.varyings[i] + l2*v2.varyings[i]) / invW
+			}
+			z := l0*v0.z + l1*v1.z + l2*v2.z + offset
+			p.shade(x, y, z, invW, varyings, front, [2]float32{})
+		}
+	}
+}
+
+// Clips a segment against the near and far planes
+func clipSegment(a, b *clipVertex) (*clipVertex, *clipVertex, bool) {
+	t0, t1 := float32(0), float32(1)
+	da, db := clipDistances(a.position), clipDistances(b.position)
+	for plane := range da {
+		switch {
+		case da[plane] < 0 && db[plane] < 0:
+			return nil, nil, false
+		case da[plane] < 0:
+			t0 = float32(math.Max(float64(t0), float64(da[plane]/(da[plane]-db[plane]))))
+		case db[plane] < 0:
+			t1 = float32(math.Min(float64(t1), float64(da[plane]/(da[plane]-db[plane]))))
+		}
+	}
+	if t0 > t1 {
+		return nil, nil, false
+	}
+	return lerpVertex(a, b, t0), lerpVertex(a, b, t1), true
+}
+
+// Rasterizes a line one pixel per column, or per row for steep lines, widened by the line width
+func (p *pass) line(a, b *clipVertex) {
+	a, b, ok := clipSegment(a, b)
+	if !ok {
+		return
+	}
+	wa, wb := p.toWindow(a), p.toWindow(b)
+	xMajor := math.Abs(float64(wb.x-wa.x)) >= math.Abs(float64(wb.y-wa.y))
+	major := func(v *windowVertex) (float32, float32) {
+		if xMajor {
+			return v.x, v.y
+		}
+		return v.y, v.x
+	}
+	startMajor, startMinor := major(wa)
+	endMajor, endMinor := major(wb)
+	if startMajor > endMajor {
+		wa, wb = wb, wa
+		startMajor, startMinor, endMajor, endMinor = endMajor, endMinor, startMajor, startMinor
+	}
+	length := endMajor - startMajor
+	if length == 0 {
+		return
+	}
+
+	width := maxInt(1, int(math.Round(float64(p.r.lineWidth))))
+	varyings := make([]float32, len(wa.varyings))
+	for m := int(math.Ceil(float64(startMajor - 0.5))); float32(m)+0.5 < endMajor; m++ {
+		t := (float32(m) + 0.5 - startMajor) / length
+		minor := int(math.Floor(float64(startMinor + t*(endMinor-startMinor))))
+		invW := wa.invW + (wb.invW-wa.invW)*t
+		for i := range varyings {
+			varyings[i] = (wa.varyings[i] + (wb.varyings[i]-wa.varyings[i])*t) / invW
+		}
+		z := wa.z + (wb.z-wa.z)*t
+		for k := 0; k < width; k++ {
+			offset := minor + k - (width-1)/2
+			if xMajor {
+				p.shade(m, offset, z, invW, varyings, true, [2]float32{})
+			} else {
+				p.shade(offset, m, z, invW, varyings, true, [2]float32{})
+			}
+		}
+	}
+}
+
+// Rasterizes a square point of PointSize pixels, discarded when its center is clipped
+func (p *pass) point(v *clipVertex) {
+	position := v.position
+	for i := 0; i < 3; i++ {
+		if position[i] < -position[3] || position[i] > position[3] {
+			return
+		}
+	}
+	if position[3] < minClipW {
+		return
+	}
+	w := p.toWindow(v)
+	size := w.pointSize
+	if size <= 0 {
+		size = 1
+	}
+	left, bottom := w.x-size/2, w.y-size/2
+	startX, endX := int(math.Ceil(float64(left-0.5))), int(math.Ceil(float64(left+size-0.5)))
+	startY, endY := int(math.Ceil(float64(bottom-0.5))), int(math.Ceil(float64(bottom+size-0.5)))
+	for y := startY; y < endY; y++ {
+		for x := startX; x < endX; x++ {
+			pointCoord := [2]float32{(float32(x) + 0.5 - left) / size, 1 - (float32(y)+0.5-bottom)/size}
+			p.shade(x, y, w.z, w.invW, v.varyings, true, pointCoord)
+		}
+	}
+}
+
+// Runs the fragment shader and the per fragment operations: stencil and depth tests, blending
+// and the masked write to the color buffer
+func (p *pass) shade(x, y int, z, invW float32, varyings []float32, front bool, pointCoord [2]float32) {
+	if x < p.rect[0] || y < p.rect[1] || x >= p.rect[2] || y >= p.rect[3] {
+		return
+	}
+	fragment := &Fragment{
+		Varyings:    varyings,
+		Coord:       [4]float32{float32(x) + 0.5, float32(y) + 0.5, z, invW},
+		FrontFacing: front,
+		PointCoord:  pointCoord,
+	}
+	color, discard := p.program.fragment(fragment, p.uniforms)
+	if discard {
+		return
+	}
+
+	r := p.r
+	z = clamp01(z)
+	face := 0
+	if !front {
+		face = 1
+	}
+	stencil := &r.stencil[face]
+	var stencilPixel []float32
+	if r.caps[webgl.STENCIL_TEST] && p.target.stencil != nil {
+		stencilPixel = p.target.stencil.pixel(x, y)
+		stored := uint8(stencilPixel[1])
+		ref := uint8(stencil.ref)
+		if !compare(stencil.function, float32(ref&stencil.valueMask), float32(stored&stencil.valueMask)) {
+			updateStencil(stencilPixel, stencil, stencil.fail)
+			return
+		}
+	}
+	if r.caps[webgl.DEPTH_TEST] && p.target.depth != nil {
+		depthPixel := p.target.depth.pixel(x, y)
+		if !compare(r.depthFunc, z, depthPixel[0]) {
+			updateStencil(stencilPixel, stencil, stencil.zFail)
+			return
+		}
+		if r.depthMask {
+			depthPixel[0] = z
+		}
+	}
+	updateStencil(stencilPixel, stencil, stencil.zPass)
+
+	if p.target.color == nil {
+		return
+	}
+	if r.caps[webgl.BLEND] {
+		color = r.blend(color, p.target.color.texel(x, y), p.target.color.levels[0] > 0)
+	}
+	p.target.color.storeColor(x, y, color, r.colorMask)
+}
+
+func compare(function types.GLEnum, value, stored float32) bool {
+	switch function {
+	case webgl.NEVER:
+		return false
+	case webgl.LESS:
+		return value < stored
+	case webgl.EQUAL:
+		return value == stored
+	case webgl.LEQUAL:
+		return value <= stored
+	case webgl.GREATER:
+		return value > stored
+	case webgl.NOTEQUAL:
+		return value != stored
+	case webgl.GEQUAL:
+		return value >= stored
+	}
+	return true
+}
+
+func updateStencil(pixel []float32, stencil *stencilState, operation types.GLEnum) {
+	if pixel == nil {
+		return
+	}
+	stored := uint8(pixel[1])
+	value := stored
+	switch operation {
+	case webgl.ZERO:
+		value = 0
+	case webgl.REPLACE:
+		value = uint8(stencil.ref)
+	case webgl.INCR:
+		if value < 0xFF {
+			value++
+		}
+	case webgl.DECR:
+		if value > 0 {
+			value--
+		}
+	case webgl.INVERT:
+		value = ^value
+	case webgl.INCR_WRAP:
+		value++
+	case webgl.DECR_WRAP:
+		value--
+	}
+	pixel[1] = float32(stored&^stencil.writeMask | value&stencil.writeMask)
+}
+
+// Blends a fragment color with the stored one, normalized targets clamp the inputs to [0, 1]
+func (r *Rasterizer) blend(src, dst [4]float32, normalized bool) [4]float32 {
+	constant := r.blendColor
+	if normalized {
+		for i := range src {
+			src[i], constant[i] = clamp01(src[i]), clamp01(constant[i])
+		}
+	}
+
+	var result [4]float32
+	for i := range result {
+		srcFactor, dstFactor, equation := r.blendSrcRGB, r.blendDstRGB, r.blendEqRGB
+		if i == 3 {
+			srcFactor, dstFactor, equation = r.blendSrcAlpha, r.blendDstAlpha, r.blendEqAlpha
+		}
+		s := src[i] * blendFactor(srcFactor, src, dst, constant, i)
+		d := dst[i] * blendFactor(dstFactor, src, dst, constant, i)
+		switch equation {
+		case webgl.FUNC_SUBTRACT:
+			result[i] = s - d
+		case webgl.FUNC_REVERSE_SUBTRACT:
+			result[i] = d - s
+		case webgl.MIN:
+			result[i] = float32(math.Min(float64(src[i]), float64(dst[i])))
+		case webgl.MAX:
+			result[i] = float32(math.Max(float64(src[i]), float64(dst[i])))
+		default:
+			result[i] = s + d
+		}
+	}
+	return result
+}
+
+func blendFactor(factor types.GLEnum, src, dst, constant [4]float32, channel int) float32 {
+	switch factor {
+	case webgl.ZERO:
+		return 0
+	case webgl.SRC_COLOR:
+		return src[channel]
+	case webgl.ONE_MINUS_SRC_COLOR:
+		return 1 - src[channel]
+	case webgl.DST_COLOR:
+		return dst[channel]
+	case webgl.ONE_MINUS_DST_COLOR:
+		return 1 - dst[channel]
+	case webgl.SRC_ALPHA:
+		return src[3]
+	case webgl.ONE_MINUS_SRC_ALPHA:
+		return 1 - src[3]
+	case webgl.DST_ALPHA:
+		return dst[3]
+	case webgl.ONE_MINUS_DST_ALPHA:
+		return 1 - dst[3]
+	case webgl.CONSTANT_COLOR:
+		return constant[channel]
+	case webgl.ONE_MINUS_CONSTANT_COLOR:
+		return 1 - constant[channel]
+	case webgl.CONSTANT_ALPHA:
+		return constant[3]
+	case webgl.ONE_MINUS_CONSTANT_ALPHA:
+		return 1 - constant[3]
+	case webgl.SRC_ALPHA_SATURATE:
+		if channel == 3 {
+			return 1
+		}
+		return float32(math.Min(float64(src[3]), float64(1-dst[3])))
+	}
+	return 1
+}
+
+func min3(a, b, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a, b, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
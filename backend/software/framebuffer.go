@@ -0,0 +1,424 @@
+package software
+
+import (
+	"encoding/binary"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+)
+
+type renderbuffer struct {
+	object
+	surface *surface
+}
+
+// Texture level or renderbuffer attached to a framebuffer
+type attachment struct {
+	texture      *texture
+	face         int
+	level        int
+	renderbuffer *renderbuffer
+}
+
+func (a attachment) surface() *surface {
+	if a.texture != nil {
+		return a.texture.level(a.face, a.level)
+	}
+	if a.renderbuffer != nil {
+		return a.renderbuffer.surface
+	}
+	return nil
+}
+
+type framebuffer struct {
+	object
+	color   attachment
+	depth   attachment
+	stencil attachment
+
+	// Surfaces of the default framebuffer
+	defaultColor        *surface
+	defaultDepthStencil *surface
+}
+
+// Surfaces drawn to or read from, nil when not attached
+type renderTarget struct {
+	color   *surface
+	depth   *surface
+	stencil *surface
+}
+
+func (fb *framebuffer) target() renderTarget {
+	if fb.defaultColor != nil {
+		return renderTarget{color: fb.defaultColor, depth: fb.defaultDepthStencil, stencil: fb.defaultDepthStencil}
+	}
+	target := renderTarget{color: fb.color.surface(), depth: fb.depth.surface(), stencil: fb.stencil.surface()}
+	if target.depth != nil && !target.depth.depth {
+		target.depth = nil
+	}
+	if target.stencil != nil && !target.stencil.stencil {
+		target.stencil = nil
+	}
+	return target
+}
+
+// Returns the size shared by the attachments, false when there is none or they differ
+func (t renderTarget) size() (int, int, bool) {
+	width, height, found := 0, 0, false
+	for _, s := range []*surface{t.color, t.depth, t.stencil} {
+		if s == nil {
+			continue
+		}
+		if found && (s.width != width || s.height != height) {
+			return 0, 0, false
+		}
+		width, height, found = s.width, s.height, true
+	}
+	return width, height, found
+}
+
+// Clears every attached surface inside the optional rectangle, nil clears all of them
+func (fb *framebuffer) clear(rect *[4]int, clearColor [4]float32, clearDepth float32, clearStencil int) {
+	t := fb.target()
+	t.clear(rect, &clearColor, &clearDepth, &clearStencil, [4]bool{true, true, true, true}, 0xFF)
+}
+
+func (t renderTarget) clear(rect *[4]int, clearColor *[4]float32, clearDepth *float32, clearStencil *int, colorMask [4]bool, stencilMask uint8) {
+	width, height, ok := t.size()
+	if !ok {
+		return
+	}
+	x0, y0, x1, y1 := 0, 0, width, height
+	if rect != nil {
+		x0, y0 = maxInt(x0, rect[0]), maxInt(y0, rect[1])
+		x1, y1 = minInt(x1, rect[0]+rect[2]), minInt(y1, rect[1]+rect[3])
+	}
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if clearColor != nil && t.color != nil {
+				t.color.storeColor(x, y, *clearColor, colorMask)
+			}
+			if clearDepth != nil && t.depth != nil {
+				t.depth.pixel(x, y)[0] = clamp01(*clearDepth)
+			}
+			if clearStencil != nil && t.stencil != nil {
+				pixel := t.stencil.pixel(x, y)
+				stored := uint8(pixel[1])
+				pixel[1] = float32(stored&^stencilMask | uint8(*clearStencil)&stencilMask)
+			}
+		}
+	}
+}
+
+func (r *Rasterizer) drawTarget() renderTarget {
+	if r.drawFramebuffer != nil {
+		return r.drawFramebuffer.target()
+	}
+	return r.defaultFramebuffer.target()
+}
+
+func (r *Rasterizer) readTarget() renderTarget {
+	if r.readFramebuffer != nil {
+		return r.readFramebuffer.target()
+	}
+	return r.defaultFramebuffer.target()
+}
+
+// Returns the scissor box when the scissor test is enabled
+func (r *Rasterizer) scissorRect() *[4]int {
+	if !r.caps[webgl.SCISSOR_TEST] {
+		return nil
+	}
+	rect := r.scissor
+	return &rect
+}
+
+func (r *Rasterizer) clear(mask int) {
+	target := r.drawTarget()
+	var clearColor *[4]float32
+	var clearDepth *float32
+	var clearStencil *int
+	if mask&int(webgl.COLOR_BUFFER_BIT) != 0 {
+		clearColor = &r.clearColor
+	}
+	if mask&int(webgl.DEPTH_BUFFER_BIT) != 0 && r.depthMask {
+		clearDepth = &r.clearDepth
+	}
+	if mask&int(webgl.STENCIL_BUFFER_BIT) != 0 {
+		clearStencil = &r.clearStencil
+	}
+	target.clear(r.scissorRect(), clearColor, clearDepth, clearStencil, r.colorMask, r.stencil[0].writeMask)
+}
+
+// Handles clearBufferfv, clearBufferiv, clearBufferuiv and clearBufferfi for the first draw buffer
+func (r *Rasterizer) clearBuffer(method string, args []interface{}) {
+	target := r.drawTarget()
+	if argInt(args, 1) != 0 {
+		return
+	}
+	values := toFloats(args[2])
+	switch argEnum(args, 0) {
+	case webgl.COLOR:
+		var clearColor [4]float32
+		copy(clearColor[:], values)
+		target.clear(r.scissorRect(), &clearColor, nil, nil, r.colorMask, 0)
+	case webgl.DEPTH:
+		if len(values) > 0 && r.depthMask {
+			target.clear(r.scissorRect(), nil, &values[0], nil, r.colorMask, 0)
+		}
+	case webgl.STENCIL:
+		if len(values) > 0 {
+			stencil := int(values[0])
+			target.clear(r.scissorRect(), nil, nil, &stencil, r.colorMask, r.stencil[0].writeMask)
+		}
+	case webgl.DEPTH_STENCIL:
+		depth, stencil := argFloat(args, 2), argInt(args, 3)
+		if !r.depthMask {
+			target.clear(r.scissorRect(), nil, nil, &stencil, r.colorMask, r.stencil[0].writeMask)
+		} else {
+			target.clear(r.scissorRect(), nil, &depth, &stencil, r.colorMask, r.stencil[0].writeMask)
+		}
+	}
+}
+
+func (r *Rasterizer) bindFramebuffer(target types.GLEnum, obj interface{}) {
+	fb, _ := obj.(*framebuffer)
+	switch target {
+	case webgl.FRAMEBUFFER:
+		r.drawFramebuffer, r.readFramebuffer = fb, fb
+	case webgl.DRAW_FRAMEBUFFER:
+		r.drawFramebuffer = fb
+	case webgl.READ_FRAMEBUFFER:
+		r.readFramebuffer = fb
+	default:
+		r.setError(webgl.INVALID_ENUM)
+	}
+}
+
+func (r *Rasterizer) boundFramebuffer(target types.GLEnum) *framebuffer {
+	if target == webgl.READ_FRAMEBUFFER {
+		return r.readFramebuffer
+	}
+	return r.drawFramebuffer
+}
+
+func (r *Rasterizer) attach(target types.GLEnum, attachmentPoint types.GLEnum, value attachment) {
+	fb := r.boundFramebuffer(target)
+	if fb == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	switch attachmentPoint {
+	case webgl.COLOR_ATTACHMENT0:
+		fb.color = value
+	case webgl.DEPTH_ATTACHMENT:
+		fb.depth = value
+	case webgl.STENCIL_ATTACHMENT:
+		fb.stencil = value
+	case webgl.DEPTH_STENCIL_ATTACHMENT:
+		fb.depth, fb.stencil = value, value
+	default:
+		r.setError(webgl.INVALID_ENUM)
+	}
+}
+
+// Handles framebufferTexture2D(target, attachment, texTarget, texture, level)
+func (r *Rasterizer) framebufferTexture2D(args []interface{}) {
+	tex, _ := argObject(args, 3).(*texture)
+	value := attachment{}
+	if tex != nil {
+		value = attachment{texture: tex, face: faceIndex(argEnum(args, 2)), level: argInt(args, 4)}
+	}
+	r.attach(argEnum(args, 0), argEnum(args, 1), value)
+}
+
+// Handles framebufferRenderbuffer(target, attachment, renderbufferTarget, renderbuffer)
+func (r *Rasterizer) framebufferRenderbuffer(args []interface{}) {
+	rb, _ := argObject(args, 3).(*renderbuffer)
+	r.attach(argEnum(args, 0), argEnum(args, 1), attachment{renderbuffer: rb})
+}
+
+// Detaches a deleted texture or renderbuffer from the bound framebuffers, as attachments of
+// other framebuffers stay until they are replaced
+func (r *Rasterizer) detach(obj deletable) {
+	for _, fb := range []*framebuffer{r.drawFramebuffer, r.readFramebuffer} {
+		if fb == nil {
+			continue
+		}
+		for _, a := range []*attachment{&fb.color, &fb.depth, &fb.stencil} {
+			if (a.texture != nil && deletable(a.texture) == obj) || (a.renderbuffer != nil && deletable(a.renderbuffer) == obj) {
+				*a = attachment{}
+			}
+		}
+	}
+	if rb, ok := obj.(*renderbuffer); ok && r.renderbuffer == rb {
+		r.renderbuffer = nil
+	}
+}
+
+func (r *Rasterizer) framebufferStatus(target types.GLEnum) types.GLEnum {
+	fb := r.boundFramebuffer(target)
+	if fb == nil {
+		return webgl.FRAMEBUFFER_COMPLETE
+	}
+	attachments := []attachment{fb.color, fb.depth, fb.stencil}
+	empty := true
+	for _, a := range attachments {
+		if a.texture == nil && a.renderbuffer == nil {
+			continue
+		}
+		empty = false
+		if s := a.surface(); s == nil || s.width == 0 || s.height == 0 {
+			return webgl.FRAMEBUFFER_INCOMPLETE_ATTACHMENT
+		}
+	}
+	if empty {
+		return webgl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT
+	}
+	if _, _, ok := fb.target().size(); !ok {
+		return webgl.FRAMEBUFFER_INCOMPLETE_DIMENSIONS
+	}
+	return webgl.FRAMEBUFFER_COMPLETE
+}
+
+// Handles readPixels(x, y, width, height, format, type, pixels[, dstOffset]) for RGBA and RGB
+// with UNSIGNED_BYTE or FLOAT, pixels being a typed slice or an offset into PIXEL_PACK_BUFFER.
+// Pixels outside of the framebuffer are left untouched.
+func (r *Rasterizer) readPixels(args []interface{}) {
+	source := r.readTarget().color
+	if source == nil {
+		r.setError(webgl.INVALID_FRAMEBUFFER_OPERATION)
+		return
+	}
+	x, y, width, height := argInt(args, 0), argInt(args, 1), argInt(args, 2), argInt(args, 3)
+	format, dataType := argEnum(args, 4), argEnum(args, 5)
+	components := formatComponents(format)
+	if (format != webgl.RGBA && format != webgl.RGB) || (dataType != webgl.UNSIGNED_BYTE && dataType != webgl.FLOAT) {
+		r.setError(webgl.INVALID_ENUM)
+		return
+	}
+
+	componentSize := typeSize(dataType)
+	rowSize := width * components * componentSize
+	if align := r.packAlign; align > 1 && rowSize%align != 0 {
+		rowSize += align - rowSize%align
+	}
+
+	// Destination bytes, floats are encoded back into float slices at the end
+	var data []byte
+	var floats []float32
+	offset := argInt(args, 7)
+	switch pixels := args[6].(type) {
+	case []byte:
+		data = pixels[minInt(offset, len(pixels)):]
+	case []float32:
+		floats = pixels[minInt(offset, len(pixels)):]
+		data = make([]byte, len(floats)*4)
+		for i, value := range floats {
+			binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(value))
+		}
+	default:
+		buf := r.buffers[webgl.PIXEL_PACK_BUFFER]
+		if buf == nil || !isNumber(args[6]) {
+			r.setError(webgl.INVALID_OPERATION)
+			return
+		}
+		data = buf.data[minInt(backend.ValueOf(args[6]).Int(), len(buf.data)):]
+	}
+	if (height-1)*rowSize+width*components*componentSize > len(data) {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+
+	for row := 0; row < height; row++ {
+		for column := 0; column < width; column++ {
+			sx, sy := x+column, y+row
+			if sx < 0 || sy < 0 || sx >= source.width || sy >= source.height {
+				continue
+			}
+			texel := source.texel(sx, sy)
+			base := row*rowSize + column*components*componentSize
+			for c := 0; c < components; c++ {
+				if dataType == webgl.UNSIGNED_BYTE {
+					data[base+c] = uint8(math.Round(float64(clamp01(texel[c]) * 255)))
+				} else {
+					binary.LittleEndian.PutUint32(data[base+c*4:], math.Float32bits(texel[c]))
+				}
+			}
+		}
+	}
+
+	for i := range floats {
+		floats[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+}
+
+// Returns the drawing buffer as an image with non premultiplied colors, top row first
+func (r *Rasterizer) Image() *image.NRGBA {
+	source := r.defaultFramebuffer.defaultColor
+	img := image.NewNRGBA(image.Rect(0, 0, source.width, source.height))
+	for y := 0; y < source.height; y++ {
+		for x := 0; x < source.width; x++ {
+			texel := source.texel(x, y)
+			img.SetNRGBA(x, source.height-1-y, color.NRGBA{
+				R: uint8(math.Round(float64(clamp01(texel[0]) * 255))),
+				G: uint8(math.Round(float64(clamp01(texel[1]) * 255))),
+				B: uint8(math.Round(float64(clamp01(texel[2]) * 255))),
+				A: uint8(math.Round(float64(clamp01(texel[3]) * 255))),
+			})
+		}
+	}
+	return img
+}
+
+// Writes the drawing buffer to a PNG file, useful to create or update golden images
+func (r *Rasterizer) SavePNG(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, r.Image()); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Counts the pixels whose channels differ by more than tolerance, in 8 bits units.
+// Images of different sizes differ on every pixel of the larger one.
+func CompareImages(got, want image.Image, tolerance uint8) int {
+	if got.Bounds().Size() != want.Bounds().Size() {
+		size := got.Bounds().Size()
+		if other := want.Bounds().Size(); other.X*other.Y > size.X*size.Y {
+			size = other
+		}
+		return size.X * size.Y
+	}
+
+	mismatched := 0
+	gotMin, wantMin := got.Bounds().Min, want.Bounds().Min
+	size := got.Bounds().Size()
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			a := color.NRGBAModel.Convert(got.At(gotMin.X+x, gotMin.Y+y)).(color.NRGBA)
+			b := color.NRGBAModel.Convert(want.At(wantMin.X+x, wantMin.Y+y)).(color.NRGBA)
+			if channelDiff(a.R, b.R) > tolerance || channelDiff(a.G, b.G) > tolerance ||
+				channelDiff(a.B, b.B) > tolerance || channelDiff(a.A, b.A) > tolerance {
+				mismatched++
+			}
+		}
+	}
+	return mismatched
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
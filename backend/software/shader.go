@@ -0,0 +1,375 @@
+package software
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"strings"
+)
+
+// Result of a vertex shader run
+type VertexOutput struct {
+	Position  [4]float32 // gl_Position, in clip space
+	PointSize float32    // gl_PointSize, 1 when not positive
+	Varyings  []float32  // Interpolated over primitives and given to the fragment shader
+}
+
+// Go replacement of a GLSL vertex shader. Main receives the values of the declared attributes,
+// in order, with missing components filled from (0, 0, 0, 1).
+type VertexShader struct {
+	Attributes []string
+	Main       func(attributes [][4]float32, uniforms *Uniforms) VertexOutput
+}
+
+// Input of a fragment shader run
+type Fragment struct {
+	Varyings    []float32
+	Coord       [4]float32 // gl_FragCoord: window position, depth and 1/w
+	FrontFacing bool
+	PointCoord  [2]float32
+}
+
+// Go replacement of a GLSL fragment shader, returning gl_FragColor or whether the fragment is discarded
+type FragmentShader func(fragment *Fragment, uniforms *Uniforms) (color [4]float32, discard bool)
+
+type shader struct {
+	object
+	kind     types.GLEnum
+	source   string
+	compiled bool
+	infoLog  string
+	vertex   *VertexShader
+	fragment FragmentShader
+}
+
+type program struct {
+	object
+	shaders      []*shader
+	linked       bool
+	infoLog      string
+	vertex       *VertexShader
+	fragment     FragmentShader
+	boundAttribs map[string]int
+	attribs      map[string]int
+	uniforms     map[string][]float32
+}
+
+type uniformLocation struct {
+	program *program
+	name    string
+}
+
+func newProgram() *program {
+	return &program{
+		boundAttribs: make(map[string]int),
+		attribs:      make(map[string]int),
+		uniforms:     make(map[string][]float32),
+	}
+}
+
+// Registers the Go implementation of a GLSL vertex shader. Shaders compile only when their
+// source, ignoring surrounding white space, has been registered.
+func (r *Rasterizer) RegisterVertexShader(source string, shader *VertexShader) {
+	r.vertexShaders[strings.TrimSpace(source)] = shader
+}
+
+// Registers the Go implementation of a GLSL fragment shader
+func (r *Rasterizer) RegisterFragmentShader(source string, shader FragmentShader) {
+	r.fragmentShaders[strings.TrimSpace(source)] = shader
+}
+
+func (r *Rasterizer) compileShader(sh *shader) {
+	source := strings.TrimSpace(sh.source)
+	sh.vertex, sh.fragment = nil, nil
+	if sh.kind == webgl.VERTEX_SHADER {
+		sh.vertex = r.vertexShaders[source]
+		sh.compiled = sh.vertex != nil
+	} else {
+		sh.fragment = r.fragmentShaders[source]
+		sh.compiled = sh.fragment != nil
+	}
+	sh.infoLog = ""
+	if !sh.compiled {
+		sh.infoLog = "ERROR: no Go shader registered for this source"
+	}
+}
+
+func (r *Rasterizer) getShaderParameter(args []interface{}) backend.Value {
+	sh, ok := argObject(args, 0).(*shader)
+	if !ok {
+		r.setError(webgl.INVALID_VALUE)
+		return backend.Null()
+	}
+	switch argEnum(args, 1) {
+	case webgl.COMPILE_STATUS:
+		return backend.ValueOf(sh.compiled)
+	case webgl.SHADER_TYPE:
+		return backend.ValueOf(sh.kind)
+	case webgl.DELETE_STATUS:
+		return backend.ValueOf(sh.deleted)
+	}
+	return backend.Null()
+}
+
+func (r *Rasterizer) attachShader(attach bool, args []interface{}) {
+	prog, ok := argObject(args, 0).(*program)
+	sh, shaderOk := argObject(args, 1).(*shader)
+	if !ok || !shaderOk {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	for i, attached := range prog.shaders {
+		if attached == sh {
+			if !attach {
+				prog.shaders = append(prog.shaders[:i], prog.shaders[i+1:]...)
+			}
+			return
+		}
+	}
+	if attach {
+		prog.shaders = append(prog.shaders, sh)
+	}
+}
+
+func (r *Rasterizer) getAttachedShaders(args []interface{}) backend.Value {
+	prog, ok := argObject(args, 0).(*program)
+	if !ok {
+		return backend.Null()
+	}
+	shaders := make([]interface{}, len(prog.shaders))
+	for i, sh := range prog.shaders {
+		shaders[i] = sh
+	}
+	return backend.ValueOf(shaders)
+}
+
+func (r *Rasterizer) getProgramParameter(args []interface{}) backend.Value {
+	prog, ok := argObject(args, 0).(*program)
+	if !ok {
+		r.setError(webgl.INVALID_VALUE)
+		return backend.Null()
+	}
+	switch argEnum(args, 1) {
+	case webgl.LINK_STATUS, webgl.VALIDATE_STATUS:
+		return backend.ValueOf(prog.linked)
+	case webgl.DELETE_STATUS:
+		return backend.ValueOf(prog.deleted)
+	case webgl.ATTACHED_SHADERS:
+		return backend.ValueOf(len(prog.shaders))
+	case webgl.ACTIVE_ATTRIBUTES:
+		return backend.ValueOf(len(prog.attribs))
+	case webgl.ACTIVE_UNIFORMS:
+		return backend.ValueOf(len(prog.uniforms))
+	}
+	return backend.Null()
+}
+
+// Links the attached shaders, attributes keep the locations given by bindAttribLocation
+// and the others take the lowest free locations, in declaration order
+func (prog *program) link() {
+	prog.linked = false
+	prog.vertex, prog.fragment = nil, nil
+	for _, sh := range prog.shaders {
+		if sh.vertex != nil {
+			prog.vertex = sh.vertex
+		}
+		if sh.fragment != nil {
+			prog.fragment = sh.fragment
+		}
+	}
+	if prog.vertex == nil || prog.fragment == nil {
+		prog.infoLog = "ERROR: a compiled vertex and fragment shader must be attached"
+		return
+	}
+
+	prog.attribs = make(map[string]int)
+	used := make(map[int]bool)
+	for _, name := range prog.vertex.Attributes {
+		if location, ok := prog.boundAttribs[name]; ok {
+			if location < 0 || location >= maxVertexAttribs {
+				prog.infoLog = "ERROR: attribute " + name + " bound to an invalid location"
+				return
+			}
+			prog.attribs[name] = location
+			used[location] = true
+		}
+	}
+	next := 0
+	for _, name := range prog.vertex.Attributes {
+		if _, ok := prog.attribs[name]; ok {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		if next >= maxVertexAttribs {
+			prog.infoLog = "ERROR: too many attributes"
+			return
+		}
+		prog.attribs[name] = next
+		used[next] = true
+	}
+
+	prog.uniforms = make(map[string][]float32)
+	prog.infoLog = ""
+	prog.linked = true
+}
+
+// Array uniforms are stored under their name without the "[0]" suffix
+func uniformName(name string) string {
+	return strings.TrimSuffix(name, "[0]")
+}
+
+// Handles the uniform[1234][fi][v] and uniformMatrix[234]fv calls
+func (r *Rasterizer) uniform(method string, args []interface{}) {
+	location, ok := argObject(args, 0).(*uniformLocation)
+	if !ok {
+		// Setting a null location is silently ignored
+		return
+	}
+	if location.program != r.program {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+
+	var values []float32
+	switch {
+	case strings.HasPrefix(method, "uniformMatrix"):
+		values = toFloats(args[2])
+		if argBool(args, 1) {
+			size := int(method[len("uniformMatrix")] - '0')
+			values = transposeMatrices(values, size)
+		}
+	case strings.HasSuffix(method, "v"):
+		values = toFloats(args[1])
+	default:
+		for _, arg := range args[1:] {
+			values = append(values, float32(backend.ValueOf(arg).Float()))
+		}
+	}
+	location.program.uniforms[location.name] = values
+}
+
+func toFloats(data interface{}) []float32 {
+	switch values := data.(type) {
+	case []float32:
+		copied := make([]float32, len(values))
+		copy(copied, values)
+		return copied
+	case []int32:
+		converted := make([]float32, len(values))
+		for i, value := range values {
+			converted[i] = float32(value)
+		}
+		return converted
+	case []uint32:
+		converted := make([]float32, len(values))
+		for i, value := range values {
+			converted[i] = float32(value)
+		}
+		return converted
+	}
+	return nil
+}
+
+func transposeMatrices(values []float32, size int) []float32 {
+	transposed := make([]float32, len(values))
+	for base := 0; base+size*size <= len(values); base += size * size {
+		for column := 0; column < size; column++ {
+			for row := 0; row < size; row++ {
+				transposed[base+column*size+row] = values[base+row*size+column]
+			}
+		}
+	}
+	return transposed
+}
+
+// Uniform values of the current program and texture sampling, given to the shaders
+type Uniforms struct {
+	r       *Rasterizer
+	program *program
+}
+
+// Returns the raw values of a uniform, nil when it has not been set
+func (u *Uniforms) Floats(name string) []float32 {
+	return u.program.uniforms[uniformName(name)]
+}
+
+func (u *Uniforms) Float(name string) float32 {
+	values := u.Floats(name)
+	if len(values) == 0 {
+		return 0
+	}
+	return values[0]
+}
+
+func (u *Uniforms) Int(name string) int {
+	return int(u.Float(name))
+}
+
+func (u *Uniforms) Vec2(name string) (vec [2]float32) {
+	copy(vec[:], u.Floats(name))
+	return vec
+}
+
+func (u *Uniforms) Vec3(name string) (vec [3]float32) {
+	copy(vec[:], u.Floats(name))
+	return vec
+}
+
+func (u *Uniforms) Vec4(name string) (vec [4]float32) {
+	copy(vec[:], u.Floats(name))
+	return vec
+}
+
+// Column major matrix
+func (u *Uniforms) Mat3(name string) (mat [9]float32) {
+	copy(mat[:], u.Floats(name))
+	return mat
+}
+
+// Column major matrix
+func (u *Uniforms) Mat4(name string) (mat [16]float32) {
+	copy(mat[:], u.Floats(name))
+	return mat
+}
+
+// Samples the 2D texture bound to the unit of a sampler2D uniform, like texture2D in GLSL
+func (u *Uniforms) Texture2D(name string, s, t float32) [4]float32 {
+	unit := u.Int(name)
+	if unit < 0 || unit >= maxTextureUnits {
+		return [4]float32{0, 0, 0, 1}
+	}
+	return u.r.textureUnits[unit].texture2D.sample2D(s, t)
+}
+
+// Samples the cube map bound to the unit of a samplerCube uniform, like textureCube in GLSL
+func (u *Uniforms) TextureCube(name string, x, y, z float32) [4]float32 {
+	unit := u.Int(name)
+	if unit < 0 || unit >= maxTextureUnits {
+		return [4]float32{0, 0, 0, 1}
+	}
+	return u.r.textureUnits[unit].cubeMap.sampleCube(x, y, z)
+}
+
+// Multiplies a column major matrix by a vector
+func Transform(mat [16]float32, vec [4]float32) (result [4]float32) {
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			result[row] += mat[column*4+row] * vec[column]
+		}
+	}
+	return result
+}
+
+// Multiplies two column major matrices
+func MulMat4(a, b [16]float32) (result [16]float32) {
+	for column := 0; column < 4; column++ {
+		for row := 0; row < 4; row++ {
+			for k := 0; k < 4; k++ {
+				result[column*4+row] += a[k*4+row] * b[column*4+k]
+			}
+		}
+	}
+	return result
+}
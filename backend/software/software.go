@@ -0,0 +1,654 @@
+// Package software is a CPU reference implementation of the WebGL subset wrapped by RenderingContext:
+// buffers, textures, renderbuffers, framebuffers, viewport and scissor, depth and stencil testing,
+// blending and DrawArrays/DrawElements for points, lines and triangles.
+//
+// Shaders are Go functions registered for the GLSL source they replace, so rendering code runs
+// unchanged and its output can be read back with ReadPixels or compared against golden images:
+//
+//	rasterizer := software.New(800, 600)
+//	rasterizer.RegisterVertexShader(vertShaderCode, &software.VertexShader{...})
+//	rasterizer.RegisterFragmentShader(fragShaderCode, func(...) {...})
+//	gl := webgl.WrapBackend(rasterizer, webgl.WebGL2)
+//	drawScene(gl)
+//	err := rasterizer.SavePNG("testdata/scene.png")
+package software
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"reflect"
+	"strings"
+)
+
+const (
+	maxVertexAttribs = 16
+	maxTextureUnits  = 16
+	maxTextureSize   = 4096
+)
+
+// Shared by every object handle
+type object struct {
+	deleted bool
+}
+
+func (o *object) markDeleted() {
+	o.deleted = true
+}
+
+func (o *object) isDeleted() bool {
+	return o.deleted
+}
+
+type deletable interface {
+	markDeleted()
+	isDeleted() bool
+}
+
+type stencilState struct {
+	function  types.GLEnum
+	ref       int
+	valueMask uint8
+	writeMask uint8
+	fail      types.GLEnum
+	zFail     types.GLEnum
+	zPass     types.GLEnum
+}
+
+type textureUnit struct {
+	texture2D *texture
+	cubeMap   *texture
+}
+
+// Software rasterizer implementing backend.Backend
+type Rasterizer struct {
+	width  int
+	height int
+
+	vertexShaders   map[string]*VertexShader
+	fragmentShaders map[string]FragmentShader
+
+	err types.GLEnum
+
+	defaultFramebuffer *framebuffer
+	drawFramebuffer    *framebuffer
+	readFramebuffer    *framebuffer
+	renderbuffer       *renderbuffer
+	buffers            map[types.GLEnum]*buffer
+	defaultVertexArray *vertexArray
+	vertexArray        *vertexArray
+	genericAttribs     [maxVertexAttribs][4]float32
+	program            *program
+	activeTexture      int
+	textureUnits       [maxTextureUnits]textureUnit
+
+	caps           map[types.GLEnum]bool
+	viewport       [4]int
+	scissor        [4]int
+	depthRange     [2]float32
+	clearColor     [4]float32
+	clearDepth     float32
+	clearStencil   int
+	colorMask      [4]bool
+	depthMask      bool
+	depthFunc      types.GLEnum
+	blendSrcRGB    types.GLEnum
+	blendDstRGB    types.GLEnum
+	blendSrcAlpha  types.GLEnum
+	blendDstAlpha  types.GLEnum
+	blendEqRGB     types.GLEnum
+	blendEqAlpha   types.GLEnum
+	blendColor     [4]float32
+	stencil        [2]stencilState // Front and back faces
+	cullFace       types.GLEnum
+	frontFace      types.GLEnum
+	lineWidth      float32
+	polygonFactor  float32
+	polygonUnits   float32
+	unpackAlign    int
+	packAlign      int
+	unpackFlipY    bool
+	unpackPremulti bool
+}
+
+// Creates a rasterizer with a drawing buffer of the given size, holding RGBA8 colors,
+// a depth buffer and an 8 bits stencil buffer
+func New(width, height int) *Rasterizer {
+	r := &Rasterizer{
+		width:           width,
+		height:          height,
+		vertexShaders:   make(map[string]*VertexShader),
+		fragmentShaders: make(map[string]FragmentShader),
+		buffers:         make(map[types.GLEnum]*buffer),
+		caps:            map[types.GLEnum]bool{webgl.DITHER: true},
+		viewport:        [4]int{0, 0, width, height},
+		scissor:         [4]int{0, 0, width, height},
+		depthRange:      [2]float32{0, 1},
+		clearDepth:      1,
+		colorMask:       [4]bool{true, true, true, true},
+		depthMask:       true,
+		depthFunc:       webgl.LESS,
+		blendSrcRGB:     webgl.ONE,
+		blendDstRGB:     webgl.ZERO,
+		blendSrcAlpha:   webgl.ONE,
+		blendDstAlpha:   webgl.ZERO,
+		blendEqRGB:      webgl.FUNC_ADD,
+		blendEqAlpha:    webgl.FUNC_ADD,
+		cullFace:        webgl.BACK,
+		frontFace:       webgl.CCW,
+		lineWidth:       1,
+		unpackAlign:     4,
+		packAlign:       4,
+	}
+	for face := range r.stencil {
+		r.stencil[face] = stencilState{
+			function:  webgl.ALWAYS,
+			valueMask: 0xFF,
+			writeMask: 0xFF,
+			fail:      webgl.KEEP,
+			zFail:     webgl.KEEP,
+			zPass:     webgl.KEEP,
+		}
+	}
+
+	depthStencil := newSurface(width, height, webgl.DEPTH24_STENCIL8, webgl.UNSIGNED_INT_24_8)
+	r.defaultFramebuffer = &framebuffer{
+		defaultColor:        newSurface(width, height, webgl.RGBA8, webgl.UNSIGNED_BYTE),
+		defaultDepthStencil: depthStencil,
+	}
+	r.defaultFramebuffer.clear(nil, [4]float32{}, 1, 0)
+	r.defaultVertexArray = &vertexArray{}
+	r.vertexArray = r.defaultVertexArray
+	return r
+}
+
+func (r *Rasterizer) Get(property string) backend.Value {
+	switch property {
+	case "drawingBufferWidth":
+		return backend.ValueOf(r.width)
+	case "drawingBufferHeight":
+		return backend.ValueOf(r.height)
+	}
+	return backend.Undefined()
+}
+
+func (r *Rasterizer) Call(method string, args ...interface{}) backend.Value {
+	switch method {
+	case "getError":
+		err := r.err
+		r.err = webgl.NO_ERROR
+		return backend.ValueOf(err)
+	case "getExtension":
+		return backend.Null()
+	case "getSupportedExtensions":
+		return backend.ValueOf([]interface{}{})
+	case "isContextLost":
+		return backend.ValueOf(false)
+	case "getParameter":
+		return r.getParameter(argEnum(args, 0))
+
+	// Capabilities and fixed function state
+	case "enable", "disable":
+		r.caps[argEnum(args, 0)] = method == "enable"
+	case "isEnabled":
+		return backend.ValueOf(r.caps[argEnum(args, 0)])
+	case "viewport", "scissor":
+		box := [4]int{argInt(args, 0), argInt(args, 1), argInt(args, 2), argInt(args, 3)}
+		if box[2] < 0 || box[3] < 0 {
+			r.setError(webgl.INVALID_VALUE)
+			return backend.Undefined()
+		}
+		if method == "viewport" {
+			r.viewport = box
+		} else {
+			r.scissor = box
+		}
+	case "depthRange":
+		r.depthRange = [2]float32{clamp01(argFloat(args, 0)), clamp01(argFloat(args, 1))}
+	case "clearColor":
+		r.clearColor = [4]float32{argFloat(args, 0), argFloat(args, 1), argFloat(args, 2), argFloat(args, 3)}
+	case "clearDepth":
+		r.clearDepth = clamp01(argFloat(args, 0))
+	case "clearStencil":
+		r.clearStencil = argInt(args, 0)
+	case "colorMask":
+		r.colorMask = [4]bool{argBool(args, 0), argBool(args, 1), argBool(args, 2), argBool(args, 3)}
+	case "depthMask":
+		r.depthMask = argBool(args, 0)
+	case "depthFunc":
+		r.depthFunc = argEnum(args, 0)
+	case "blendFunc":
+		r.blendSrcRGB, r.blendDstRGB = argEnum(args, 0), argEnum(args, 1)
+		r.blendSrcAlpha, r.blendDstAlpha = r.blendSrcRGB, r.blendDstRGB
+	case "blendFuncSeparate":
+		r.blendSrcRGB, r.blendDstRGB = argEnum(args, 0), argEnum(args, 1)
+		r.blendSrcAlpha, r.blendDstAlpha = argEnum(args, 2), argEnum(args, 3)
+	case "blendEquation":
+		r.blendEqRGB, r.blendEqAlpha = argEnum(args, 0), argEnum(args, 0)
+	case "blendEquationSeparate":
+		r.blendEqRGB, r.blendEqAlpha = argEnum(args, 0), argEnum(args, 1)
+	case "blendColor":
+		r.blendColor = [4]float32{argFloat(args, 0), argFloat(args, 1), argFloat(args, 2), argFloat(args, 3)}
+	case "stencilFunc", "stencilFuncSeparate", "stencilOp", "stencilOpSeparate", "stencilMask", "stencilMaskSeparate":
+		r.setStencil(method, args)
+	case "cullFace":
+		r.cullFace = argEnum(args, 0)
+	case "frontFace":
+		r.frontFace = argEnum(args, 0)
+	case "lineWidth":
+		r.lineWidth = argFloat(args, 0)
+	case "polygonOffset":
+		r.polygonFactor, r.polygonUnits = argFloat(args, 0), argFloat(args, 1)
+	case "pixelStorei":
+		r.pixelStore(argEnum(args, 0), argInt(args, 1))
+	case "clear":
+		r.clear(argInt(args, 0))
+	case "clearBufferfv", "clearBufferiv", "clearBufferuiv", "clearBufferfi":
+		r.clearBuffer(method, args)
+	case "flush", "finish", "hint":
+
+	// Buffers
+	case "createBuffer":
+		return objectValue(&buffer{})
+	case "bindBuffer":
+		r.bindBuffer(argEnum(args, 0), argObject(args, 1))
+	case "bufferData":
+		r.bufferData(args)
+	case "bufferSubData":
+		r.bufferSubData(args)
+	case "getBufferSubData":
+		r.getBufferSubData(args)
+	case "getBufferParameter":
+		if buf := r.boundBuffer(argEnum(args, 0)); buf != nil && argEnum(args, 1) == webgl.BUFFER_SIZE {
+			return backend.ValueOf(len(buf.data))
+		}
+		return backend.Null()
+
+	// Vertex arrays and attributes
+	case "createVertexArray":
+		return objectValue(&vertexArray{})
+	case "bindVertexArray":
+		r.vertexArray = r.defaultVertexArray
+		if vao, ok := argObject(args, 0).(*vertexArray); ok {
+			r.vertexArray = vao
+		}
+	case "enableVertexAttribArray", "disableVertexAttribArray":
+		if index := argInt(args, 0); r.checkAttrib(index) {
+			r.vertexArray.attribs[index].enabled = method == "enableVertexAttribArray"
+		}
+	case "vertexAttribPointer", "vertexAttribIPointer":
+		r.vertexAttribPointer(method, args)
+	case "vertexAttribDivisor":
+		if index := argInt(args, 0); r.checkAttrib(index) {
+			r.vertexArray.attribs[index].divisor = argInt(args, 1)
+		}
+	case "vertexAttrib1f", "vertexAttrib2f", "vertexAttrib3f", "vertexAttrib4f",
+		"vertexAttrib1fv", "vertexAttrib2fv", "vertexAttrib3fv", "vertexAttrib4fv":
+		r.vertexAttrib(method, args)
+
+	// Shaders and programs
+	case "createShader":
+		return objectValue(&shader{kind: argEnum(args, 0)})
+	case "shaderSource":
+		if sh, ok := argObject(args, 0).(*shader); ok {
+			sh.source, _ = args[1].(string)
+		}
+	case "compileShader":
+		if sh, ok := argObject(args, 0).(*shader); ok {
+			r.compileShader(sh)
+		}
+	case "getShaderParameter":
+		return r.getShaderParameter(args)
+	case "getShaderInfoLog":
+		if sh, ok := argObject(args, 0).(*shader); ok {
+			return backend.ValueOf(sh.infoLog)
+		}
+		return backend.Null()
+	case "getShaderSource":
+		if sh, ok := argObject(args, 0).(*shader); ok {
+			return backend.ValueOf(sh.source)
+		}
+		return backend.Null()
+	case "createProgram":
+		return objectValue(newProgram())
+	case "attachShader", "detachShader":
+		r.attachShader(method == "attachShader", args)
+	case "bindAttribLocation":
+		if prog, ok := argObject(args, 0).(*program); ok && r.checkAttrib(argInt(args, 1)) {
+			name, _ := args[2].(string)
+			prog.boundAttribs[name] = argInt(args, 1)
+		}
+	case "linkProgram":
+		if prog, ok := argObject(args, 0).(*program); ok {
+			prog.link()
+		}
+	case "validateProgram":
+	case "useProgram":
+		prog, _ := argObject(args, 0).(*program)
+		if prog != nil && !prog.linked {
+			r.setError(webgl.INVALID_OPERATION)
+			return backend.Undefined()
+		}
+		r.program = prog
+	case "getProgramParameter":
+		return r.getProgramParameter(args)
+	case "getProgramInfoLog":
+		if prog, ok := argObject(args, 0).(*program); ok {
+			return backend.ValueOf(prog.infoLog)
+		}
+		return backend.Null()
+	case "getAttachedShaders":
+		return r.getAttachedShaders(args)
+	case "getAttribLocation":
+		if prog, ok := argObject(args, 0).(*program); ok && prog.linked {
+			name, _ := args[1].(string)
+			if location, ok := prog.attribs[name]; ok {
+				return backend.ValueOf(location)
+			}
+		}
+		return backend.ValueOf(-1)
+	case "getUniformLocation":
+		if prog, ok := argObject(args, 0).(*program); ok && prog.linked {
+			name, _ := args[1].(string)
+			return objectValue(&uniformLocation{program: prog, name: uniformName(name)})
+		}
+		return backend.Null()
+	case "getUniform":
+		if location, ok := argObject(args, 1).(*uniformLocation); ok {
+			return backend.ValueOf(location.program.uniforms[location.name])
+		}
+		return backend.Null()
+
+	// Textures
+	case "createTexture":
+		return objectValue(newTexture())
+	case "activeTexture":
+		r.activeTexture = argInt(args, 0) - int(webgl.TEXTURE0)
+		if r.activeTexture < 0 || r.activeTexture >= maxTextureUnits {
+			r.setError(webgl.INVALID_ENUM)
+			r.activeTexture = 0
+		}
+	case "bindTexture":
+		r.bindTexture(argEnum(args, 0), argObject(args, 1))
+	case "texImage2D":
+		r.texImage2D(args)
+	case "texSubImage2D":
+		r.texSubImage2D(args)
+	case "texParameteri", "texParameterf":
+		if tex := r.boundTexture(argEnum(args, 0)); tex != nil {
+			tex.setParameter(argEnum(args, 1), argEnum(args, 2))
+		}
+	case "generateMipmap":
+		if tex := r.boundTexture(argEnum(args, 0)); tex != nil {
+			tex.generateMipmap()
+		}
+
+	// Framebuffers and renderbuffers
+	case "createFramebuffer":
+		return objectValue(&framebuffer{})
+	case "bindFramebuffer":
+		r.bindFramebuffer(argEnum(args, 0), argObject(args, 1))
+	case "framebufferTexture2D":
+		r.framebufferTexture2D(args)
+	case "framebufferRenderbuffer":
+		r.framebufferRenderbuffer(args)
+	case "checkFramebufferStatus":
+		return backend.ValueOf(r.framebufferStatus(argEnum(args, 0)))
+	case "createRenderbuffer":
+		return objectValue(&renderbuffer{})
+	case "bindRenderbuffer":
+		r.renderbuffer, _ = argObject(args, 1).(*renderbuffer)
+	case "renderbufferStorage":
+		if r.renderbuffer == nil {
+			r.setError(webgl.INVALID_OPERATION)
+			return backend.Undefined()
+		}
+		r.renderbuffer.surface = newSurface(argInt(args, 2), argInt(args, 3), argEnum(args, 1), 0)
+	case "readPixels":
+		r.readPixels(args)
+
+	// Drawing
+	case "drawArrays":
+		r.drawArrays(argEnum(args, 0), argInt(args, 1), argInt(args, 2), 1)
+	case "drawArraysInstanced":
+		r.drawArrays(argEnum(args, 0), argInt(args, 1), argInt(args, 2), argInt(args, 3))
+	case "drawElements":
+		r.drawElements(argEnum(args, 0), argInt(args, 1), argEnum(args, 2), argInt(args, 3), 1)
+	case "drawElementsInstanced":
+		r.drawElements(argEnum(args, 0), argInt(args, 1), argEnum(args, 2), argInt(args, 3), argInt(args, 4))
+
+	default:
+		switch {
+		case strings.HasPrefix(method, "uniform"):
+			r.uniform(method, args)
+		case strings.HasPrefix(method, "delete"):
+			if obj, ok := argObject(args, 0).(deletable); ok {
+				obj.markDeleted()
+				r.detach(obj)
+			}
+		case strings.HasPrefix(method, "is"):
+			obj, ok := argObject(args, 0).(deletable)
+			return backend.ValueOf(ok && !obj.isDeleted() && isKind(method, obj))
+		}
+	}
+	return backend.Undefined()
+}
+
+// Keeps the first error until getError reads it
+func (r *Rasterizer) setError(err types.GLEnum) {
+	if r.err == webgl.NO_ERROR {
+		r.err = err
+	}
+}
+
+func (r *Rasterizer) pixelStore(pName types.GLEnum, param int) {
+	switch pName {
+	case webgl.UNPACK_ALIGNMENT:
+		r.unpackAlign = param
+	case webgl.PACK_ALIGNMENT:
+		r.packAlign = param
+	case webgl.UNPACK_FLIP_Y_WEBGL:
+		r.unpackFlipY = param != 0
+	case webgl.UNPACK_PREMULTIPLY_ALPHA_WEBGL:
+		r.unpackPremulti = param != 0
+	}
+}
+
+func (r *Rasterizer) setStencil(method string, args []interface{}) {
+	faces := []int{0, 1}
+	if strings.HasSuffix(method, "Separate") {
+		switch argEnum(args, 0) {
+		case webgl.FRONT:
+			faces = []int{0}
+		case webgl.BACK:
+			faces = []int{1}
+		}
+		args = args[1:]
+	}
+	for _, face := range faces {
+		state := &r.stencil[face]
+		switch strings.TrimSuffix(method, "Separate") {
+		case "stencilFunc":
+			state.function, state.ref, state.valueMask = argEnum(args, 0), argInt(args, 1), uint8(argInt(args, 2))
+		case "stencilOp":
+			state.fail, state.zFail, state.zPass = argEnum(args, 0), argEnum(args, 1), argEnum(args, 2)
+		case "stencilMask":
+			state.writeMask = uint8(argInt(args, 0))
+		}
+	}
+}
+
+func (r *Rasterizer) getParameter(pName types.GLEnum) backend.Value {
+	switch pName {
+	case webgl.VIEWPORT:
+		return backend.ValueOf(toInt32s(r.viewport[:]))
+	case webgl.SCISSOR_BOX:
+		return backend.ValueOf(toInt32s(r.scissor[:]))
+	case webgl.MAX_VIEWPORT_DIMS:
+		return backend.ValueOf([]int32{maxTextureSize, maxTextureSize})
+	case webgl.MAX_TEXTURE_SIZE, webgl.MAX_CUBE_MAP_TEXTURE_SIZE, webgl.MAX_RENDERBUFFER_SIZE:
+		return backend.ValueOf(maxTextureSize)
+	case webgl.MAX_VERTEX_ATTRIBS:
+		return backend.ValueOf(maxVertexAttribs)
+	case webgl.MAX_TEXTURE_IMAGE_UNITS, webgl.MAX_VERTEX_TEXTURE_IMAGE_UNITS, webgl.MAX_COMBINED_TEXTURE_IMAGE_UNITS:
+		return backend.ValueOf(maxTextureUnits)
+	case webgl.RED_BITS, webgl.GREEN_BITS, webgl.BLUE_BITS, webgl.ALPHA_BITS, webgl.STENCIL_BITS:
+		return backend.ValueOf(8)
+	case webgl.DEPTH_BITS:
+		return backend.ValueOf(24)
+	case webgl.COLOR_CLEAR_VALUE:
+		return backend.ValueOf(r.clearColor[:])
+	case webgl.DEPTH_CLEAR_VALUE:
+		return backend.ValueOf(r.clearDepth)
+	case webgl.STENCIL_CLEAR_VALUE:
+		return backend.ValueOf(r.clearStencil)
+	case webgl.COLOR_WRITEMASK:
+		return backend.ValueOf(r.colorMask[:])
+	case webgl.DEPTH_WRITEMASK:
+		return backend.ValueOf(r.depthMask)
+	case webgl.DEPTH_FUNC:
+		return backend.ValueOf(r.depthFunc)
+	case webgl.DEPTH_RANGE:
+		return backend.ValueOf(r.depthRange[:])
+	case webgl.BLEND_SRC_RGB:
+		return backend.ValueOf(r.blendSrcRGB)
+	case webgl.BLEND_DST_RGB:
+		return backend.ValueOf(r.blendDstRGB)
+	case webgl.BLEND_SRC_ALPHA:
+		return backend.ValueOf(r.blendSrcAlpha)
+	case webgl.BLEND_DST_ALPHA:
+		return backend.ValueOf(r.blendDstAlpha)
+	case webgl.BLEND_EQUATION_RGB:
+		return backend.ValueOf(r.blendEqRGB)
+	case webgl.BLEND_EQUATION_ALPHA:
+		return backend.ValueOf(r.blendEqAlpha)
+	case webgl.BLEND_COLOR:
+		return backend.ValueOf(r.blendColor[:])
+	case webgl.CULL_FACE_MODE:
+		return backend.ValueOf(r.cullFace)
+	case webgl.FRONT_FACE:
+		return backend.ValueOf(r.frontFace)
+	case webgl.LINE_WIDTH:
+		return backend.ValueOf(r.lineWidth)
+	case webgl.ACTIVE_TEXTURE:
+		return backend.ValueOf(int(webgl.TEXTURE0) + r.activeTexture)
+	case webgl.UNPACK_ALIGNMENT:
+		return backend.ValueOf(r.unpackAlign)
+	case webgl.PACK_ALIGNMENT:
+		return backend.ValueOf(r.packAlign)
+	case webgl.CURRENT_PROGRAM:
+		return objectValue(r.program)
+	case webgl.ARRAY_BUFFER_BINDING:
+		return objectValue(r.buffers[webgl.ARRAY_BUFFER])
+	case webgl.ELEMENT_ARRAY_BUFFER_BINDING:
+		return objectValue(r.vertexArray.elements)
+	case webgl.VERTEX_ARRAY_BINDING:
+		if r.vertexArray == r.defaultVertexArray {
+			return backend.Null()
+		}
+		return objectValue(r.vertexArray)
+	case webgl.FRAMEBUFFER_BINDING:
+		return objectValue(r.drawFramebuffer)
+	case webgl.READ_FRAMEBUFFER_BINDING:
+		return objectValue(r.readFramebuffer)
+	case webgl.RENDERBUFFER_BINDING:
+		return objectValue(r.renderbuffer)
+	case webgl.TEXTURE_BINDING_2D:
+		return objectValue(r.textureUnits[r.activeTexture].texture2D)
+	case webgl.TEXTURE_BINDING_CUBE_MAP:
+		return objectValue(r.textureUnits[r.activeTexture].cubeMap)
+	case webgl.VERSION:
+		return backend.ValueOf("WebGL 2.0 (software)")
+	case webgl.SHADING_LANGUAGE_VERSION:
+		return backend.ValueOf("WebGL GLSL ES 3.00 (Go functions)")
+	case webgl.VENDOR, webgl.RENDERER:
+		return backend.ValueOf("github.com/nuberu/webgl/backend/software")
+	}
+	if enabled, ok := r.caps[pName]; ok {
+		return backend.ValueOf(enabled)
+	}
+	return backend.Null()
+}
+
+// Wraps an object as a handle value, nil objects give null
+func objectValue(obj interface{}) backend.Value {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return backend.Null()
+	}
+	return backend.ValueOf(obj)
+}
+
+func isKind(method string, obj interface{}) bool {
+	switch obj.(type) {
+	case *buffer:
+		return method == "isBuffer"
+	case *texture:
+		return method == "isTexture"
+	case *shader:
+		return method == "isShader"
+	case *program:
+		return method == "isProgram"
+	case *framebuffer:
+		return method == "isFramebuffer"
+	case *renderbuffer:
+		return method == "isRenderbuffer"
+	case *vertexArray:
+		return method == "isVertexArray"
+	}
+	return false
+}
+
+func argObject(args []interface{}, i int) interface{} {
+	if i >= len(args) {
+		return nil
+	}
+	return backend.Unwrap(args[i])
+}
+
+func argInt(args []interface{}, i int) int {
+	if i >= len(args) {
+		return 0
+	}
+	return backend.ValueOf(args[i]).Int()
+}
+
+func argEnum(args []interface{}, i int) types.GLEnum {
+	return types.GLEnum(argInt(args, i))
+}
+
+func argFloat(args []interface{}, i int) float32 {
+	if i >= len(args) {
+		return 0
+	}
+	return float32(backend.ValueOf(args[i]).Float())
+}
+
+func argBool(args []interface{}, i int) bool {
+	if i >= len(args) {
+		return false
+	}
+	return backend.ValueOf(args[i]).Bool()
+}
+
+func isNumber(arg interface{}) bool {
+	kind := reflect.ValueOf(arg).Kind()
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func toInt32s(values []int) []int32 {
+	converted := make([]int32, len(values))
+	for i, value := range values {
+		converted[i] = int32(value)
+	}
+	return converted
+}
+
+func clamp01(value float32) float32 {
+	if value < 0 {
+		return 0
+	}
+	if value > 1 {
+		return 1
+	}
+	return value
+}
@@ -0,0 +1,386 @@
+package software_test
+
+import (
+	"errors"
+	"flag"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/software"
+	"github.com/nuberu/webgl/types"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images of testdata")
+
+const (
+	vertexSource   = "attribute vec3 position; attribute vec3 color; varying vec3 vColor; void main() {}"
+	fragmentSource = "varying vec3 vColor; void main() {}"
+	// Samples the texture of unit 0 at the red and green components of the color
+	textureSource = "varying vec3 vColor; uniform sampler2D sampler; void main() {}"
+)
+
+// Context drawing colored vertices, three position then three color floats per vertex
+func newContext(t *testing.T, width, height int) (*webgl.RenderingContext, *software.Rasterizer, *types.Program) {
+	t.Helper()
+	rasterizer := software.New(width, height)
+	rasterizer.RegisterVertexShader(vertexSource, &software.VertexShader{
+		Attributes: []string{"position", "color"},
+		Main: func(attributes [][4]float32, uniforms *software.Uniforms) software.VertexOutput {
+			position, color := attributes[0], attributes[1]
+			return software.VertexOutput{
+				Position: [4]float32{position[0], position[1], position[2], 1},
+				Varyings: []float32{color[0], color[1], color[2]},
+			}
+		},
+	})
+	rasterizer.RegisterFragmentShader(fragmentSource, func(fragment *software.Fragment, uniforms *software.Uniforms) ([4]float32, bool) {
+		return [4]float32{fragment.Varyings[0], fragment.Varyings[1], fragment.Varyings[2], 1}, false
+	})
+	rasterizer.RegisterFragmentShader(textureSource, func(fragment *software.Fragment, uniforms *software.Uniforms) ([4]float32, bool) {
+		return uniforms.Texture2D("sampler", fragment.Varyings[0], fragment.Varyings[1]), false
+	})
+	gl := webgl.WrapBackend(rasterizer, webgl.WebGL1)
+	gl.BindBuffer(webgl.ARRAY_BUFFER, gl.CreateBuffer())
+	return gl, rasterizer, useProgram(t, gl, fragmentSource)
+}
+
+// Links the vertex shader with a fragment shader and points its attributes at the bound buffer
+func useProgram(t *testing.T, gl *webgl.RenderingContext, fragment string) *types.Program {
+	t.Helper()
+	program := gl.CreateProgram()
+	for kind, source := range map[types.GLEnum]string{webgl.VERTEX_SHADER: vertexSource, webgl.FRAGMENT_SHADER: fragment} {
+		shader := gl.CreateShader(kind)
+		gl.ShaderSource(shader, source)
+		gl.CompileShader(shader)
+		gl.AttachShader(program, shader)
+	}
+	gl.LinkProgram(program)
+	if !gl.GetProgramParameterLinkStatus(program) {
+		t.Fatalf("link failed: %s", gl.GetProgramInfoLog(program))
+	}
+	gl.UseProgram(program)
+
+	position := gl.GetAttribLocation(program, "position")
+	color := gl.GetAttribLocation(program, "color")
+	gl.EnableVertexAttribArray(position)
+	gl.EnableVertexAttribArray(color)
+	gl.VertexAttribPointer(position, 3, webgl.FLOAT, false, 24, 0)
+	gl.VertexAttribPointer(color, 3, webgl.FLOAT, false, 24, 12)
+	return program
+}
+
+// Two triangles covering a rectangle of normalized device coordinates with one color
+func quad(x0, y0, x1, y1, z float32, color [3]float32) []float32 {
+	var data []float32
+	for _, corner := range [6][2]float32{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y0}, {x1, y1}, {x0, y1}} {
+		data = append(data, corner[0], corner[1], z, color[0], color[1], color[2])
+	}
+	return data
+}
+
+func clearBlack(gl *webgl.RenderingContext) {
+	gl.ClearColor(0, 0, 0, 1)
+	gl.Clear(uint32(webgl.COLOR_BUFFER_BIT | webgl.DEPTH_BUFFER_BIT | webgl.STENCIL_BUFFER_BIT))
+}
+
+func checkNoError(t *testing.T, gl *webgl.RenderingContext) {
+	t.Helper()
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Compares the drawing buffer with a golden image of testdata, rewritten with -update
+func checkGolden(t *testing.T, rasterizer *software.Rasterizer, name string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := rasterizer.SavePNG(path); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	want, err := png.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatched := software.CompareImages(rasterizer.Image(), want, 1); mismatched > 0 {
+		t.Errorf("%d pixels differ from %s", mismatched, path)
+	}
+}
+
+func TestTriangle(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	gl.ClearColor(0, 0, 0, 1)
+	gl.Clear(uint32(webgl.COLOR_BUFFER_BIT))
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-0.75, -0.75, 0, 1, 0, 0,
+		0.75, -0.75, 0, 0, 1, 0,
+		0, 0.75, 0, 0, 0, 1,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 3)
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, rasterizer, "triangle.png")
+}
+
+// A near quad covers the right half, a far one drawn after it only shows on the left half
+func TestDepthTest(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	gl.Enable(webgl.DEPTH_TEST)
+	gl.ClearColor(0, 0, 0, 1)
+	gl.Clear(uint32(webgl.COLOR_BUFFER_BIT | webgl.DEPTH_BUFFER_BIT))
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		// Near blue quad on the right half
+		0, -1, -0.5, 0, 0, 1,
+		1, -1, -0.5, 0, 0, 1,
+		0, 1, -0.5, 0, 0, 1,
+		1, -1, -0.5, 0, 0, 1,
+		1, 1, -0.5, 0, 0, 1,
+		0, 1, -0.5, 0, 0, 1,
+		// Far green quad on the whole buffer
+		-1, -1, 0.5, 0, 1, 0,
+		1, -1, 0.5, 0, 1, 0,
+		-1, 1, 0.5, 0, 1, 0,
+		1, -1, 0.5, 0, 1, 0,
+		1, 1, 0.5, 0, 1, 0,
+		-1, 1, 0.5, 0, 1, 0,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 12)
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, rasterizer, "depth_test.png")
+}
+
+// Locations past the attribute limit raise INVALID_VALUE instead of reaching the draws
+func TestBindAttribLocationOutOfRange(t *testing.T) {
+	gl, _, program := newContext(t, 4, 4)
+	gl.BindAttribLocation(program, 99, "position")
+	if err := gl.GetError(); !errors.Is(err, webgl.ErrInvalidValue) {
+		t.Fatalf("got error %v, expected INVALID_VALUE", err)
+	}
+	gl.LinkProgram(program)
+	if !gl.GetProgramParameterLinkStatus(program) {
+		t.Fatalf("link failed: %s", gl.GetProgramInfoLog(program))
+	}
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{0, 0, 0, 1, 1, 1}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.POINTS, 0, 1)
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLines(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-0.9, -0.9, 0, 1, 0, 0,
+		0.9, 0.9, 0, 1, 0, 0,
+		-0.9, 0.5, 0, 0, 1, 0,
+		0.9, 0.5, 0, 0, 1, 0,
+		0.5, -0.9, 0, 0, 0, 1,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.LINES, 0, 2)
+	gl.DrawArrays(webgl.LINE_STRIP, 2, 3)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "lines.png")
+}
+
+func TestPoints(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-0.5, -0.5, 0, 1, 0, 0,
+		0.5, -0.5, 0, 0, 1, 0,
+		0, 0.5, 0, 0, 0, 1,
+		// Clipped away
+		2, 0, 0, 1, 1, 1,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.POINTS, 0, 4)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "points.png")
+}
+
+// Additive blending mixes a red and a blue quad into magenta where they overlap
+func TestBlending(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.Enable(webgl.BLEND)
+	gl.BlendFunc(webgl.ONE, webgl.ONE)
+	data := quad(-1, -1, 0.5, 1, 0, [3]float32{1, 0, 0})
+	data = append(data, quad(-0.5, -1, 1, 1, 0, [3]float32{0, 0, 1})...)
+	gl.BufferData(webgl.ARRAY_BUFFER, data, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 12)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "blending.png")
+}
+
+// A triangle only written to the stencil buffer masks a green quad
+func TestStencil(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.Enable(webgl.STENCIL_TEST)
+	data := []float32{
+		-0.75, -0.75, 0, 1, 0, 0,
+		0.75, -0.75, 0, 1, 0, 0,
+		0, 0.75, 0, 1, 0, 0,
+	}
+	data = append(data, quad(-1, -1, 1, 1, 0, [3]float32{0, 1, 0})...)
+	gl.BufferData(webgl.ARRAY_BUFFER, data, webgl.STATIC_DRAW)
+
+	gl.ColorMask(0, 0, 0, 0)
+	gl.StencilFunc(webgl.ALWAYS, 1, 0xFF)
+	gl.StencilOp(webgl.KEEP, webgl.KEEP, webgl.REPLACE)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 3)
+
+	gl.ColorMask(1, 1, 1, 1)
+	gl.StencilFunc(webgl.EQUAL, 1, 0xFF)
+	gl.StencilOp(webgl.KEEP, webgl.KEEP, webgl.KEEP)
+	gl.DrawArrays(webgl.TRIANGLES, 3, 6)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "stencil.png")
+}
+
+// The scissor box limits both clears and draws
+func TestScissor(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.Enable(webgl.SCISSOR_TEST)
+	gl.Scissor(4, 4, 8, 8)
+	gl.ClearColor(1, 0, 0, 1)
+	gl.Clear(uint32(webgl.COLOR_BUFFER_BIT))
+	gl.Scissor(0, 0, 6, 16)
+	gl.BufferData(webgl.ARRAY_BUFFER, quad(-1, -1, 1, 1, 0, [3]float32{0, 1, 0}), webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 6)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "scissor.png")
+}
+
+// Four vertices indexed into a quad, the draw starting past a leading degenerate triangle
+func TestDrawElements(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-0.75, -0.75, 0, 1, 0, 0,
+		0.75, -0.75, 0, 0, 1, 0,
+		-0.75, 0.75, 0, 0, 0, 1,
+		0.75, 0.75, 0, 1, 1, 1,
+	}, webgl.STATIC_DRAW)
+	gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, gl.CreateBuffer())
+	gl.BufferDataUI16(webgl.ELEMENT_ARRAY_BUFFER, []uint16{0, 0, 0, 0, 1, 2, 2, 1, 3}, webgl.STATIC_DRAW)
+	gl.DrawElements(webgl.TRIANGLES, 6, webgl.UNSIGNED_SHORT, 6)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "draw_elements.png")
+}
+
+// A 2x2 texture sampled with NEAREST filtering fills the four quarters of the buffer
+func TestTexture(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	useProgram(t, gl, textureSource)
+	clearBlack(gl)
+	gl.BindTexture(webgl.TEXTURE_2D, gl.CreateTexture())
+	gl.TexParameteri(webgl.TEXTURE_2D, webgl.TEXTURE_MIN_FILTER, int(webgl.NEAREST))
+	gl.TexParameteri(webgl.TEXTURE_2D, webgl.TEXTURE_MAG_FILTER, int(webgl.NEAREST))
+	gl.TexImage2Db(webgl.TEXTURE_2D, 0, webgl.RGBA, 2, 2, 0, webgl.RGBA, []byte{
+		255, 0, 0, 255, 0, 255, 0, 255,
+		0, 0, 255, 255, 255, 255, 255, 255,
+	})
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-1, -1, 0, 0, 0, 0,
+		1, -1, 0, 1, 0, 0,
+		-1, 1, 0, 0, 1, 0,
+		1, -1, 0, 1, 0, 0,
+		1, 1, 0, 1, 1, 0,
+		-1, 1, 0, 0, 1, 0,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 6)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "texture.png")
+}
+
+// A triangle rendered into an 8x8 texture is then drawn magnified on the drawing buffer
+func TestFramebuffer(t *testing.T) {
+	gl, rasterizer, _ := newContext(t, 16, 16)
+	clearBlack(gl)
+	texture := gl.CreateTexture()
+	gl.BindTexture(webgl.TEXTURE_2D, texture)
+	gl.TexParameteri(webgl.TEXTURE_2D, webgl.TEXTURE_MIN_FILTER, int(webgl.NEAREST))
+	gl.TexParameteri(webgl.TEXTURE_2D, webgl.TEXTURE_MAG_FILTER, int(webgl.NEAREST))
+	gl.TexImage2Db(webgl.TEXTURE_2D, 0, webgl.RGBA, 8, 8, 0, webgl.RGBA, nil)
+	framebuffer := gl.CreateFrameBuffer()
+	gl.BindFrameBuffer(webgl.FRAMEBUFFER, framebuffer)
+	gl.FrameBufferTexture2D(webgl.FRAMEBUFFER, webgl.COLOR_ATTACHMENT0, webgl.TEXTURE_2D, texture, 0)
+	if status := gl.CheckFrameBufferStatus(webgl.FRAMEBUFFER); status != webgl.FRAMEBUFFER_COMPLETE {
+		t.Fatalf("got status %s, expected FRAMEBUFFER_COMPLETE", webgl.EnumName(status))
+	}
+
+	gl.Viewport(0, 0, 8, 8)
+	gl.ClearColor(0, 0, 1, 1)
+	gl.Clear(uint32(webgl.COLOR_BUFFER_BIT))
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-1, -1, 0, 1, 1, 0,
+		1, -1, 0, 1, 1, 0,
+		-1, 1, 0, 1, 1, 0,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 3)
+
+	gl.BindFrameBuffer(webgl.FRAMEBUFFER, nil)
+	gl.Viewport(0, 0, 16, 16)
+	useProgram(t, gl, textureSource)
+	gl.BufferData(webgl.ARRAY_BUFFER, []float32{
+		-0.5, -0.5, 0, 0, 0, 0,
+		1, -0.5, 0, 1, 0, 0,
+		-0.5, 1, 0, 0, 1, 0,
+		1, -0.5, 0, 1, 0, 0,
+		1, 1, 0, 1, 1, 0,
+		-0.5, 1, 0, 0, 1, 0,
+	}, webgl.STATIC_DRAW)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 6)
+	checkNoError(t, gl)
+	checkGolden(t, rasterizer, "framebuffer.png")
+}
+
+// Deleting a texture attached to the bound framebuffer detaches it
+func TestDeleteAttachedTexture(t *testing.T) {
+	gl, _, _ := newContext(t, 4, 4)
+	texture := gl.CreateTexture()
+	gl.BindTexture(webgl.TEXTURE_2D, texture)
+	gl.TexImage2Db(webgl.TEXTURE_2D, 0, webgl.RGBA, 4, 4, 0, webgl.RGBA, nil)
+	gl.BindFrameBuffer(webgl.FRAMEBUFFER, gl.CreateFrameBuffer())
+	gl.FrameBufferTexture2D(webgl.FRAMEBUFFER, webgl.COLOR_ATTACHMENT0, webgl.TEXTURE_2D, texture, 0)
+	if status := gl.CheckFrameBufferStatus(webgl.FRAMEBUFFER); status != webgl.FRAMEBUFFER_COMPLETE {
+		t.Fatalf("got status %s, expected FRAMEBUFFER_COMPLETE", webgl.EnumName(status))
+	}
+	gl.DeleteTexture(texture)
+	if status := gl.CheckFrameBufferStatus(webgl.FRAMEBUFFER); status != webgl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT {
+		t.Fatalf("got status %s after deleting the texture, expected FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT", webgl.EnumName(status))
+	}
+	gl.DrawArrays(webgl.TRIANGLES, 0, 0)
+	if err := gl.GetError(); !errors.Is(err, webgl.ErrInvalidFramebufferOperation) {
+		t.Fatalf("got error %v, expected INVALID_FRAMEBUFFER_OPERATION", err)
+	}
+}
+
+func TestNegativeViewport(t *testing.T) {
+	gl, _, _ := newContext(t, 4, 4)
+	gl.Viewport(0, 0, -1, 4)
+	if err := gl.GetError(); !errors.Is(err, webgl.ErrInvalidValue) {
+		t.Fatalf("got error %v for a negative width, expected INVALID_VALUE", err)
+	}
+	gl.Scissor(0, 0, 4, -1)
+	if err := gl.GetError(); !errors.Is(err, webgl.ErrInvalidValue) {
+		t.Fatalf("got error %v for a negative height, expected INVALID_VALUE", err)
+	}
+	if viewport := gl.GetParameter(webgl.VIEWPORT); viewport.Index(2).Int() != 4 {
+		t.Fatalf("got viewport width %d, expected the previous 4", viewport.Index(2).Int())
+	}
+}
@@ -0,0 +1,529 @@
+package software
+
+import (
+	"encoding/binary"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"math"
+)
+
+const halfFloatOES types.GLEnum = 0x8D61 // HALF_FLOAT_OES from OES_texture_half_float
+
+// Pixel storage of textures and renderbuffers. Every pixel takes four floats: the RGBA color
+// for color formats, or the depth and the stencil value for depth and stencil formats.
+type surface struct {
+	width   int
+	height  int
+	format  types.GLEnum
+	levels  [4]float32 // Quantization steps of each channel, 0 for floating point channels
+	present [4]bool    // Stored channels, missing color channels read as 0 and alpha as 1
+	depth   bool
+	stencil bool
+	data    []float32
+}
+
+func newSurface(width, height int, internalFormat types.GLEnum, dataType types.GLEnum) *surface {
+	s := &surface{
+		width:   width,
+		height:  height,
+		format:  internalFormat,
+		levels:  [4]float32{255, 255, 255, 255},
+		present: [4]bool{true, true, true, true},
+		data:    make([]float32, width*height*4),
+	}
+
+	switch internalFormat {
+	case webgl.DEPTH_COMPONENT, webgl.DEPTH_COMPONENT16, webgl.DEPTH_COMPONENT24, webgl.DEPTH_COMPONENT32F:
+		s.depth = true
+	case webgl.STENCIL_INDEX8:
+		s.stencil = true
+	case webgl.DEPTH_STENCIL, webgl.DEPTH24_STENCIL8, webgl.DEPTH32F_STENCIL8:
+		s.depth, s.stencil = true, true
+	case webgl.RGBA4:
+		s.levels = [4]float32{15, 15, 15, 15}
+	case webgl.RGB565:
+		s.levels = [4]float32{31, 63, 31, 1}
+		s.present[3] = false
+	case webgl.RGB5_A1:
+		s.levels = [4]float32{31, 31, 31, 1}
+	case webgl.RGB, webgl.RGB8, webgl.SRGB8, webgl.LUMINANCE:
+		s.present[3] = false
+	case webgl.R8:
+		s.present = [4]bool{true, false, false, false}
+	case webgl.RG8:
+		s.present = [4]bool{true, true, false, false}
+	case webgl.RGBA16F, webgl.RGBA32F:
+		s.levels = [4]float32{}
+	case webgl.RGB16F, webgl.RGB32F, webgl.R11F_G11F_B10F:
+		s.levels = [4]float32{}
+		s.present[3] = false
+	case webgl.RG16F, webgl.RG32F:
+		s.levels = [4]float32{}
+		s.present = [4]bool{true, true, false, false}
+	case webgl.R16F, webgl.R32F:
+		s.levels = [4]float32{}
+		s.present = [4]bool{true, false, false, false}
+	}
+
+	// Unsized formats take their precision from the upload type
+	switch dataType {
+	case webgl.FLOAT, webgl.HALF_FLOAT, halfFloatOES:
+		s.levels = [4]float32{}
+	case webgl.UNSIGNED_SHORT_4_4_4_4:
+		s.levels = [4]float32{15, 15, 15, 15}
+	case webgl.UNSIGNED_SHORT_5_6_5:
+		s.levels = [4]float32{31, 63, 31, 1}
+	case webgl.UNSIGNED_SHORT_5_5_5_1:
+		s.levels = [4]float32{31, 31, 31, 1}
+	}
+	return s
+}
+
+func (s *surface) isColor() bool {
+	return !s.depth && !s.stencil
+}
+
+func (s *surface) pixel(x, y int) []float32 {
+	offset := (y*s.width + x) * 4
+	return s.data[offset : offset+4]
+}
+
+// Stores a color with the precision of the surface, channels disabled by the mask are kept
+func (s *surface) storeColor(x, y int, color [4]float32, mask [4]bool) {
+	pixel := s.pixel(x, y)
+	for i := range color {
+		if !mask[i] {
+			continue
+		}
+		value := color[i]
+		switch {
+		case !s.present[i] && i == 3:
+			value = 1
+		case !s.present[i]:
+			value = 0
+		case s.levels[i] > 0:
+			value = float32(math.Round(float64(clamp01(value)*s.levels[i]))) / s.levels[i]
+		}
+		pixel[i] = value
+	}
+}
+
+// Returns the color of a texel, depth textures give their depth in the red, green and blue channels
+func (s *surface) texel(x, y int) [4]float32 {
+	pixel := s.pixel(x, y)
+	if s.depth {
+		return [4]float32{pixel[0], pixel[0], pixel[0], 1}
+	}
+	return [4]float32{pixel[0], pixel[1], pixel[2], pixel[3]}
+}
+
+type texture struct {
+	object
+	target    types.GLEnum
+	faces     [6][]*surface
+	minFilter types.GLEnum
+	magFilter types.GLEnum
+	wrapS     types.GLEnum
+	wrapT     types.GLEnum
+}
+
+func newTexture() *texture {
+	return &texture{
+		minFilter: webgl.NEAREST_MIPMAP_LINEAR,
+		magFilter: webgl.LINEAR,
+		wrapS:     webgl.REPEAT,
+		wrapT:     webgl.REPEAT,
+	}
+}
+
+// Returns the face of a texImage2D target, -1 when it is not a 2D or cube map face target
+func faceIndex(target types.GLEnum) int {
+	switch {
+	case target == webgl.TEXTURE_2D:
+		return 0
+	case target >= webgl.TEXTURE_CUBE_MAP_POSITIVE_X && target <= webgl.TEXTURE_CUBE_MAP_NEGATIVE_Z:
+		return int(target - webgl.TEXTURE_CUBE_MAP_POSITIVE_X)
+	}
+	return -1
+}
+
+func (tex *texture) level(face int, level int) *surface {
+	if tex == nil || face < 0 || level < 0 || level >= len(tex.faces[face]) {
+		return nil
+	}
+	return tex.faces[face][level]
+}
+
+func (tex *texture) setLevel(face int, level int, s *surface) {
+	for len(tex.faces[face]) <= level {
+		tex.faces[face] = append(tex.faces[face], nil)
+	}
+	tex.faces[face][level] = s
+}
+
+func (tex *texture) setParameter(pName types.GLEnum, param types.GLEnum) {
+	switch pName {
+	case webgl.TEXTURE_MIN_FILTER:
+		tex.minFilter = param
+	case webgl.TEXTURE_MAG_FILTER:
+		tex.magFilter = param
+	case webgl.TEXTURE_WRAP_S:
+		tex.wrapS = param
+	case webgl.TEXTURE_WRAP_T:
+		tex.wrapT = param
+	}
+}
+
+// Textures missing their base level, or the mip levels required by their minification filter,
+// are incomplete and sample as opaque black
+func (tex *texture) isComplete() bool {
+	if tex == nil {
+		return false
+	}
+	faces := 1
+	if tex.target == webgl.TEXTURE_CUBE_MAP {
+		faces = 6
+	}
+	mipmapped := tex.minFilter != webgl.NEAREST && tex.minFilter != webgl.LINEAR
+	for face := 0; face < faces; face++ {
+		base := tex.level(face, 0)
+		if base == nil {
+			return false
+		}
+		if !mipmapped {
+			continue
+		}
+		width, height := base.width, base.height
+		for level := 1; width > 1 || height > 1; level++ {
+			width, height = maxInt(width/2, 1), maxInt(height/2, 1)
+			if s := tex.level(face, level); s == nil || s.width != width || s.height != height {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Fills every mip level from the base level with a box filter
+func (tex *texture) generateMipmap() {
+	for face := range tex.faces {
+		source := tex.level(face, 0)
+		for level := 1; source != nil && (source.width > 1 || source.height > 1); level++ {
+			width, height := maxInt(source.width/2, 1), maxInt(source.height/2, 1)
+			mip := newSurface(width, height, source.format, 0)
+			mip.levels, mip.present = source.levels, source.present
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					var sum [4]float32
+					for _, offset := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+						sx, sy := minInt(x*2+offset[0], source.width-1), minInt(y*2+offset[1], source.height-1)
+						for i, value := range source.pixel(sx, sy) {
+							sum[i] += value / 4
+						}
+					}
+					copy(mip.pixel(x, y), sum[:])
+				}
+			}
+			tex.setLevel(face, level, mip)
+			source = mip
+		}
+	}
+}
+
+// Samples the base level with the magnification filter, as fragments carry no derivatives
+// to select a mip level
+func (tex *texture) sample2D(s, t float32) [4]float32 {
+	if !tex.isComplete() || tex.target != webgl.TEXTURE_2D {
+		return [4]float32{0, 0, 0, 1}
+	}
+	return tex.sampleFace(0, s, t, tex.wrapS, tex.wrapT)
+}
+
+func (tex *texture) sampleCube(x, y, z float32) [4]float32 {
+	if !tex.isComplete() || tex.target != webgl.TEXTURE_CUBE_MAP {
+		return [4]float32{0, 0, 0, 1}
+	}
+
+	var face int
+	var sc, tc, ma float32
+	ax, ay, az := abs(x), abs(y), abs(z)
+	switch {
+	case ax >= ay && ax >= az && x >= 0:
+		face, sc, tc, ma = 0, -z, -y, ax
+	case ax >= ay && ax >= az:
+		face, sc, tc, ma = 1, z, -y, ax
+	case ay >= az && y >= 0:
+		face, sc, tc, ma = 2, x, z, ay
+	case ay >= az:
+		face, sc, tc, ma = 3, x, -z, ay
+	case z >= 0:
+		face, sc, tc, ma = 4, x, -y, az
+	default:
+		face, sc, tc, ma = 5, -x, -y, az
+	}
+	if ma == 0 {
+		return [4]float32{0, 0, 0, 1}
+	}
+	return tex.sampleFace(face, (sc/ma+1)/2, (tc/ma+1)/2, webgl.CLAMP_TO_EDGE, webgl.CLAMP_TO_EDGE)
+}
+
+func (tex *texture) sampleFace(face int, s, t float32, wrapS, wrapT types.GLEnum) [4]float32 {
+	base := tex.level(face, 0)
+	u, v := s*float32(base.width), t*float32(base.height)
+	if tex.magFilter == webgl.NEAREST {
+		x := wrap(int(math.Floor(float64(u))), base.width, wrapS)
+		y := wrap(int(math.Floor(float64(v))), base.height, wrapT)
+		return base.texel(x, y)
+	}
+
+	u, v = u-0.5, v-0.5
+	x0, y0 := int(math.Floor(float64(u))), int(math.Floor(float64(v)))
+	fx, fy := u-float32(x0), v-float32(y0)
+	xs := [2]int{wrap(x0, base.width, wrapS), wrap(x0+1, base.width, wrapS)}
+	ys := [2]int{wrap(y0, base.height, wrapT), wrap(y0+1, base.height, wrapT)}
+	weights := [4]float32{(1 - fx) * (1 - fy), fx * (1 - fy), (1 - fx) * fy, fx * fy}
+	var color [4]float32
+	for i, weight := range weights {
+		texel := base.texel(xs[i%2], ys[i/2])
+		for c := range color {
+			color[c] += texel[c] * weight
+		}
+	}
+	return color
+}
+
+func wrap(coord int, size int, mode types.GLEnum) int {
+	switch mode {
+	case webgl.CLAMP_TO_EDGE:
+		return minInt(maxInt(coord, 0), size-1)
+	case webgl.MIRRORED_REPEAT:
+		period := coord % (2 * size)
+		if period < 0 {
+			period += 2 * size
+		}
+		if period >= size {
+			return 2*size - 1 - period
+		}
+		return period
+	}
+	coord %= size
+	if coord < 0 {
+		coord += size
+	}
+	return coord
+}
+
+func (r *Rasterizer) bindTexture(target types.GLEnum, obj interface{}) {
+	tex, _ := obj.(*texture)
+	if tex != nil {
+		if tex.target == 0 {
+			tex.target = target
+		} else if tex.target != target {
+			r.setError(webgl.INVALID_OPERATION)
+			return
+		}
+	}
+	unit := &r.textureUnits[r.activeTexture]
+	switch target {
+	case webgl.TEXTURE_2D:
+		unit.texture2D = tex
+	case webgl.TEXTURE_CUBE_MAP:
+		unit.cubeMap = tex
+	default:
+		r.setError(webgl.INVALID_ENUM)
+	}
+}
+
+// Returns the texture bound to the active unit for a bind target or a cube map face target
+func (r *Rasterizer) boundTexture(target types.GLEnum) *texture {
+	unit := &r.textureUnits[r.activeTexture]
+	if target == webgl.TEXTURE_2D {
+		return unit.texture2D
+	}
+	if target == webgl.TEXTURE_CUBE_MAP || faceIndex(target) >= 0 {
+		return unit.cubeMap
+	}
+	return nil
+}
+
+// Handles texImage2D(target, level, internalFormat, width, height, border, format, type, pixels[, srcOffset]).
+// The HTML element variants are not supported.
+func (r *Rasterizer) texImage2D(args []interface{}) {
+	if len(args) < 9 {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	target, level := argEnum(args, 0), argInt(args, 1)
+	tex, face := r.boundTexture(target), faceIndex(target)
+	if tex == nil || face < 0 {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	width, height := argInt(args, 3), argInt(args, 4)
+	format, dataType := argEnum(args, 6), argEnum(args, 7)
+	if width < 0 || height < 0 || width > maxTextureSize || height > maxTextureSize {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+
+	s := newSurface(width, height, argEnum(args, 2), dataType)
+	if data := r.unpackData(args[8], args[9:]); data != nil {
+		r.decodePixels(s, 0, 0, width, height, format, dataType, data)
+	}
+	tex.setLevel(face, level, s)
+}
+
+// Handles texSubImage2D(target, level, xOffset, yOffset, width, height, format, type, pixels[, srcOffset])
+func (r *Rasterizer) texSubImage2D(args []interface{}) {
+	if len(args) < 9 {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	target := argEnum(args, 0)
+	s := r.boundTexture(target).level(faceIndex(target), argInt(args, 1))
+	if s == nil {
+		r.setError(webgl.INVALID_OPERATION)
+		return
+	}
+	x, y, width, height := argInt(args, 2), argInt(args, 3), argInt(args, 4), argInt(args, 5)
+	if x < 0 || y < 0 || x+width > s.width || y+height > s.height {
+		r.setError(webgl.INVALID_VALUE)
+		return
+	}
+	if data := r.unpackData(args[8], args[9:]); data != nil {
+		r.decodePixels(s, x, y, width, height, argEnum(args, 6), argEnum(args, 7), data)
+	}
+}
+
+// Returns the bytes of an upload, read from a typed slice or, for a numeric offset, from
+// the buffer bound to PIXEL_UNPACK_BUFFER
+func (r *Rasterizer) unpackData(pixels interface{}, rest []interface{}) []byte {
+	if isNumber(pixels) {
+		buf := r.buffers[webgl.PIXEL_UNPACK_BUFFER]
+		offset := backend.ValueOf(pixels).Int()
+		if buf == nil || offset < 0 || offset > len(buf.data) {
+			r.setError(webgl.INVALID_OPERATION)
+			return nil
+		}
+		return buf.data[offset:]
+	}
+	data, elementSize := toBytes(pixels)
+	if len(rest) > 0 {
+		data = sliceElements(data, elementSize, rest[:1])
+	}
+	return data
+}
+
+// Decodes uploaded rows into a region of a surface, following UNPACK_ALIGNMENT,
+// UNPACK_FLIP_Y_WEBGL and UNPACK_PREMULTIPLY_ALPHA_WEBGL
+func (r *Rasterizer) decodePixels(s *surface, x, y, width, height int, format, dataType types.GLEnum, data []byte) {
+	components := formatComponents(format)
+	pixelSize := components * typeSize(dataType)
+	switch dataType {
+	case webgl.UNSIGNED_SHORT_4_4_4_4, webgl.UNSIGNED_SHORT_5_6_5, webgl.UNSIGNED_SHORT_5_5_5_1:
+		pixelSize = 2
+	case webgl.UNSIGNED_INT_24_8:
+		pixelSize = 4
+	}
+	rowSize := width * pixelSize
+	if align := r.unpackAlign; align > 1 && rowSize%align != 0 {
+		rowSize += align - rowSize%align
+	}
+
+	for row := 0; row < height; row++ {
+		destRow := row
+		if r.unpackFlipY {
+			destRow = height - 1 - row
+		}
+		for column := 0; column < width; column++ {
+			offset := row*rowSize + column*pixelSize
+			if offset+pixelSize > len(data) {
+				r.setError(webgl.INVALID_OPERATION)
+				return
+			}
+			pixel := decodePixel(data[offset:offset+pixelSize], format, dataType)
+			if r.unpackPremulti && s.isColor() {
+				pixel[0], pixel[1], pixel[2] = pixel[0]*pixel[3], pixel[1]*pixel[3], pixel[2]*pixel[3]
+			}
+			dest := s.pixel(x+column, y+destRow)
+			if s.isColor() {
+				s.storeColor(x+column, y+destRow, pixel, [4]bool{true, true, true, true})
+			} else {
+				copy(dest, pixel[:])
+			}
+		}
+	}
+}
+
+func formatComponents(format types.GLEnum) int {
+	switch format {
+	case webgl.RGBA:
+		return 4
+	case webgl.RGB:
+		return 3
+	case webgl.LUMINANCE_ALPHA, webgl.RG:
+		return 2
+	}
+	return 1
+}
+
+// Decodes one pixel into RGBA, or into depth and stencil for depth formats
+func decodePixel(data []byte, format, dataType types.GLEnum) [4]float32 {
+	switch dataType {
+	case webgl.UNSIGNED_SHORT_4_4_4_4:
+		v := binary.LittleEndian.Uint16(data)
+		return [4]float32{float32(v>>12) / 15, float32(v>>8&0xF) / 15, float32(v>>4&0xF) / 15, float32(v&0xF) / 15}
+	case webgl.UNSIGNED_SHORT_5_6_5:
+		v := binary.LittleEndian.Uint16(data)
+		return [4]float32{float32(v>>11) / 31, float32(v>>5&0x3F) / 63, float32(v&0x1F) / 31, 1}
+	case webgl.UNSIGNED_SHORT_5_5_5_1:
+		v := binary.LittleEndian.Uint16(data)
+		return [4]float32{float32(v>>11) / 31, float32(v>>6&0x1F) / 31, float32(v>>1&0x1F) / 31, float32(v & 1)}
+	case webgl.UNSIGNED_INT_24_8:
+		v := binary.LittleEndian.Uint32(data)
+		return [4]float32{float32(v>>8) / 0xFFFFFF, float32(v & 0xFF), 0, 0}
+	}
+
+	size := typeSize(dataType)
+	var values [4]float32
+	for i := 0; i < formatComponents(format); i++ {
+		values[i] = readComponent(data[i*size:], dataType, true)
+	}
+	switch format {
+	case webgl.RGB:
+		return [4]float32{values[0], values[1], values[2], 1}
+	case webgl.LUMINANCE:
+		return [4]float32{values[0], values[0], values[0], 1}
+	case webgl.ALPHA:
+		return [4]float32{0, 0, 0, values[0]}
+	case webgl.LUMINANCE_ALPHA:
+		return [4]float32{values[0], values[0], values[0], values[1]}
+	case webgl.RED:
+		return [4]float32{values[0], 0, 0, 1}
+	case webgl.RG:
+		return [4]float32{values[0], values[1], 0, 1}
+	}
+	return values
+}
+
+func abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
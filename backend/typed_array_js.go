@@ -0,0 +1,187 @@
+package backend
+
+import (
+	"math/bits"
+	"sync"
+	"syscall/js"
+	"unsafe"
+)
+
+// Largest typed array kept for reuse, bigger uploads get a temporary array
+const maxPooledBytes = 16 << 20
+
+// Released arrays kept per element type and size class
+const maxPooledArrays = 4
+
+// JavaScript typed array holding a copy of a Go slice for the duration of a call
+type typedArray struct {
+	pool     *typedArrayPool
+	class    int
+	array    js.Value // Whole pooled array, its length is the size class
+	bytes    js.Value // Uint8Array over the same buffer, used by CopyBytesToJS and CopyBytesToGo
+	view     js.Value // Array of the exact slice length handed to WebGL
+	viewSize int
+}
+
+// Reusable typed arrays of one element type, grouped in power of two size classes
+type typedArrayPool struct {
+	constructor string
+	elemSize    int
+	mutex       sync.Mutex
+	free        map[int][]*typedArray
+}
+
+var (
+	int8Arrays    = newTypedArrayPool("Int8Array", 1)
+	int16Arrays   = newTypedArrayPool("Int16Array", 2)
+	int32Arrays   = newTypedArrayPool("Int32Array", 4)
+	uint8Arrays   = newTypedArrayPool("Uint8Array", 1)
+	uint16Arrays  = newTypedArrayPool("Uint16Array", 2)
+	uint32Arrays  = newTypedArrayPool("Uint32Array", 4)
+	float32Arrays = newTypedArrayPool("Float32Array", 4)
+	float64Arrays = newTypedArrayPool("Float64Array", 8)
+)
+
+func newTypedArrayPool(constructor string, elemSize int) *typedArrayPool {
+	return &typedArrayPool{
+		constructor: constructor,
+		elemSize:    elemSize,
+		free:        make(map[int][]*typedArray),
+	}
+}
+
+// Returns an array of the given length, taken from the pool when one of its size class is free
+func (pool *typedArrayPool) acquire(length int) *typedArray {
+	class := 1
+	if length > 1 {
+		class = 1 << uint(bits.Len(uint(length-1)))
+	}
+
+	pool.mutex.Lock()
+	var array *typedArray
+	if free := pool.free[class]; len(free) > 0 {
+		array = free[len(free)-1]
+		pool.free[class] = free[:len(free)-1]
+	}
+	pool.mutex.Unlock()
+
+	if array == nil {
+		jsArray := js.Global().Get(pool.constructor).New(class)
+		array = &typedArray{
+			pool:     pool,
+			class:    class,
+			array:    jsArray,
+			bytes:    js.Global().Get("Uint8Array").New(jsArray.Get("buffer")),
+			view:     jsArray,
+			viewSize: class,
+		}
+	}
+
+	// WebGL reads the whole array, so shorter lengths use a view of the exact size. The last
+	// view is kept, repeated uploads of the same size, like uniforms, do not allocate.
+	if array.viewSize != length {
+		array.view = array.array.Call("subarray", 0, length)
+		array.viewSize = length
+	}
+	return array
+}
+
+// Gives the array back to its pool, it must not be used anymore
+func (array *typedArray) release() {
+	pool := array.pool
+	if array.class*pool.elemSize > maxPooledBytes {
+		return
+	}
+	pool.mutex.Lock()
+	if free := pool.free[array.class]; len(free) < maxPooledArrays {
+		pool.free[array.class] = append(free, array)
+	}
+	pool.mutex.Unlock()
+}
+
+// Slice copied into a typed array for a call, copied back afterwards when the call writes into it
+type marshalledSlice struct {
+	array *typedArray
+	data  []byte
+}
+
+// Typed arrays created for the arguments of one call
+type marshaller struct {
+	zeroCopy bool
+	slices   []marshalledSlice
+	buffers  []js.Value // Memory buffers under the views of a zero copy call
+}
+
+// Copies a numeric slice into a pooled typed array, nil when the slice is not a numeric type.
+// Zero copy calls get a view onto the slice memory when possible.
+func (m *marshaller) typedArrayOf(slice interface{}) interface{} {
+	if m.zeroCopy {
+		if view := m.memoryViewOf(slice); view != nil {
+			return view
+		}
+	}
+
+	var pool *typedArrayPool
+	var data []byte
+	switch value := slice.(type) {
+	case []int8:
+		pool, data = int8Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 1)
+	case []int16:
+		pool, data = int16Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 2)
+	case []int32:
+		pool, data = int32Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4)
+	case []uint8:
+		pool, data = uint8Arrays, value
+	case []uint16:
+		pool, data = uint16Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 2)
+	case []uint32:
+		pool, data = uint32Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4)
+	case []float32:
+		pool, data = float32Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 4)
+	case []float64:
+		pool, data = float64Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(value)), len(value), 8)
+	case []int:
+		// int is 64 bits wide on wasm, WebGL takes 32 bits integers
+		converted := make([]int32, len(value))
+		for i, v := range value {
+			converted[i] = int32(v)
+		}
+		pool, data = int32Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(converted)), len(converted), 4)
+	case []uint:
+		converted := make([]uint32, len(value))
+		for i, v := range value {
+			converted[i] = uint32(v)
+		}
+		pool, data = uint32Arrays, sliceBytes(unsafe.Pointer(unsafe.SliceData(converted)), len(converted), 4)
+	default:
+		return nil
+	}
+
+	array := pool.acquire(len(data) / pool.elemSize)
+	js.CopyBytesToJS(array.bytes, data)
+	m.slices = append(m.slices, marshalledSlice{array: array, data: data})
+	return array.view
+}
+
+// Copies the typed arrays back into their Go slices, for calls writing into their arguments.
+// Converted []int and []uint slices are not copied back.
+func (m *marshaller) copyBack() {
+	for _, slice := range m.slices {
+		js.CopyBytesToGo(slice.data, slice.array.bytes)
+	}
+}
+
+func (m *marshaller) release() {
+	for _, slice := range m.slices {
+		slice.array.release()
+	}
+	m.slices = nil
+}
+
+// Memory of a numeric slice seen as bytes. wasm and JavaScript typed arrays are both little endian.
+func sliceBytes(data unsafe.Pointer, length int, elemSize int) []byte {
+	if length == 0 {
+		return []byte{}
+	}
+	return unsafe.Slice((*byte)(data), length*elemSize)
+}
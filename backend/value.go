@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type undefinedType struct{}
+
+// Value of pure Go backends wrapping plain Go data
+type goValue struct {
+	data interface{}
+}
+
+var (
+	undefined = goValue{data: undefinedType{}}
+	null      = goValue{data: nil}
+)
+
+func Undefined() Value {
+	return undefined
+}
+
+func Null() Value {
+	return null
+}
+
+// Wraps Go data into a Value. Slices and arrays can be indexed, map[string]interface{} values
+// expose their keys as properties, and data implementing Backend can be called.
+func ValueOf(data interface{}) Value {
+	if value, ok := data.(Value); ok {
+		return value
+	}
+	return goValue{data: data}
+}
+
+// Returns the Go data behind handles and pure Go values, other arguments are returned unchanged
+func Unwrap(arg interface{}) interface{} {
+	if handle, ok := arg.(Handle); ok {
+		value := handle.GetValue()
+		if value == nil {
+			return nil
+		}
+		arg = value
+	}
+	if value, ok := arg.(goValue); ok {
+		if value.IsUndefined() {
+			return nil
+		}
+		return value.data
+	}
+	return arg
+}
+
+func (v goValue) Call(method string, args ...interface{}) Value {
+	if callable, ok := v.data.(Backend); ok {
+		return callable.Call(method, args...)
+	}
+	return undefined
+}
+
+func (v goValue) Get(property string) Value {
+	switch data := v.data.(type) {
+	case Backend:
+		return data.Get(property)
+	case map[string]interface{}:
+		if value, ok := data[property]; ok {
+			return ValueOf(value)
+		}
+	}
+	return undefined
+}
+
+func (v goValue) Index(i int) Value {
+	list := reflect.ValueOf(v.data)
+	if (list.Kind() == reflect.Slice || list.Kind() == reflect.Array) && i >= 0 && i < list.Len() {
+		return ValueOf(list.Index(i).Interface())
+	}
+	return undefined
+}
+
+func (v goValue) Length() int {
+	list := reflect.ValueOf(v.data)
+	switch list.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+		return list.Len()
+	}
+	return 0
+}
+
+func (v goValue) Int() int {
+	return int(v.Float())
+}
+
+func (v goValue) Float() float64 {
+	number := reflect.ValueOf(v.data)
+	switch number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(number.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(number.Uint())
+	case reflect.Float32, reflect.Float64:
+		return number.Float()
+	case reflect.Bool:
+		if number.Bool() {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v goValue) Bool() bool {
+	switch data := v.data.(type) {
+	case nil, undefinedType:
+		return false
+	case bool:
+		return data
+	case string:
+		return data != ""
+	}
+	if number := reflect.ValueOf(v.data); number.Kind() >= reflect.Int && number.Kind() <= reflect.Float64 {
+		return v.Float() != 0
+	}
+	return true
+}
+
+func (v goValue) String() string {
+	switch data := v.data.(type) {
+	case nil:
+		return "null"
+	case undefinedType:
+		return "undefined"
+	case string:
+		return data
+	}
+	return fmt.Sprint(v.data)
+}
+
+func (v goValue) IsNull() bool {
+	return v.data == nil
+}
+
+func (v goValue) IsUndefined() bool {
+	_, ok := v.data.(undefinedType)
+	return ok
+}
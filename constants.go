@@ -625,7 +625,7 @@ const (
 	UNSIGNED_INT_24_8_WEBGL                      types.GLEnum = 0x84FA
 	COMPRESSED_RGBA_ATC_INTERPOLATED_ALPHA_WEBGL types.GLEnum = 0x87EE
 	COMPRESSED_RGB_ATC_WEBGL                     types.GLEnum = 0x8C92
-	COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL     types.GLEnum = 0x8C92
+	COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL     types.GLEnum = 0x8C93
 	COMPRESSED_RGB_ETC1_WEBGL                    types.GLEnum = 0x8D64
 	UNPACK_FLIP_Y_WEBGL                          types.GLEnum = 0x9240
 	UNPACK_PREMULTIPLY_ALPHA_WEBGL               types.GLEnum = 0x9241
@@ -639,6 +639,59 @@ const (
 	MAX_DRAW_BUFFERS_WEBGL                       types.GLEnum = 0x8824
 )
 
+// Texture formats and data types of the extensions
+const (
+	HALF_FLOAT_OES                         types.GLEnum = 0x8D61
+	COMPRESSED_RGB_S3TC_DXT1_EXT           types.GLEnum = 0x83F0
+	COMPRESSED_RGBA_S3TC_DXT1_EXT          types.GLEnum = 0x83F1
+	COMPRESSED_RGBA_S3TC_DXT3_EXT          types.GLEnum = 0x83F2
+	COMPRESSED_RGBA_S3TC_DXT5_EXT          types.GLEnum = 0x83F3
+	COMPRESSED_SRGB_S3TC_DXT1_EXT          types.GLEnum = 0x8C4C
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT1_EXT    types.GLEnum = 0x8C4D
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT3_EXT    types.GLEnum = 0x8C4E
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT5_EXT    types.GLEnum = 0x8C4F
+	COMPRESSED_RED_RGTC1_EXT               types.GLEnum = 0x8DBB
+	COMPRESSED_SIGNED_RED_RGTC1_EXT        types.GLEnum = 0x8DBC
+	COMPRESSED_RED_GREEN_RGTC2_EXT         types.GLEnum = 0x8DBD
+	COMPRESSED_SIGNED_RED_GREEN_RGTC2_EXT  types.GLEnum = 0x8DBE
+	COMPRESSED_RGBA_BPTC_UNORM_EXT         types.GLEnum = 0x8E8C
+	COMPRESSED_SRGB_ALPHA_BPTC_UNORM_EXT   types.GLEnum = 0x8E8D
+	COMPRESSED_RGB_BPTC_SIGNED_FLOAT_EXT   types.GLEnum = 0x8E8E
+	COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT_EXT types.GLEnum = 0x8E8F
+	COMPRESSED_RGB_PVRTC_4BPPV1_IMG        types.GLEnum = 0x8C00
+	COMPRESSED_RGB_PVRTC_2BPPV1_IMG        types.GLEnum = 0x8C01
+	COMPRESSED_RGBA_PVRTC_4BPPV1_IMG       types.GLEnum = 0x8C02
+	COMPRESSED_RGBA_PVRTC_2BPPV1_IMG       types.GLEnum = 0x8C03
+	COMPRESSED_RGBA_ASTC_4x4_KHR           types.GLEnum = 0x93B0
+	COMPRESSED_RGBA_ASTC_5x4_KHR           types.GLEnum = 0x93B1
+	COMPRESSED_RGBA_ASTC_5x5_KHR           types.GLEnum = 0x93B2
+	COMPRESSED_RGBA_ASTC_6x5_KHR           types.GLEnum = 0x93B3
+	COMPRESSED_RGBA_ASTC_6x6_KHR           types.GLEnum = 0x93B4
+	COMPRESSED_RGBA_ASTC_8x5_KHR           types.GLEnum = 0x93B5
+	COMPRESSED_RGBA_ASTC_8x6_KHR           types.GLEnum = 0x93B6
+	COMPRESSED_RGBA_ASTC_8x8_KHR           types.GLEnum = 0x93B7
+	COMPRESSED_RGBA_ASTC_10x5_KHR          types.GLEnum = 0x93B8
+	COMPRESSED_RGBA_ASTC_10x6_KHR          types.GLEnum = 0x93B9
+	COMPRESSED_RGBA_ASTC_10x8_KHR          types.GLEnum = 0x93BA
+	COMPRESSED_RGBA_ASTC_10x10_KHR         types.GLEnum = 0x93BB
+	COMPRESSED_RGBA_ASTC_12x10_KHR         types.GLEnum = 0x93BC
+	COMPRESSED_RGBA_ASTC_12x12_KHR         types.GLEnum = 0x93BD
+	COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR   types.GLEnum = 0x93D0
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x4_KHR   types.GLEnum = 0x93D1
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x5_KHR   types.GLEnum = 0x93D2
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x5_KHR   types.GLEnum = 0x93D3
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x6_KHR   types.GLEnum = 0x93D4
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x5_KHR   types.GLEnum = 0x93D5
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x6_KHR   types.GLEnum = 0x93D6
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x8_KHR   types.GLEnum = 0x93D7
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x5_KHR  types.GLEnum = 0x93D8
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x6_KHR  types.GLEnum = 0x93D9
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x8_KHR  types.GLEnum = 0x93DA
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x10_KHR types.GLEnum = 0x93DB
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x10_KHR types.GLEnum = 0x93DC
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x12_KHR types.GLEnum = 0x93DD
+)
+
 const (
 	DefaultPower         types.PowerPreference = "default"
 	HighPerformancePower types.PowerPreference = "high-performance"
@@ -0,0 +1,148 @@
+package webgl
+
+import (
+	"errors"
+	"github.com/nuberu/webgl/types"
+)
+
+type ContextEvent int
+
+const (
+	ContextLost ContextEvent = iota
+	ContextRestored
+)
+
+func (event ContextEvent) String() string {
+	if event == ContextLost {
+		return "context lost"
+	}
+	return "context restored"
+}
+
+// Resource created again when the context is restored, like a texture uploaded from an asset.
+// Restorables run in registration order, so a program can be registered after its shaders.
+type Restorable interface {
+	Restore(gl *RenderingContext) error
+}
+
+// Function used as a Restorable
+type RestorableFunc func(gl *RenderingContext) error
+
+func (fn RestorableFunc) Restore(gl *RenderingContext) error {
+	return fn(gl)
+}
+
+type restorableEntry struct {
+	id         int
+	restorable Restorable
+}
+
+// Handlers and resources of the context loss events
+type contextLossState struct {
+	restorables      []restorableEntry
+	nextRestorableId int
+	lostHandlers     []func()
+	restoredHandlers []func(err error)
+	channels         []chan ContextEvent
+	removeListeners  func()
+}
+
+// Stamps a created object with the current generation, it becomes invalid on context loss
+func (c *RenderingContext) adopt(obj *types.Object) {
+	obj.SetGeneration(c.generation)
+}
+
+// Calls fn when the context is lost. Objects created before are invalid from then on.
+func (c *RenderingContext) OnContextLost(fn func()) {
+	c.loss.lostHandlers = append(c.loss.lostHandlers, fn)
+}
+
+// Calls fn when the context is restored, after the restorables ran. err joins their errors.
+func (c *RenderingContext) OnContextRestored(fn func(err error)) {
+	c.loss.restoredHandlers = append(c.loss.restoredHandlers, fn)
+}
+
+// Returns a channel receiving the context loss events. Events are dropped when the buffer of
+// the channel is full, as they are sent from the browser event handlers.
+func (c *RenderingContext) ContextEvents(buffer int) <-chan ContextEvent {
+	channel := make(chan ContextEvent, buffer)
+	c.loss.channels = append(c.loss.channels, channel)
+	return channel
+}
+
+// Registers a resource created again when the context is restored, the returned function
+// unregisters it
+func (c *RenderingContext) AddRestorable(restorable Restorable) (remove func()) {
+	c.loss.nextRestorableId++
+	id := c.loss.nextRestorableId
+	c.loss.restorables = append(c.loss.restorables, restorableEntry{id: id, restorable: restorable})
+	return func() {
+		for i, entry := range c.loss.restorables {
+			if entry.id == id {
+				c.loss.restorables = append(c.loss.restorables[:i], c.loss.restorables[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Handles the loss of the context, called by the canvas listeners of WrapContext. Backends without
+// events, like the headless one, may call it to simulate a loss.
+func (c *RenderingContext) NotifyContextLost() {
+	c.generation.Lose()
+	c.contextLost = true
+	c.resetExtensions()
+	c.forgetObjects()
+	c.memory.reset()
+	c.InvalidateStateCache()
+	for _, handler := range c.loss.lostHandlers {
+		handler()
+	}
+	c.sendContextEvent(ContextLost)
+}
+
+// Handles the restoration of the context: a new generation of objects starts, and the
+// restorables create their resources again
+func (c *RenderingContext) NotifyContextRestored() {
+	c.generation = types.NewGeneration()
+	c.contextLost = false
+	c.pendingError = nil
+	c.resetExtensions()
+	c.InvalidateStateCache()
+
+	var errs []error
+	for _, entry := range c.loss.restorables {
+		if err := entry.restorable.Restore(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	err := errors.Join(errs...)
+	for _, handler := range c.loss.restoredHandlers {
+		handler(err)
+	}
+	c.sendContextEvent(ContextRestored)
+}
+
+func (c *RenderingContext) sendContextEvent(event ContextEvent) {
+	for _, channel := range c.loss.channels {
+		select {
+		case channel <- event:
+		default:
+		}
+	}
+}
+
+// Extension objects belong to the lost context, they are loaded again on first use
+func (c *RenderingContext) resetExtensions() {
+	c.vertexArrayExt = nil
+	c.instancedArraysExt = nil
+	c.drawBuffersExt = nil
+}
+
+// Removes the canvas listeners registered by WrapContext
+func (c *RenderingContext) Release() {
+	if c.loss.removeListeners != nil {
+		c.loss.removeListeners()
+		c.loss.removeListeners = nil
+	}
+}
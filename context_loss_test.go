@@ -0,0 +1,86 @@
+package webgl_test
+
+import (
+	"errors"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/backend/mock"
+	"github.com/nuberu/webgl/types"
+	"testing"
+)
+
+func expectLostObjectError(t *testing.T, gl *webgl.RenderingContext, method string, object types.Tracked) {
+	t.Helper()
+	var lost *webgl.LostObjectError
+	if err := gl.GetError(); !errors.As(err, &lost) {
+		t.Fatalf("got error %v, expected a LostObjectError", err)
+	}
+	if lost.Method != method || lost.Object != object {
+		t.Fatalf("got %q, expected the error of %s on %s", lost, method, webgl.ObjectName(object))
+	}
+}
+
+// Restorables create their objects again, the handles of the lost context are rejected
+func TestContextLossRestore(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	gl.EnableObjectChecks()
+	var buffer *types.Buffer
+	gl.AddRestorable(webgl.RestorableFunc(func(gl *webgl.RenderingContext) error {
+		buffer = gl.CreateBuffer()
+		return nil
+	}))
+	buffer = gl.CreateBuffer()
+	old := buffer
+
+	gl.NotifyContextLost()
+	if !old.IsLost() {
+		t.Fatal("the buffer is not lost after the context loss")
+	}
+	var restored []error
+	gl.OnContextRestored(func(err error) { restored = append(restored, err) })
+	recorder.Reset()
+	gl.NotifyContextRestored()
+	if len(restored) != 1 || restored[0] != nil {
+		t.Fatalf("got restored events %v, expected one without error", restored)
+	}
+	if buffer == old || buffer.IsLost() {
+		t.Fatal("the restorable did not create the buffer again")
+	}
+	if err := recorder.ExpectCalls(mock.NewCall("createBuffer")); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder.Reset()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, old)
+	expectLostObjectError(t, gl, "bindBuffer", old)
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	if err := recorder.ExpectCalls(mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, buffer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+	if live := gl.LiveObjects(); len(live) != 1 || backend.Unwrap(live[0]) != backend.Unwrap(buffer) {
+		t.Fatalf("got live objects %v, expected the restored buffer", live)
+	}
+}
+
+// Deleting an object of a lost context is skipped, with or without object checks
+func TestDeleteLostObject(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	texture := gl.CreateTexture()
+	gl.NotifyContextLost()
+	gl.NotifyContextRestored()
+	recorder.Reset()
+
+	gl.DeleteTexture(texture)
+	expectLostObjectError(t, gl, "DeleteTexture", texture)
+	if err := recorder.ExpectCalls(); err != nil {
+		t.Fatal(err)
+	}
+	if texture.IsDeleted() {
+		t.Fatal("the lost texture was marked deleted")
+	}
+}
@@ -0,0 +1,143 @@
+package webgl
+
+import (
+	"fmt"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"log"
+	"reflect"
+	"runtime/debug"
+	"strings"
+)
+
+// Receives the calls raising a WebGL error while the debug mode is enabled
+type DebugHandler func(err *CallError)
+
+// Logs the failed call with its Go stack
+func LogCallErrors(err *CallError) {
+	log.Printf("webgl: %v\n%s", err, err.Stack)
+}
+
+// Panics with the failed call, so the offending code shows in the panic trace
+func PanicOnCallErrors(err *CallError) {
+	panic(err)
+}
+
+// Returns a handler appending the failed calls to errs
+func CollectCallErrors(errs *[]*CallError) DebugHandler {
+	return func(err *CallError) {
+		*errs = append(*errs, err)
+	}
+}
+
+// Backend checking getError after every call, installed by EnableDebug
+type debugBackend struct {
+	backend.Backend
+	handler DebugHandler
+
+	// Errors consumed by the checks, one per code like the flags of GL, returned by the next getError
+	// calls of the application before the ones of the backend
+	pending []types.GLEnum
+}
+
+// Checks getError after every call and reports the ones raising an error to the handler, with
+// their arguments and Go stack. The errors stay visible to GetError. Calls on extension objects
+// are not checked. Debugging costs a getError round trip per call, nothing once disabled.
+func (c *RenderingContext) EnableDebug(handler DebugHandler) {
+	if debugging, ok := c.findLayer((*debugBackend)(nil)).(*debugBackend); ok {
+		debugging.handler = handler
+		return
+	}
+	c.addLayer(&debugBackend{handler: handler})
+}
+
+// Stops checking the calls, the context talks to its backend directly again
+func (c *RenderingContext) DisableDebug() {
+	c.removeLayer((*debugBackend)(nil))
+}
+
+func (c *RenderingContext) IsDebugEnabled() bool {
+	return c.findLayer((*debugBackend)(nil)) != nil
+}
+
+func (b *debugBackend) Unwrap() backend.Backend {
+	return b.Backend
+}
+
+func (b *debugBackend) wrap(inner backend.Backend) {
+	b.Backend = inner
+}
+
+func (b *debugBackend) Call(method string, args ...interface{}) backend.Value {
+	if method == "getError" {
+		if len(b.pending) > 0 {
+			code := b.pending[0]
+			b.pending = b.pending[1:]
+			return backend.ValueOf(code)
+		}
+		return b.Backend.Call(method)
+	}
+
+	result := b.Backend.Call(method, args...)
+	code := types.GLEnum(b.Backend.Call("getError").Int())
+	if code == NO_ERROR {
+		return result
+	}
+	b.consume(code)
+	b.handler(&CallError{
+		Method: method,
+		Args:   args,
+		Code:   code,
+		Stack:  debug.Stack(),
+	})
+	return result
+}
+
+// Keeps an error for the application, once per code until it is returned
+func (b *debugBackend) consume(code types.GLEnum) {
+	for _, pending := range b.pending {
+		if pending == code {
+			return
+		}
+	}
+	b.pending = append(b.pending, code)
+}
+
+// Formats call arguments with enum names, like "ARRAY_BUFFER, []float32(9), STATIC_DRAW"
+func FormatArgs(args []interface{}) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		formatted[i] = formatArg(arg)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func formatArg(arg interface{}) string {
+	switch value := arg.(type) {
+	case nil:
+		return "null"
+	case types.GLEnum:
+		return EnumName(value)
+	case []types.GLEnum:
+		names := make([]string, len(value))
+		for i, enum := range value {
+			names[i] = EnumName(enum)
+		}
+		return "[" + strings.Join(names, ", ") + "]"
+	case string:
+		return fmt.Sprintf("%q", value)
+	case backend.Handle:
+		if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Ptr && reflected.IsNil() {
+			return "null"
+		}
+		return strings.TrimPrefix(fmt.Sprintf("%T", value), "*types.")
+	case backend.Value:
+		return "value"
+	}
+
+	reflected := reflect.ValueOf(arg)
+	if reflected.Kind() == reflect.Slice {
+		return fmt.Sprintf("%s(%d)", reflected.Type(), reflected.Len())
+	}
+	return fmt.Sprint(arg)
+}
@@ -0,0 +1,681 @@
+package webgl
+
+import (
+	"fmt"
+	"github.com/nuberu/webgl/types"
+)
+
+// Names of the enum values, values shared by several constants take the first name declared
+var enumNames = map[types.GLEnum]string{
+	POINTS:                               "POINTS",
+	LINES:                                "LINES",
+	LINE_LOOP:                            "LINE_LOOP",
+	LINE_STRIP:                           "LINE_STRIP",
+	TRIANGLES:                            "TRIANGLES",
+	TRIANGLE_STRIP:                       "TRIANGLE_STRIP",
+	TRIANGLE_FAN:                         "TRIANGLE_FAN",
+	SRC_COLOR:                            "SRC_COLOR",
+	ONE_MINUS_SRC_COLOR:                  "ONE_MINUS_SRC_COLOR",
+	SRC_ALPHA:                            "SRC_ALPHA",
+	ONE_MINUS_SRC_ALPHA:                  "ONE_MINUS_SRC_ALPHA",
+	DST_ALPHA:                            "DST_ALPHA",
+	ONE_MINUS_DST_ALPHA:                  "ONE_MINUS_DST_ALPHA",
+	DST_COLOR:                            "DST_COLOR",
+	ONE_MINUS_DST_COLOR:                  "ONE_MINUS_DST_COLOR",
+	SRC_ALPHA_SATURATE:                   "SRC_ALPHA_SATURATE",
+	FUNC_ADD:                             "FUNC_ADD",
+	BLEND_EQUATION:                       "BLEND_EQUATION",
+	BLEND_EQUATION_ALPHA:                 "BLEND_EQUATION_ALPHA",
+	FUNC_SUBTRACT:                        "FUNC_SUBTRACT",
+	FUNC_REVERSE_SUBTRACT:                "FUNC_REVERSE_SUBTRACT",
+	BLEND_DST_RGB:                        "BLEND_DST_RGB",
+	BLEND_SRC_RGB:                        "BLEND_SRC_RGB",
+	BLEND_DST_ALPHA:                      "BLEND_DST_ALPHA",
+	BLEND_SRC_ALPHA:                      "BLEND_SRC_ALPHA",
+	CONSTANT_COLOR:                       "CONSTANT_COLOR",
+	ONE_MINUS_CONSTANT_COLOR:             "ONE_MINUS_CONSTANT_COLOR",
+	CONSTANT_ALPHA:                       "CONSTANT_ALPHA",
+	ONE_MINUS_CONSTANT_ALPHA:             "ONE_MINUS_CONSTANT_ALPHA",
+	BLEND_COLOR:                          "BLEND_COLOR",
+	ARRAY_BUFFER:                         "ARRAY_BUFFER",
+	ELEMENT_ARRAY_BUFFER:                 "ELEMENT_ARRAY_BUFFER",
+	ARRAY_BUFFER_BINDING:                 "ARRAY_BUFFER_BINDING",
+	ELEMENT_ARRAY_BUFFER_BINDING:         "ELEMENT_ARRAY_BUFFER_BINDING",
+	STREAM_DRAW:                          "STREAM_DRAW",
+	STATIC_DRAW:                          "STATIC_DRAW",
+	DYNAMIC_DRAW:                         "DYNAMIC_DRAW",
+	BUFFER_SIZE:                          "BUFFER_SIZE",
+	BUFFER_USAGE:                         "BUFFER_USAGE",
+	CURRENT_VERTEX_ATTRIB:                "CURRENT_VERTEX_ATTRIB",
+	FRONT:                                "FRONT",
+	BACK:                                 "BACK",
+	FRONT_AND_BACK:                       "FRONT_AND_BACK",
+	TEXTURE_2D:                           "TEXTURE_2D",
+	CULL_FACE:                            "CULL_FACE",
+	BLEND:                                "BLEND",
+	DITHER:                               "DITHER",
+	STENCIL_TEST:                         "STENCIL_TEST",
+	DEPTH_TEST:                           "DEPTH_TEST",
+	SCISSOR_TEST:                         "SCISSOR_TEST",
+	POLYGON_OFFSET_FILL:                  "POLYGON_OFFSET_FILL",
+	SAMPLE_ALPHA_TO_COVERAGE:             "SAMPLE_ALPHA_TO_COVERAGE",
+	SAMPLE_COVERAGE:                      "SAMPLE_COVERAGE",
+	INVALID_ENUM:                         "INVALID_ENUM",
+	INVALID_VALUE:                        "INVALID_VALUE",
+	INVALID_OPERATION:                    "INVALID_OPERATION",
+	OUT_OF_MEMORY:                        "OUT_OF_MEMORY",
+	CW:                                   "CW",
+	CCW:                                  "CCW",
+	LINE_WIDTH:                           "LINE_WIDTH",
+	ALIASED_POINT_SIZE_RANGE:             "ALIASED_POINT_SIZE_RANGE",
+	ALIASED_LINE_WIDTH_RANGE:             "ALIASED_LINE_WIDTH_RANGE",
+	CULL_FACE_MODE:                       "CULL_FACE_MODE",
+	FRONT_FACE:                           "FRONT_FACE",
+	DEPTH_RANGE:                          "DEPTH_RANGE",
+	DEPTH_WRITEMASK:                      "DEPTH_WRITEMASK",
+	DEPTH_CLEAR_VALUE:                    "DEPTH_CLEAR_VALUE",
+	DEPTH_FUNC:                           "DEPTH_FUNC",
+	STENCIL_CLEAR_VALUE:                  "STENCIL_CLEAR_VALUE",
+	STENCIL_FUNC:                         "STENCIL_FUNC",
+	STENCIL_FAIL:                         "STENCIL_FAIL",
+	STENCIL_PASS_DEPTH_FAIL:              "STENCIL_PASS_DEPTH_FAIL",
+	STENCIL_PASS_DEPTH_PASS:              "STENCIL_PASS_DEPTH_PASS",
+	STENCIL_REF:                          "STENCIL_REF",
+	STENCIL_VALUE_MASK:                   "STENCIL_VALUE_MASK",
+	STENCIL_WRITEMASK:                    "STENCIL_WRITEMASK",
+	STENCIL_BACK_FUNC:                    "STENCIL_BACK_FUNC",
+	STENCIL_BACK_FAIL:                    "STENCIL_BACK_FAIL",
+	STENCIL_BACK_PASS_DEPTH_FAIL:         "STENCIL_BACK_PASS_DEPTH_FAIL",
+	STENCIL_BACK_PASS_DEPTH_PASS:         "STENCIL_BACK_PASS_DEPTH_PASS",
+	STENCIL_BACK_REF:                     "STENCIL_BACK_REF",
+	STENCIL_BACK_VALUE_MASK:              "STENCIL_BACK_VALUE_MASK",
+	STENCIL_BACK_WRITEMASK:               "STENCIL_BACK_WRITEMASK",
+	VIEWPORT:                             "VIEWPORT",
+	SCISSOR_BOX:                          "SCISSOR_BOX",
+	COLOR_CLEAR_VALUE:                    "COLOR_CLEAR_VALUE",
+	COLOR_WRITEMASK:                      "COLOR_WRITEMASK",
+	UNPACK_ALIGNMENT:                     "UNPACK_ALIGNMENT",
+	PACK_ALIGNMENT:                       "PACK_ALIGNMENT",
+	MAX_TEXTURE_SIZE:                     "MAX_TEXTURE_SIZE",
+	MAX_VIEWPORT_DIMS:                    "MAX_VIEWPORT_DIMS",
+	SUBPIXEL_BITS:                        "SUBPIXEL_BITS",
+	RED_BITS:                             "RED_BITS",
+	GREEN_BITS:                           "GREEN_BITS",
+	BLUE_BITS:                            "BLUE_BITS",
+	ALPHA_BITS:                           "ALPHA_BITS",
+	DEPTH_BITS:                           "DEPTH_BITS",
+	STENCIL_BITS:                         "STENCIL_BITS",
+	POLYGON_OFFSET_UNITS:                 "POLYGON_OFFSET_UNITS",
+	POLYGON_OFFSET_FACTOR:                "POLYGON_OFFSET_FACTOR",
+	TEXTURE_BINDING_2D:                   "TEXTURE_BINDING_2D",
+	SAMPLE_BUFFERS:                       "SAMPLE_BUFFERS",
+	SAMPLES:                              "SAMPLES",
+	SAMPLE_COVERAGE_VALUE:                "SAMPLE_COVERAGE_VALUE",
+	SAMPLE_COVERAGE_INVERT:               "SAMPLE_COVERAGE_INVERT",
+	NUM_COMPRESSED_TEXTURE_FORMATS:       "NUM_COMPRESSED_TEXTURE_FORMATS",
+	COMPRESSED_TEXTURE_FORMATS:           "COMPRESSED_TEXTURE_FORMATS",
+	DONT_CARE:                            "DONT_CARE",
+	FASTEST:                              "FASTEST",
+	NICEST:                               "NICEST",
+	GENERATE_MIPMAP_HINT:                 "GENERATE_MIPMAP_HINT",
+	BYTE:                                 "BYTE",
+	UNSIGNED_BYTE:                        "UNSIGNED_BYTE",
+	SHORT:                                "SHORT",
+	UNSIGNED_SHORT:                       "UNSIGNED_SHORT",
+	INT:                                  "INT",
+	UNSIGNED_INT:                         "UNSIGNED_INT",
+	FLOAT:                                "FLOAT",
+	FIXED:                                "FIXED",
+	DEPTH_COMPONENT:                      "DEPTH_COMPONENT",
+	ALPHA:                                "ALPHA",
+	RGB:                                  "RGB",
+	RGBA:                                 "RGBA",
+	LUMINANCE:                            "LUMINANCE",
+	LUMINANCE_ALPHA:                      "LUMINANCE_ALPHA",
+	UNSIGNED_SHORT_4_4_4_4:               "UNSIGNED_SHORT_4_4_4_4",
+	UNSIGNED_SHORT_5_5_5_1:               "UNSIGNED_SHORT_5_5_5_1",
+	UNSIGNED_SHORT_5_6_5:                 "UNSIGNED_SHORT_5_6_5",
+	MAX_VERTEX_ATTRIBS:                   "MAX_VERTEX_ATTRIBS",
+	MAX_VERTEX_UNIFORM_VECTORS:           "MAX_VERTEX_UNIFORM_VECTORS",
+	MAX_VARYING_VECTORS:                  "MAX_VARYING_VECTORS",
+	MAX_COMBINED_TEXTURE_IMAGE_UNITS:     "MAX_COMBINED_TEXTURE_IMAGE_UNITS",
+	MAX_VERTEX_TEXTURE_IMAGE_UNITS:       "MAX_VERTEX_TEXTURE_IMAGE_UNITS",
+	MAX_TEXTURE_IMAGE_UNITS:              "MAX_TEXTURE_IMAGE_UNITS",
+	MAX_FRAGMENT_UNIFORM_VECTORS:         "MAX_FRAGMENT_UNIFORM_VECTORS",
+	SHADER_TYPE:                          "SHADER_TYPE",
+	DELETE_STATUS:                        "DELETE_STATUS",
+	LINK_STATUS:                          "LINK_STATUS",
+	VALIDATE_STATUS:                      "VALIDATE_STATUS",
+	ATTACHED_SHADERS:                     "ATTACHED_SHADERS",
+	ACTIVE_UNIFORMS:                      "ACTIVE_UNIFORMS",
+	ACTIVE_UNIFORM_MAX_LENGTH:            "ACTIVE_UNIFORM_MAX_LENGTH",
+	ACTIVE_ATTRIBUTES:                    "ACTIVE_ATTRIBUTES",
+	ACTIVE_ATTRIBUTE_MAX_LENGTH:          "ACTIVE_ATTRIBUTE_MAX_LENGTH",
+	SHADING_LANGUAGE_VERSION:             "SHADING_LANGUAGE_VERSION",
+	CURRENT_PROGRAM:                      "CURRENT_PROGRAM",
+	NEVER:                                "NEVER",
+	LESS:                                 "LESS",
+	EQUAL:                                "EQUAL",
+	LEQUAL:                               "LEQUAL",
+	GREATER:                              "GREATER",
+	NOTEQUAL:                             "NOTEQUAL",
+	GEQUAL:                               "GEQUAL",
+	ALWAYS:                               "ALWAYS",
+	KEEP:                                 "KEEP",
+	REPLACE:                              "REPLACE",
+	INCR:                                 "INCR",
+	DECR:                                 "DECR",
+	INVERT:                               "INVERT",
+	INCR_WRAP:                            "INCR_WRAP",
+	DECR_WRAP:                            "DECR_WRAP",
+	VENDOR:                               "VENDOR",
+	RENDERER:                             "RENDERER",
+	VERSION:                              "VERSION",
+	EXTENSIONS:                           "EXTENSIONS",
+	NEAREST:                              "NEAREST",
+	LINEAR:                               "LINEAR",
+	NEAREST_MIPMAP_NEAREST:               "NEAREST_MIPMAP_NEAREST",
+	LINEAR_MIPMAP_NEAREST:                "LINEAR_MIPMAP_NEAREST",
+	NEAREST_MIPMAP_LINEAR:                "NEAREST_MIPMAP_LINEAR",
+	LINEAR_MIPMAP_LINEAR:                 "LINEAR_MIPMAP_LINEAR",
+	TEXTURE_MAG_FILTER:                   "TEXTURE_MAG_FILTER",
+	TEXTURE_MIN_FILTER:                   "TEXTURE_MIN_FILTER",
+	TEXTURE_WRAP_S:                       "TEXTURE_WRAP_S",
+	TEXTURE_WRAP_T:                       "TEXTURE_WRAP_T",
+	TEXTURE:                              "TEXTURE",
+	TEXTURE_CUBE_MAP:                     "TEXTURE_CUBE_MAP",
+	TEXTURE_BINDING_CUBE_MAP:             "TEXTURE_BINDING_CUBE_MAP",
+	TEXTURE_CUBE_MAP_POSITIVE_X:          "TEXTURE_CUBE_MAP_POSITIVE_X",
+	TEXTURE_CUBE_MAP_NEGATIVE_X:          "TEXTURE_CUBE_MAP_NEGATIVE_X",
+	TEXTURE_CUBE_MAP_POSITIVE_Y:          "TEXTURE_CUBE_MAP_POSITIVE_Y",
+	TEXTURE_CUBE_MAP_NEGATIVE_Y:          "TEXTURE_CUBE_MAP_NEGATIVE_Y",
+	TEXTURE_CUBE_MAP_POSITIVE_Z:          "TEXTURE_CUBE_MAP_POSITIVE_Z",
+	TEXTURE_CUBE_MAP_NEGATIVE_Z:          "TEXTURE_CUBE_MAP_NEGATIVE_Z",
+	MAX_CUBE_MAP_TEXTURE_SIZE:            "MAX_CUBE_MAP_TEXTURE_SIZE",
+	TEXTURE0:                             "TEXTURE0",
+	TEXTURE1:                             "TEXTURE1",
+	TEXTURE2:                             "TEXTURE2",
+	TEXTURE3:                             "TEXTURE3",
+	TEXTURE4:                             "TEXTURE4",
+	TEXTURE5:                             "TEXTURE5",
+	TEXTURE6:                             "TEXTURE6",
+	TEXTURE7:                             "TEXTURE7",
+	TEXTURE8:                             "TEXTURE8",
+	TEXTURE9:                             "TEXTURE9",
+	TEXTURE10:                            "TEXTURE10",
+	TEXTURE11:                            "TEXTURE11",
+	TEXTURE12:                            "TEXTURE12",
+	TEXTURE13:                            "TEXTURE13",
+	TEXTURE14:                            "TEXTURE14",
+	TEXTURE15:                            "TEXTURE15",
+	TEXTURE16:                            "TEXTURE16",
+	TEXTURE17:                            "TEXTURE17",
+	TEXTURE18:                            "TEXTURE18",
+	TEXTURE19:                            "TEXTURE19",
+	TEXTURE20:                            "TEXTURE20",
+	TEXTURE21:                            "TEXTURE21",
+	TEXTURE22:                            "TEXTURE22",
+	TEXTURE23:                            "TEXTURE23",
+	TEXTURE24:                            "TEXTURE24",
+	TEXTURE25:                            "TEXTURE25",
+	TEXTURE26:                            "TEXTURE26",
+	TEXTURE27:                            "TEXTURE27",
+	TEXTURE28:                            "TEXTURE28",
+	TEXTURE29:                            "TEXTURE29",
+	TEXTURE30:                            "TEXTURE30",
+	TEXTURE31:                            "TEXTURE31",
+	ACTIVE_TEXTURE:                       "ACTIVE_TEXTURE",
+	REPEAT:                               "REPEAT",
+	CLAMP_TO_EDGE:                        "CLAMP_TO_EDGE",
+	MIRRORED_REPEAT:                      "MIRRORED_REPEAT",
+	VERTEX_ATTRIB_ARRAY_ENABLED:          "VERTEX_ATTRIB_ARRAY_ENABLED",
+	VERTEX_ATTRIB_ARRAY_SIZE:             "VERTEX_ATTRIB_ARRAY_SIZE",
+	VERTEX_ATTRIB_ARRAY_STRIDE:           "VERTEX_ATTRIB_ARRAY_STRIDE",
+	VERTEX_ATTRIB_ARRAY_TYPE:             "VERTEX_ATTRIB_ARRAY_TYPE",
+	VERTEX_ATTRIB_ARRAY_NORMALIZED:       "VERTEX_ATTRIB_ARRAY_NORMALIZED",
+	VERTEX_ATTRIB_ARRAY_POINTER:          "VERTEX_ATTRIB_ARRAY_POINTER",
+	VERTEX_ATTRIB_ARRAY_BUFFER_BINDING:   "VERTEX_ATTRIB_ARRAY_BUFFER_BINDING",
+	IMPLEMENTATION_COLOR_READ_TYPE:       "IMPLEMENTATION_COLOR_READ_TYPE",
+	IMPLEMENTATION_COLOR_READ_FORMAT:     "IMPLEMENTATION_COLOR_READ_FORMAT",
+	COMPILE_STATUS:                       "COMPILE_STATUS",
+	INFO_LOG_LENGTH:                      "INFO_LOG_LENGTH",
+	SHADER_SOURCE_LENGTH:                 "SHADER_SOURCE_LENGTH",
+	SHADER_COMPILER:                      "SHADER_COMPILER",
+	SHADER_BINARY_FORMATS:                "SHADER_BINARY_FORMATS",
+	NUM_SHADER_BINARY_FORMATS:            "NUM_SHADER_BINARY_FORMATS",
+	LOW_FLOAT:                            "LOW_FLOAT",
+	MEDIUM_FLOAT:                         "MEDIUM_FLOAT",
+	HIGH_FLOAT:                           "HIGH_FLOAT",
+	LOW_INT:                              "LOW_INT",
+	MEDIUM_INT:                           "MEDIUM_INT",
+	HIGH_INT:                             "HIGH_INT",
+	FRAMEBUFFER:                          "FRAMEBUFFER",
+	RENDERBUFFER:                         "RENDERBUFFER",
+	RGBA4:                                "RGBA4",
+	RGB5_A1:                              "RGB5_A1",
+	RGB565:                               "RGB565",
+	DEPTH_COMPONENT16:                    "DEPTH_COMPONENT16",
+	STENCIL_INDEX8:                       "STENCIL_INDEX8",
+	RENDERBUFFER_WIDTH:                   "RENDERBUFFER_WIDTH",
+	RENDERBUFFER_HEIGHT:                  "RENDERBUFFER_HEIGHT",
+	RENDERBUFFER_INTERNAL_FORMAT:         "RENDERBUFFER_INTERNAL_FORMAT",
+	RENDERBUFFER_RED_SIZE:                "RENDERBUFFER_RED_SIZE",
+	RENDERBUFFER_GREEN_SIZE:              "RENDERBUFFER_GREEN_SIZE",
+	RENDERBUFFER_BLUE_SIZE:               "RENDERBUFFER_BLUE_SIZE",
+	RENDERBUFFER_ALPHA_SIZE:              "RENDERBUFFER_ALPHA_SIZE",
+	RENDERBUFFER_DEPTH_SIZE:              "RENDERBUFFER_DEPTH_SIZE",
+	RENDERBUFFER_STENCIL_SIZE:            "RENDERBUFFER_STENCIL_SIZE",
+	FRAMEBUFFER_ATTACHMENT_OBJECT_TYPE:   "FRAMEBUFFER_ATTACHMENT_OBJECT_TYPE",
+	FRAMEBUFFER_ATTACHMENT_OBJECT_NAME:   "FRAMEBUFFER_ATTACHMENT_OBJECT_NAME",
+	FRAMEBUFFER_ATTACHMENT_TEXTURE_LEVEL: "FRAMEBUFFER_ATTACHMENT_TEXTURE_LEVEL",
+
+	FRAMEBUFFER_ATTACHMENT_TEXTURE_CUBE_MAP_FACE: "FRAMEBUFFER_ATTACHMENT_TEXTURE_CUBE_MAP_FACE",
+
+	COLOR_ATTACHMENT0:                         "COLOR_ATTACHMENT0",
+	DEPTH_ATTACHMENT:                          "DEPTH_ATTACHMENT",
+	STENCIL_ATTACHMENT:                        "STENCIL_ATTACHMENT",
+	FRAMEBUFFER_COMPLETE:                      "FRAMEBUFFER_COMPLETE",
+	FRAMEBUFFER_INCOMPLETE_ATTACHMENT:         "FRAMEBUFFER_INCOMPLETE_ATTACHMENT",
+	FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT: "FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT",
+	FRAMEBUFFER_INCOMPLETE_DIMENSIONS:         "FRAMEBUFFER_INCOMPLETE_DIMENSIONS",
+	FRAMEBUFFER_UNSUPPORTED:                   "FRAMEBUFFER_UNSUPPORTED",
+	FRAMEBUFFER_BINDING:                       "FRAMEBUFFER_BINDING",
+	RENDERBUFFER_BINDING:                      "RENDERBUFFER_BINDING",
+	MAX_RENDERBUFFER_SIZE:                     "MAX_RENDERBUFFER_SIZE",
+	INVALID_FRAMEBUFFER_OPERATION:             "INVALID_FRAMEBUFFER_OPERATION",
+
+	DEPTH_BUFFER_BIT:   "DEPTH_BUFFER_BIT",
+	STENCIL_BUFFER_BIT: "STENCIL_BUFFER_BIT",
+	COLOR_BUFFER_BIT:   "COLOR_BUFFER_BIT",
+
+	FLOAT_VEC2:   "FLOAT_VEC2",
+	FLOAT_VEC3:   "FLOAT_VEC3",
+	FLOAT_VEC4:   "FLOAT_VEC4",
+	INT_VEC2:     "INT_VEC2",
+	INT_VEC3:     "INT_VEC3",
+	INT_VEC4:     "INT_VEC4",
+	BOOL:         "BOOL",
+	BOOL_VEC2:    "BOOL_VEC2",
+	BOOL_VEC3:    "BOOL_VEC3",
+	BOOL_VEC4:    "BOOL_VEC4",
+	FLOAT_MAT2:   "FLOAT_MAT2",
+	FLOAT_MAT3:   "FLOAT_MAT3",
+	FLOAT_MAT4:   "FLOAT_MAT4",
+	SAMPLER_2D:   "SAMPLER_2D",
+	SAMPLER_CUBE: "SAMPLER_CUBE",
+
+	FRAGMENT_SHADER: "FRAGMENT_SHADER",
+	VERTEX_SHADER:   "VERTEX_SHADER",
+
+	ACTIVE_UNIFORM_BLOCK_MAX_NAME_LENGTH:      "ACTIVE_UNIFORM_BLOCK_MAX_NAME_LENGTH",
+	ACTIVE_UNIFORM_BLOCKS:                     "ACTIVE_UNIFORM_BLOCKS",
+	ALREADY_SIGNALED:                          "ALREADY_SIGNALED",
+	ANY_SAMPLES_PASSED:                        "ANY_SAMPLES_PASSED",
+	ANY_SAMPLES_PASSED_CONSERVATIVE:           "ANY_SAMPLES_PASSED_CONSERVATIVE",
+	BLUE:                                      "BLUE",
+	BUFFER_ACCESS_FLAGS:                       "BUFFER_ACCESS_FLAGS",
+	BUFFER_MAP_LENGTH:                         "BUFFER_MAP_LENGTH",
+	BUFFER_MAP_OFFSET:                         "BUFFER_MAP_OFFSET",
+	BUFFER_MAPPED:                             "BUFFER_MAPPED",
+	BUFFER_MAP_POINTER:                        "BUFFER_MAP_POINTER",
+	COLOR:                                     "COLOR",
+	COLOR_ATTACHMENT10:                        "COLOR_ATTACHMENT10",
+	COLOR_ATTACHMENT1:                         "COLOR_ATTACHMENT1",
+	COLOR_ATTACHMENT11:                        "COLOR_ATTACHMENT11",
+	COLOR_ATTACHMENT12:                        "COLOR_ATTACHMENT12",
+	COLOR_ATTACHMENT13:                        "COLOR_ATTACHMENT13",
+	COLOR_ATTACHMENT14:                        "COLOR_ATTACHMENT14",
+	COLOR_ATTACHMENT15:                        "COLOR_ATTACHMENT15",
+	COLOR_ATTACHMENT2:                         "COLOR_ATTACHMENT2",
+	COLOR_ATTACHMENT3:                         "COLOR_ATTACHMENT3",
+	COLOR_ATTACHMENT4:                         "COLOR_ATTACHMENT4",
+	COLOR_ATTACHMENT5:                         "COLOR_ATTACHMENT5",
+	COLOR_ATTACHMENT6:                         "COLOR_ATTACHMENT6",
+	COLOR_ATTACHMENT7:                         "COLOR_ATTACHMENT7",
+	COLOR_ATTACHMENT8:                         "COLOR_ATTACHMENT8",
+	COLOR_ATTACHMENT9:                         "COLOR_ATTACHMENT9",
+	COMPARE_REF_TO_TEXTURE:                    "COMPARE_REF_TO_TEXTURE",
+	COMPRESSED_R11_EAC:                        "COMPRESSED_R11_EAC",
+	COMPRESSED_RG11_EAC:                       "COMPRESSED_RG11_EAC",
+	COMPRESSED_RGB8_ETC2:                      "COMPRESSED_RGB8_ETC2",
+	COMPRESSED_RGB8_PUNCHTHROUGH_ALPHA1_ETC2:  "COMPRESSED_RGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	COMPRESSED_RGBA8_ETC2_EAC:                 "COMPRESSED_RGBA8_ETC2_EAC",
+	COMPRESSED_SIGNED_R11_EAC:                 "COMPRESSED_SIGNED_R11_EAC",
+	COMPRESSED_SIGNED_RG11_EAC:                "COMPRESSED_SIGNED_RG11_EAC",
+	COMPRESSED_SRGB8_ALPHA8_ETC2_EAC:          "COMPRESSED_SRGB8_ALPHA8_ETC2_EAC",
+	COMPRESSED_SRGB8_ETC2:                     "COMPRESSED_SRGB8_ETC2",
+	COMPRESSED_SRGB8_PUNCHTHROUGH_ALPHA1_ETC2: "COMPRESSED_SRGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	CONDITION_SATISFIED:                       "CONDITION_SATISFIED",
+	COPY_READ_BUFFER:                          "COPY_READ_BUFFER",
+	COPY_WRITE_BUFFER:                         "COPY_WRITE_BUFFER",
+	CURRENT_QUERY:                             "CURRENT_QUERY",
+	DEPTH:                                     "DEPTH",
+	DEPTH24_STENCIL8:                          "DEPTH24_STENCIL8",
+	DEPTH32F_STENCIL8:                         "DEPTH32F_STENCIL8",
+	DEPTH_COMPONENT24:                         "DEPTH_COMPONENT24",
+	DEPTH_COMPONENT32F:                        "DEPTH_COMPONENT32F",
+	DEPTH_STENCIL:                             "DEPTH_STENCIL",
+	DEPTH_STENCIL_ATTACHMENT:                  "DEPTH_STENCIL_ATTACHMENT",
+	DRAW_BUFFER0:                              "DRAW_BUFFER0",
+	DRAW_BUFFER10:                             "DRAW_BUFFER10",
+	DRAW_BUFFER1:                              "DRAW_BUFFER1",
+	DRAW_BUFFER11:                             "DRAW_BUFFER11",
+	DRAW_BUFFER12:                             "DRAW_BUFFER12",
+	DRAW_BUFFER13:                             "DRAW_BUFFER13",
+	DRAW_BUFFER14:                             "DRAW_BUFFER14",
+	DRAW_BUFFER15:                             "DRAW_BUFFER15",
+	DRAW_BUFFER2:                              "DRAW_BUFFER2",
+	DRAW_BUFFER3:                              "DRAW_BUFFER3",
+	DRAW_BUFFER4:                              "DRAW_BUFFER4",
+	DRAW_BUFFER5:                              "DRAW_BUFFER5",
+	DRAW_BUFFER6:                              "DRAW_BUFFER6",
+	DRAW_BUFFER7:                              "DRAW_BUFFER7",
+	DRAW_BUFFER8:                              "DRAW_BUFFER8",
+	DRAW_BUFFER9:                              "DRAW_BUFFER9",
+	DRAW_FRAMEBUFFER:                          "DRAW_FRAMEBUFFER",
+	DYNAMIC_COPY:                              "DYNAMIC_COPY",
+	DYNAMIC_READ:                              "DYNAMIC_READ",
+	FLOAT_32_UNSIGNED_INT_24_8_REV:            "FLOAT_32_UNSIGNED_INT_24_8_REV",
+	FLOAT_MAT2x3:                              "FLOAT_MAT2x3",
+	FLOAT_MAT2x4:                              "FLOAT_MAT2x4",
+	FLOAT_MAT3x2:                              "FLOAT_MAT3x2",
+	FLOAT_MAT3x4:                              "FLOAT_MAT3x4",
+	FLOAT_MAT4x2:                              "FLOAT_MAT4x2",
+	FLOAT_MAT4x3:                              "FLOAT_MAT4x3",
+	FRAGMENT_SHADER_DERIVATIVE_HINT:           "FRAGMENT_SHADER_DERIVATIVE_HINT",
+	FRAMEBUFFER_ATTACHMENT_ALPHA_SIZE:         "FRAMEBUFFER_ATTACHMENT_ALPHA_SIZE",
+	FRAMEBUFFER_ATTACHMENT_BLUE_SIZE:          "FRAMEBUFFER_ATTACHMENT_BLUE_SIZE",
+	FRAMEBUFFER_ATTACHMENT_COLOR_ENCODING:     "FRAMEBUFFER_ATTACHMENT_COLOR_ENCODING",
+	FRAMEBUFFER_ATTACHMENT_COMPONENT_TYPE:     "FRAMEBUFFER_ATTACHMENT_COMPONENT_TYPE",
+	FRAMEBUFFER_ATTACHMENT_DEPTH_SIZE:         "FRAMEBUFFER_ATTACHMENT_DEPTH_SIZE",
+	FRAMEBUFFER_ATTACHMENT_GREEN_SIZE:         "FRAMEBUFFER_ATTACHMENT_GREEN_SIZE",
+	FRAMEBUFFER_ATTACHMENT_RED_SIZE:           "FRAMEBUFFER_ATTACHMENT_RED_SIZE",
+	FRAMEBUFFER_ATTACHMENT_STENCIL_SIZE:       "FRAMEBUFFER_ATTACHMENT_STENCIL_SIZE",
+	FRAMEBUFFER_ATTACHMENT_TEXTURE_LAYER:      "FRAMEBUFFER_ATTACHMENT_TEXTURE_LAYER",
+	FRAMEBUFFER_DEFAULT:                       "FRAMEBUFFER_DEFAULT",
+	FRAMEBUFFER_INCOMPLETE_MULTISAMPLE:        "FRAMEBUFFER_INCOMPLETE_MULTISAMPLE",
+	FRAMEBUFFER_UNDEFINED:                     "FRAMEBUFFER_UNDEFINED",
+	GREEN:                                     "GREEN",
+	HALF_FLOAT:                                "HALF_FLOAT",
+	INT_2_10_10_10_REV:                        "INT_2_10_10_10_REV",
+	INTERLEAVED_ATTRIBS:                       "INTERLEAVED_ATTRIBS",
+	INT_SAMPLER_2D:                            "INT_SAMPLER_2D",
+	INT_SAMPLER_2D_ARRAY:                      "INT_SAMPLER_2D_ARRAY",
+	INT_SAMPLER_3D:                            "INT_SAMPLER_3D",
+	INT_SAMPLER_CUBE:                          "INT_SAMPLER_CUBE",
+	INVALID_INDEX:                             "INVALID_INDEX",
+	MAJOR_VERSION:                             "MAJOR_VERSION",
+	MAP_FLUSH_EXPLICIT_BIT:                    "MAP_FLUSH_EXPLICIT_BIT",
+	MAP_INVALIDATE_BUFFER_BIT:                 "MAP_INVALIDATE_BUFFER_BIT",
+	MAP_UNSYNCHRONIZED_BIT:                    "MAP_UNSYNCHRONIZED_BIT",
+	MAX:                                       "MAX",
+	MAX_3D_TEXTURE_SIZE:                       "MAX_3D_TEXTURE_SIZE",
+	MAX_ARRAY_TEXTURE_LAYERS:                  "MAX_ARRAY_TEXTURE_LAYERS",
+	MAX_COLOR_ATTACHMENTS:                     "MAX_COLOR_ATTACHMENTS",
+	MAX_COMBINED_FRAGMENT_UNIFORM_COMPONENTS:  "MAX_COMBINED_FRAGMENT_UNIFORM_COMPONENTS",
+	MAX_COMBINED_UNIFORM_BLOCKS:               "MAX_COMBINED_UNIFORM_BLOCKS",
+	MAX_COMBINED_VERTEX_UNIFORM_COMPONENTS:    "MAX_COMBINED_VERTEX_UNIFORM_COMPONENTS",
+	MAX_DRAW_BUFFERS:                          "MAX_DRAW_BUFFERS",
+	MAX_ELEMENT_INDEX:                         "MAX_ELEMENT_INDEX",
+	MAX_ELEMENTS_INDICES:                      "MAX_ELEMENTS_INDICES",
+	MAX_ELEMENTS_VERTICES:                     "MAX_ELEMENTS_VERTICES",
+	MAX_FRAGMENT_INPUT_COMPONENTS:             "MAX_FRAGMENT_INPUT_COMPONENTS",
+	MAX_FRAGMENT_UNIFORM_BLOCKS:               "MAX_FRAGMENT_UNIFORM_BLOCKS",
+	MAX_FRAGMENT_UNIFORM_COMPONENTS:           "MAX_FRAGMENT_UNIFORM_COMPONENTS",
+	MAX_PROGRAM_TEXEL_OFFSET:                  "MAX_PROGRAM_TEXEL_OFFSET",
+	MAX_SAMPLES:                               "MAX_SAMPLES",
+	MAX_SERVER_WAIT_TIMEOUT:                   "MAX_SERVER_WAIT_TIMEOUT",
+	MAX_TEXTURE_LOD_BIAS:                      "MAX_TEXTURE_LOD_BIAS",
+
+	MAX_TRANSFORM_FEEDBACK_INTERLEAVED_COMPONENTS: "MAX_TRANSFORM_FEEDBACK_INTERLEAVED_COMPONENTS",
+	MAX_TRANSFORM_FEEDBACK_SEPARATE_ATTRIBS:       "MAX_TRANSFORM_FEEDBACK_SEPARATE_ATTRIBS",
+	MAX_TRANSFORM_FEEDBACK_SEPARATE_COMPONENTS:    "MAX_TRANSFORM_FEEDBACK_SEPARATE_COMPONENTS",
+	MAX_UNIFORM_BLOCK_SIZE:                        "MAX_UNIFORM_BLOCK_SIZE",
+	MAX_UNIFORM_BUFFER_BINDINGS:                   "MAX_UNIFORM_BUFFER_BINDINGS",
+	MAX_VARYING_COMPONENTS:                        "MAX_VARYING_COMPONENTS",
+	MAX_VERTEX_OUTPUT_COMPONENTS:                  "MAX_VERTEX_OUTPUT_COMPONENTS",
+	MAX_VERTEX_UNIFORM_BLOCKS:                     "MAX_VERTEX_UNIFORM_BLOCKS",
+	MAX_VERTEX_UNIFORM_COMPONENTS:                 "MAX_VERTEX_UNIFORM_COMPONENTS",
+	MIN:                                           "MIN",
+	MINOR_VERSION:                                 "MINOR_VERSION",
+	MIN_PROGRAM_TEXEL_OFFSET:                      "MIN_PROGRAM_TEXEL_OFFSET",
+	NUM_EXTENSIONS:                                "NUM_EXTENSIONS",
+	NUM_PROGRAM_BINARY_FORMATS:                    "NUM_PROGRAM_BINARY_FORMATS",
+	NUM_SAMPLE_COUNTS:                             "NUM_SAMPLE_COUNTS",
+	OBJECT_TYPE:                                   "OBJECT_TYPE",
+	PACK_ROW_LENGTH:                               "PACK_ROW_LENGTH",
+	PACK_SKIP_PIXELS:                              "PACK_SKIP_PIXELS",
+	PACK_SKIP_ROWS:                                "PACK_SKIP_ROWS",
+	PIXEL_PACK_BUFFER:                             "PIXEL_PACK_BUFFER",
+	PIXEL_PACK_BUFFER_BINDING:                     "PIXEL_PACK_BUFFER_BINDING",
+	PIXEL_UNPACK_BUFFER:                           "PIXEL_UNPACK_BUFFER",
+	PIXEL_UNPACK_BUFFER_BINDING:                   "PIXEL_UNPACK_BUFFER_BINDING",
+	PRIMITIVE_RESTART_FIXED_INDEX:                 "PRIMITIVE_RESTART_FIXED_INDEX",
+	PROGRAM_BINARY_FORMATS:                        "PROGRAM_BINARY_FORMATS",
+	PROGRAM_BINARY_LENGTH:                         "PROGRAM_BINARY_LENGTH",
+	PROGRAM_BINARY_RETRIEVABLE_HINT:               "PROGRAM_BINARY_RETRIEVABLE_HINT",
+	QUERY_RESULT:                                  "QUERY_RESULT",
+	QUERY_RESULT_AVAILABLE:                        "QUERY_RESULT_AVAILABLE",
+	R11F_G11F_B10F:                                "R11F_G11F_B10F",
+	R16F:                                          "R16F",
+	R16I:                                          "R16I",
+	R16UI:                                         "R16UI",
+	R32F:                                          "R32F",
+	R32I:                                          "R32I",
+	R32UI:                                         "R32UI",
+	R8:                                            "R8",
+	R8I:                                           "R8I",
+	R8_SNORM:                                      "R8_SNORM",
+	R8UI:                                          "R8UI",
+	RASTERIZER_DISCARD:                            "RASTERIZER_DISCARD",
+	READ_BUFFER:                                   "READ_BUFFER",
+	READ_FRAMEBUFFER:                              "READ_FRAMEBUFFER",
+	READ_FRAMEBUFFER_BINDING:                      "READ_FRAMEBUFFER_BINDING",
+	RED:                                           "RED",
+	RED_INTEGER:                                   "RED_INTEGER",
+	RENDERBUFFER_SAMPLES:                          "RENDERBUFFER_SAMPLES",
+	RG:                                            "RG",
+	RG16F:                                         "RG16F",
+	RG16I:                                         "RG16I",
+	RG16UI:                                        "RG16UI",
+	RG32F:                                         "RG32F",
+	RG32I:                                         "RG32I",
+	RG32UI:                                        "RG32UI",
+	RG8:                                           "RG8",
+	RG8I:                                          "RG8I",
+	RG8_SNORM:                                     "RG8_SNORM",
+	RG8UI:                                         "RG8UI",
+	RGB10_A2:                                      "RGB10_A2",
+	RGB10_A2UI:                                    "RGB10_A2UI",
+	RGB16F:                                        "RGB16F",
+	RGB16I:                                        "RGB16I",
+	RGB16UI:                                       "RGB16UI",
+	RGB32F:                                        "RGB32F",
+	RGB32I:                                        "RGB32I",
+	RGB32UI:                                       "RGB32UI",
+	RGB8:                                          "RGB8",
+	RGB8I:                                         "RGB8I",
+	RGB8_SNORM:                                    "RGB8_SNORM",
+	RGB8UI:                                        "RGB8UI",
+	RGB9_E5:                                       "RGB9_E5",
+	RGBA16F:                                       "RGBA16F",
+	RGBA16I:                                       "RGBA16I",
+	RGBA16UI:                                      "RGBA16UI",
+	RGBA32F:                                       "RGBA32F",
+	RGBA32I:                                       "RGBA32I",
+	RGBA32UI:                                      "RGBA32UI",
+	RGBA8:                                         "RGBA8",
+	RGBA8I:                                        "RGBA8I",
+	RGBA8_SNORM:                                   "RGBA8_SNORM",
+	RGBA8UI:                                       "RGBA8UI",
+	RGBA_INTEGER:                                  "RGBA_INTEGER",
+	RGB_INTEGER:                                   "RGB_INTEGER",
+	RG_INTEGER:                                    "RG_INTEGER",
+	SAMPLER_2D_ARRAY:                              "SAMPLER_2D_ARRAY",
+	SAMPLER_2D_ARRAY_SHADOW:                       "SAMPLER_2D_ARRAY_SHADOW",
+	SAMPLER_2D_SHADOW:                             "SAMPLER_2D_SHADOW",
+	SAMPLER_3D:                                    "SAMPLER_3D",
+	SAMPLER_BINDING:                               "SAMPLER_BINDING",
+	SAMPLER_CUBE_SHADOW:                           "SAMPLER_CUBE_SHADOW",
+	SEPARATE_ATTRIBS:                              "SEPARATE_ATTRIBS",
+	SIGNALED:                                      "SIGNALED",
+	SIGNED_NORMALIZED:                             "SIGNED_NORMALIZED",
+	SRGB:                                          "SRGB",
+	SRGB8:                                         "SRGB8",
+	SRGB8_ALPHA8:                                  "SRGB8_ALPHA8",
+	STATIC_COPY:                                   "STATIC_COPY",
+	STATIC_READ:                                   "STATIC_READ",
+	STENCIL:                                       "STENCIL",
+	STREAM_COPY:                                   "STREAM_COPY",
+	STREAM_READ:                                   "STREAM_READ",
+	SYNC_CONDITION:                                "SYNC_CONDITION",
+	SYNC_FENCE:                                    "SYNC_FENCE",
+	SYNC_FLAGS:                                    "SYNC_FLAGS",
+	SYNC_GPU_COMMANDS_COMPLETE:                    "SYNC_GPU_COMMANDS_COMPLETE",
+	SYNC_STATUS:                                   "SYNC_STATUS",
+	TEXTURE_2D_ARRAY:                              "TEXTURE_2D_ARRAY",
+	TEXTURE_3D:                                    "TEXTURE_3D",
+	TEXTURE_BASE_LEVEL:                            "TEXTURE_BASE_LEVEL",
+	TEXTURE_BINDING_2D_ARRAY:                      "TEXTURE_BINDING_2D_ARRAY",
+	TEXTURE_BINDING_3D:                            "TEXTURE_BINDING_3D",
+	TEXTURE_COMPARE_FUNC:                          "TEXTURE_COMPARE_FUNC",
+	TEXTURE_COMPARE_MODE:                          "TEXTURE_COMPARE_MODE",
+	TEXTURE_IMMUTABLE_FORMAT:                      "TEXTURE_IMMUTABLE_FORMAT",
+	TEXTURE_IMMUTABLE_LEVELS:                      "TEXTURE_IMMUTABLE_LEVELS",
+	TEXTURE_MAX_LEVEL:                             "TEXTURE_MAX_LEVEL",
+	TEXTURE_MAX_LOD:                               "TEXTURE_MAX_LOD",
+	TEXTURE_MIN_LOD:                               "TEXTURE_MIN_LOD",
+	TEXTURE_SWIZZLE_A:                             "TEXTURE_SWIZZLE_A",
+	TEXTURE_SWIZZLE_B:                             "TEXTURE_SWIZZLE_B",
+	TEXTURE_SWIZZLE_G:                             "TEXTURE_SWIZZLE_G",
+	TEXTURE_SWIZZLE_R:                             "TEXTURE_SWIZZLE_R",
+	TEXTURE_WRAP_R:                                "TEXTURE_WRAP_R",
+	TIMEOUT_EXPIRED:                               "TIMEOUT_EXPIRED",
+	TRANSFORM_FEEDBACK:                            "TRANSFORM_FEEDBACK",
+	TRANSFORM_FEEDBACK_ACTIVE:                     "TRANSFORM_FEEDBACK_ACTIVE",
+	TRANSFORM_FEEDBACK_BINDING:                    "TRANSFORM_FEEDBACK_BINDING",
+	TRANSFORM_FEEDBACK_BUFFER:                     "TRANSFORM_FEEDBACK_BUFFER",
+	TRANSFORM_FEEDBACK_BUFFER_BINDING:             "TRANSFORM_FEEDBACK_BUFFER_BINDING",
+	TRANSFORM_FEEDBACK_BUFFER_MODE:                "TRANSFORM_FEEDBACK_BUFFER_MODE",
+	TRANSFORM_FEEDBACK_BUFFER_SIZE:                "TRANSFORM_FEEDBACK_BUFFER_SIZE",
+	TRANSFORM_FEEDBACK_BUFFER_START:               "TRANSFORM_FEEDBACK_BUFFER_START",
+	TRANSFORM_FEEDBACK_PAUSED:                     "TRANSFORM_FEEDBACK_PAUSED",
+	TRANSFORM_FEEDBACK_PRIMITIVES_WRITTEN:         "TRANSFORM_FEEDBACK_PRIMITIVES_WRITTEN",
+	TRANSFORM_FEEDBACK_VARYING_MAX_LENGTH:         "TRANSFORM_FEEDBACK_VARYING_MAX_LENGTH",
+	TRANSFORM_FEEDBACK_VARYINGS:                   "TRANSFORM_FEEDBACK_VARYINGS",
+	UNIFORM_ARRAY_STRIDE:                          "UNIFORM_ARRAY_STRIDE",
+	UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES:          "UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES",
+	UNIFORM_BLOCK_ACTIVE_UNIFORMS:                 "UNIFORM_BLOCK_ACTIVE_UNIFORMS",
+	UNIFORM_BLOCK_BINDING:                         "UNIFORM_BLOCK_BINDING",
+	UNIFORM_BLOCK_DATA_SIZE:                       "UNIFORM_BLOCK_DATA_SIZE",
+	UNIFORM_BLOCK_INDEX:                           "UNIFORM_BLOCK_INDEX",
+	UNIFORM_BLOCK_NAME_LENGTH:                     "UNIFORM_BLOCK_NAME_LENGTH",
+
+	UNIFORM_BLOCK_REFERENCED_BY_FRAGMENT_SHADER: "UNIFORM_BLOCK_REFERENCED_BY_FRAGMENT_SHADER",
+	UNIFORM_BLOCK_REFERENCED_BY_VERTEX_SHADER:   "UNIFORM_BLOCK_REFERENCED_BY_VERTEX_SHADER",
+
+	UNIFORM_BUFFER:                  "UNIFORM_BUFFER",
+	UNIFORM_BUFFER_BINDING:          "UNIFORM_BUFFER_BINDING",
+	UNIFORM_BUFFER_OFFSET_ALIGNMENT: "UNIFORM_BUFFER_OFFSET_ALIGNMENT",
+	UNIFORM_BUFFER_SIZE:             "UNIFORM_BUFFER_SIZE",
+	UNIFORM_BUFFER_START:            "UNIFORM_BUFFER_START",
+	UNIFORM_IS_ROW_MAJOR:            "UNIFORM_IS_ROW_MAJOR",
+	UNIFORM_MATRIX_STRIDE:           "UNIFORM_MATRIX_STRIDE",
+	UNIFORM_NAME_LENGTH:             "UNIFORM_NAME_LENGTH",
+	UNIFORM_OFFSET:                  "UNIFORM_OFFSET",
+	UNIFORM_SIZE:                    "UNIFORM_SIZE",
+	UNIFORM_TYPE:                    "UNIFORM_TYPE",
+	UNPACK_IMAGE_HEIGHT:             "UNPACK_IMAGE_HEIGHT",
+	UNPACK_ROW_LENGTH:               "UNPACK_ROW_LENGTH",
+	UNPACK_SKIP_IMAGES:              "UNPACK_SKIP_IMAGES",
+	UNPACK_SKIP_PIXELS:              "UNPACK_SKIP_PIXELS",
+	UNPACK_SKIP_ROWS:                "UNPACK_SKIP_ROWS",
+	UNSIGNALED:                      "UNSIGNALED",
+	UNSIGNED_INT_10F_11F_11F_REV:    "UNSIGNED_INT_10F_11F_11F_REV",
+	UNSIGNED_INT_2_10_10_10_REV:     "UNSIGNED_INT_2_10_10_10_REV",
+	UNSIGNED_INT_24_8:               "UNSIGNED_INT_24_8",
+	UNSIGNED_INT_5_9_9_9_REV:        "UNSIGNED_INT_5_9_9_9_REV",
+	UNSIGNED_INT_SAMPLER_2D:         "UNSIGNED_INT_SAMPLER_2D",
+	UNSIGNED_INT_SAMPLER_2D_ARRAY:   "UNSIGNED_INT_SAMPLER_2D_ARRAY",
+	UNSIGNED_INT_SAMPLER_3D:         "UNSIGNED_INT_SAMPLER_3D",
+	UNSIGNED_INT_SAMPLER_CUBE:       "UNSIGNED_INT_SAMPLER_CUBE",
+	UNSIGNED_INT_VEC2:               "UNSIGNED_INT_VEC2",
+	UNSIGNED_INT_VEC3:               "UNSIGNED_INT_VEC3",
+	UNSIGNED_INT_VEC4:               "UNSIGNED_INT_VEC4",
+	UNSIGNED_NORMALIZED:             "UNSIGNED_NORMALIZED",
+	VERTEX_ARRAY_BINDING:            "VERTEX_ARRAY_BINDING",
+	VERTEX_ATTRIB_ARRAY_DIVISOR:     "VERTEX_ATTRIB_ARRAY_DIVISOR",
+	VERTEX_ATTRIB_ARRAY_INTEGER:     "VERTEX_ATTRIB_ARRAY_INTEGER",
+	WAIT_FAILED:                     "WAIT_FAILED",
+
+	COMPRESSED_RGBA_ATC_INTERPOLATED_ALPHA_WEBGL: "COMPRESSED_RGBA_ATC_INTERPOLATED_ALPHA_WEBGL",
+	COMPRESSED_RGB_ATC_WEBGL:                     "COMPRESSED_RGB_ATC_WEBGL",
+	COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL:     "COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL",
+	COMPRESSED_RGB_ETC1_WEBGL:                    "COMPRESSED_RGB_ETC1_WEBGL",
+	UNPACK_FLIP_Y_WEBGL:                          "UNPACK_FLIP_Y_WEBGL",
+	UNPACK_PREMULTIPLY_ALPHA_WEBGL:               "UNPACK_PREMULTIPLY_ALPHA_WEBGL",
+	CONTEXT_LOST_WEBGL:                           "CONTEXT_LOST_WEBGL",
+	UNPACK_COLORSPACE_CONVERSION_WEBGL:           "UNPACK_COLORSPACE_CONVERSION_WEBGL",
+	BROWSER_DEFAULT_WEBGL:                        "BROWSER_DEFAULT_WEBGL",
+	UNMASKED_VENDOR_WEBGL:                        "UNMASKED_VENDOR_WEBGL",
+	UNMASKED_RENDERER_WEBGL:                      "UNMASKED_RENDERER_WEBGL",
+	MAX_CLIENT_WAIT_TIMEOUT_WEBGL:                "MAX_CLIENT_WAIT_TIMEOUT_WEBGL",
+
+	HALF_FLOAT_OES:                         "HALF_FLOAT_OES",
+	COMPRESSED_RGB_S3TC_DXT1_EXT:           "COMPRESSED_RGB_S3TC_DXT1_EXT",
+	COMPRESSED_RGBA_S3TC_DXT1_EXT:          "COMPRESSED_RGBA_S3TC_DXT1_EXT",
+	COMPRESSED_RGBA_S3TC_DXT3_EXT:          "COMPRESSED_RGBA_S3TC_DXT3_EXT",
+	COMPRESSED_RGBA_S3TC_DXT5_EXT:          "COMPRESSED_RGBA_S3TC_DXT5_EXT",
+	COMPRESSED_SRGB_S3TC_DXT1_EXT:          "COMPRESSED_SRGB_S3TC_DXT1_EXT",
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT1_EXT:    "COMPRESSED_SRGB_ALPHA_S3TC_DXT1_EXT",
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT3_EXT:    "COMPRESSED_SRGB_ALPHA_S3TC_DXT3_EXT",
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT5_EXT:    "COMPRESSED_SRGB_ALPHA_S3TC_DXT5_EXT",
+	COMPRESSED_RED_RGTC1_EXT:               "COMPRESSED_RED_RGTC1_EXT",
+	COMPRESSED_SIGNED_RED_RGTC1_EXT:        "COMPRESSED_SIGNED_RED_RGTC1_EXT",
+	COMPRESSED_RED_GREEN_RGTC2_EXT:         "COMPRESSED_RED_GREEN_RGTC2_EXT",
+	COMPRESSED_SIGNED_RED_GREEN_RGTC2_EXT:  "COMPRESSED_SIGNED_RED_GREEN_RGTC2_EXT",
+	COMPRESSED_RGBA_BPTC_UNORM_EXT:         "COMPRESSED_RGBA_BPTC_UNORM_EXT",
+	COMPRESSED_SRGB_ALPHA_BPTC_UNORM_EXT:   "COMPRESSED_SRGB_ALPHA_BPTC_UNORM_EXT",
+	COMPRESSED_RGB_BPTC_SIGNED_FLOAT_EXT:   "COMPRESSED_RGB_BPTC_SIGNED_FLOAT_EXT",
+	COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT_EXT: "COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT_EXT",
+	COMPRESSED_RGB_PVRTC_4BPPV1_IMG:        "COMPRESSED_RGB_PVRTC_4BPPV1_IMG",
+	COMPRESSED_RGB_PVRTC_2BPPV1_IMG:        "COMPRESSED_RGB_PVRTC_2BPPV1_IMG",
+	COMPRESSED_RGBA_PVRTC_4BPPV1_IMG:       "COMPRESSED_RGBA_PVRTC_4BPPV1_IMG",
+	COMPRESSED_RGBA_PVRTC_2BPPV1_IMG:       "COMPRESSED_RGBA_PVRTC_2BPPV1_IMG",
+	COMPRESSED_RGBA_ASTC_4x4_KHR:           "COMPRESSED_RGBA_ASTC_4x4_KHR",
+	COMPRESSED_RGBA_ASTC_5x4_KHR:           "COMPRESSED_RGBA_ASTC_5x4_KHR",
+	COMPRESSED_RGBA_ASTC_5x5_KHR:           "COMPRESSED_RGBA_ASTC_5x5_KHR",
+	COMPRESSED_RGBA_ASTC_6x5_KHR:           "COMPRESSED_RGBA_ASTC_6x5_KHR",
+	COMPRESSED_RGBA_ASTC_6x6_KHR:           "COMPRESSED_RGBA_ASTC_6x6_KHR",
+	COMPRESSED_RGBA_ASTC_8x5_KHR:           "COMPRESSED_RGBA_ASTC_8x5_KHR",
+	COMPRESSED_RGBA_ASTC_8x6_KHR:           "COMPRESSED_RGBA_ASTC_8x6_KHR",
+	COMPRESSED_RGBA_ASTC_8x8_KHR:           "COMPRESSED_RGBA_ASTC_8x8_KHR",
+	COMPRESSED_RGBA_ASTC_10x5_KHR:          "COMPRESSED_RGBA_ASTC_10x5_KHR",
+	COMPRESSED_RGBA_ASTC_10x6_KHR:          "COMPRESSED_RGBA_ASTC_10x6_KHR",
+	COMPRESSED_RGBA_ASTC_10x8_KHR:          "COMPRESSED_RGBA_ASTC_10x8_KHR",
+	COMPRESSED_RGBA_ASTC_10x10_KHR:         "COMPRESSED_RGBA_ASTC_10x10_KHR",
+	COMPRESSED_RGBA_ASTC_12x10_KHR:         "COMPRESSED_RGBA_ASTC_12x10_KHR",
+	COMPRESSED_RGBA_ASTC_12x12_KHR:         "COMPRESSED_RGBA_ASTC_12x12_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x4_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_5x4_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x5_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_5x5_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x5_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_6x5_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x6_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_6x6_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x5_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_8x5_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x6_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_8x6_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x8_KHR:   "COMPRESSED_SRGB8_ALPHA8_ASTC_8x8_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x5_KHR:  "COMPRESSED_SRGB8_ALPHA8_ASTC_10x5_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x6_KHR:  "COMPRESSED_SRGB8_ALPHA8_ASTC_10x6_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x8_KHR:  "COMPRESSED_SRGB8_ALPHA8_ASTC_10x8_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x10_KHR: "COMPRESSED_SRGB8_ALPHA8_ASTC_10x10_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x10_KHR: "COMPRESSED_SRGB8_ALPHA8_ASTC_12x10_KHR",
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x12_KHR: "COMPRESSED_SRGB8_ALPHA8_ASTC_12x12_KHR",
+}
+
+// Returns the name of an enum value, like "ARRAY_BUFFER", or its hexadecimal value when unknown
+func EnumName(value types.GLEnum) string {
+	if name, ok := enumNames[value]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04X", uint32(value))
+}
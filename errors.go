@@ -0,0 +1,129 @@
+package webgl
+
+import (
+	"fmt"
+	"github.com/nuberu/webgl/extensions"
+	"github.com/nuberu/webgl/types"
+)
+
+// Reported by GetError when a WebGL 2.0 method is called on a WebGL 1.0 context.
+// The offending call is skipped instead of being forwarded to the browser.
+type VersionError struct {
+	Method   string
+	Version  uint
+	Required uint
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s requires WebGL %d.0, context is WebGL %d.0", e.Method, e.Required, e.Version)
+}
+
+// Reported by GetError when a method needs an extension the WebGL 1.0 context does not expose.
+// The offending call is skipped instead of being forwarded to the browser.
+type ExtensionError struct {
+	Method    string
+	Extension extensions.Name
+}
+
+func (e *ExtensionError) Error() string {
+	return fmt.Sprintf("%s requires WebGL 2.0 or the %s extension", e.Method, e.Extension)
+}
+
+// Reported by GetError when an object is deleted twice, or used after its deletion while object
+// checks are enabled, see EnableObjectChecks. The offending call is skipped.
+type DeletedObjectError struct {
+	Method string
+	Object types.Tracked
+}
+
+func (e *DeletedObjectError) Error() string {
+	return fmt.Sprintf("%s: %s was deleted", e.Method, ObjectName(e.Object))
+}
+
+// Reported by GetError when an object created before a context loss is deleted, or used while
+// object checks are enabled, see EnableObjectChecks. The offending call is skipped.
+type LostObjectError struct {
+	Method string
+	Object types.Tracked
+}
+
+func (e *LostObjectError) Error() string {
+	return fmt.Sprintf("%s: %s belongs to a lost context", e.Method, ObjectName(e.Object))
+}
+
+// Reported to the debug handler when a call raised a WebGL error, see EnableDebug
+type CallError struct {
+	Method string
+	Args   []interface{}
+	Code   types.GLEnum
+	Stack  []byte // Go stack of the call
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("%s(%s): %s", e.Method, FormatArgs(e.Args), EnumName(e.Code))
+}
+
+// Returns the GLError of the code, so errors.Is(err, ErrInvalidOperation) matches the call error
+func (e *CallError) Unwrap() error {
+	return GLError{Code: e.Code}
+}
+
+// Error flag reported by WebGL getError. Values compare equal for equal codes, so the sentinels
+// below can be matched with errors.Is.
+type GLError struct {
+	Code types.GLEnum
+}
+
+var (
+	ErrInvalidEnum                 = GLError{Code: INVALID_ENUM}
+	ErrInvalidValue                = GLError{Code: INVALID_VALUE}
+	ErrInvalidOperation            = GLError{Code: INVALID_OPERATION}
+	ErrInvalidFramebufferOperation = GLError{Code: INVALID_FRAMEBUFFER_OPERATION}
+	ErrOutOfMemory                 = GLError{Code: OUT_OF_MEMORY}
+	ErrContextLost                 = GLError{Code: CONTEXT_LOST_WEBGL}
+)
+
+func (e GLError) Error() string {
+	switch e.Code {
+	case INVALID_ENUM:
+		return "invalid enum"
+	case INVALID_VALUE:
+		return "invalid value"
+	case INVALID_OPERATION:
+		return "invalid operation"
+	case INVALID_FRAMEBUFFER_OPERATION:
+		return "invalid framebuffer operation"
+	case OUT_OF_MEMORY:
+		return "out of memory"
+	case CONTEXT_LOST_WEBGL:
+		return "context lost webgl"
+	}
+	return fmt.Sprintf("unknown error %s", EnumName(e.Code))
+}
+
+// Upper bound of the flags drained by GetErrors, WebGL keeps one per error code
+const maxErrorFlags = 16
+
+// Returns nil for NO_ERROR
+func glError(code types.GLEnum) error {
+	if code == NO_ERROR {
+		return nil
+	}
+	return GLError{Code: code}
+}
+
+// Reported by GetError when a call threw a JavaScript exception while safe calls are enabled,
+// see EnableSafeCalls. Method is FlushCommands for the calls buffered by a batched context, the
+// message then starts with the name of the call that threw.
+type ExceptionError struct {
+	Method string
+	Err    error
+}
+
+func (e *ExceptionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Method, e.Err)
+}
+
+func (e *ExceptionError) Unwrap() error {
+	return e.Err
+}
@@ -137,14 +137,14 @@ func main() {
 
 		// Drawing the Cube
 		movMatrix := mgl32.Ident4()
-		var renderFrame js.Callback
+		var renderFrame js.Func
 		var tmark float32
 		var rotation = float32(0)
 
 		// Bind to element array for draw function
 		gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, indexBuffer)
 
-		renderFrame = js.NewCallback(func(args []js.Value) {
+		renderFrame = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 			// Calculate rotation rate
 			now := float32(args[0].Float())
 			tdiff := now - tmark
@@ -172,6 +172,7 @@ func main() {
 
 			// Call next frame
 			js.Global().Call("requestAnimationFrame", renderFrame)
+			return nil
 		})
 		defer renderFrame.Release()
 
@@ -0,0 +1,23 @@
+package webgl
+
+import (
+	"errors"
+	"strings"
+	"syscall/js"
+)
+
+// Returns the JavaScript exception a call panicked with. Calling a method the context does not
+// have, like a WebGL 2.0 one on a WebGL 1.0 context, panics with a syscall/js message instead.
+func exception(recovered interface{}) (error, bool) {
+	switch err := recovered.(type) {
+	case js.Error:
+		return err, true
+	case *js.ValueError:
+		return err, true
+	case string:
+		if strings.HasPrefix(err, "syscall/js: ") {
+			return errors.New(err), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,8 @@
+//go:build !js
+
+package webgl
+
+// Only the browser backend throws exceptions, the panics of the other backends are bugs
+func exception(recovered interface{}) (error, bool) {
+	return nil, false
+}
@@ -0,0 +1,34 @@
+package extensions
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+)
+
+const DisjointTimerQueryWebGL2ExtensionName Name = "EXT_disjoint_timer_query_webgl2"
+
+const (
+	QUERY_COUNTER_BITS_EXT types.GLEnum = 0x8864
+	TIME_ELAPSED_EXT       types.GLEnum = 0x88BF
+	TIMESTAMP_EXT          types.GLEnum = 0x8E28
+	GPU_DISJOINT_EXT       types.GLEnum = 0x8FBB
+)
+
+// WebGL 2.0 timer queries. Queries are created and read with the context query methods,
+// TIME_ELAPSED_EXT is used as BeginQuery target.
+type DisjointTimerQuery struct {
+	Extension
+}
+
+func LoadDisjointTimerQueryExtension(glContext backend.Backend) *DisjointTimerQuery {
+	return &DisjointTimerQuery{
+		Extension: Extension{
+			value: glContext.Call("getExtension", string(DisjointTimerQueryWebGL2ExtensionName)),
+		},
+	}
+}
+
+// Records the GPU timestamp in the query, target must be TIMESTAMP_EXT
+func (dtq *DisjointTimerQuery) QueryCounterEXT(query *types.Query, target types.GLEnum) {
+	dtq.value.Call("queryCounterEXT", query, uint32(target))
+}
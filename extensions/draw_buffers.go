@@ -0,0 +1,61 @@
+package extensions
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+)
+
+const DrawBuffersExtensionName Name = "WEBGL_draw_buffers"
+
+const (
+	COLOR_ATTACHMENT0_WEBGL     types.GLEnum = 0x8CE0
+	COLOR_ATTACHMENT1_WEBGL     types.GLEnum = 0x8CE1
+	COLOR_ATTACHMENT2_WEBGL     types.GLEnum = 0x8CE2
+	COLOR_ATTACHMENT3_WEBGL     types.GLEnum = 0x8CE3
+	COLOR_ATTACHMENT4_WEBGL     types.GLEnum = 0x8CE4
+	COLOR_ATTACHMENT5_WEBGL     types.GLEnum = 0x8CE5
+	COLOR_ATTACHMENT6_WEBGL     types.GLEnum = 0x8CE6
+	COLOR_ATTACHMENT7_WEBGL     types.GLEnum = 0x8CE7
+	COLOR_ATTACHMENT8_WEBGL     types.GLEnum = 0x8CE8
+	COLOR_ATTACHMENT9_WEBGL     types.GLEnum = 0x8CE9
+	COLOR_ATTACHMENT10_WEBGL    types.GLEnum = 0x8CEA
+	COLOR_ATTACHMENT11_WEBGL    types.GLEnum = 0x8CEB
+	COLOR_ATTACHMENT12_WEBGL    types.GLEnum = 0x8CEC
+	COLOR_ATTACHMENT13_WEBGL    types.GLEnum = 0x8CED
+	COLOR_ATTACHMENT14_WEBGL    types.GLEnum = 0x8CEE
+	COLOR_ATTACHMENT15_WEBGL    types.GLEnum = 0x8CEF
+	DRAW_BUFFER0_WEBGL          types.GLEnum = 0x8825
+	DRAW_BUFFER1_WEBGL          types.GLEnum = 0x8826
+	DRAW_BUFFER2_WEBGL          types.GLEnum = 0x8827
+	DRAW_BUFFER3_WEBGL          types.GLEnum = 0x8828
+	DRAW_BUFFER4_WEBGL          types.GLEnum = 0x8829
+	DRAW_BUFFER5_WEBGL          types.GLEnum = 0x882A
+	DRAW_BUFFER6_WEBGL          types.GLEnum = 0x882B
+	DRAW_BUFFER7_WEBGL          types.GLEnum = 0x882C
+	DRAW_BUFFER8_WEBGL          types.GLEnum = 0x882D
+	DRAW_BUFFER9_WEBGL          types.GLEnum = 0x882E
+	DRAW_BUFFER10_WEBGL         types.GLEnum = 0x882F
+	DRAW_BUFFER11_WEBGL         types.GLEnum = 0x8830
+	DRAW_BUFFER12_WEBGL         types.GLEnum = 0x8831
+	DRAW_BUFFER13_WEBGL         types.GLEnum = 0x8832
+	DRAW_BUFFER14_WEBGL         types.GLEnum = 0x8833
+	DRAW_BUFFER15_WEBGL         types.GLEnum = 0x8834
+	MAX_COLOR_ATTACHMENTS_WEBGL types.GLEnum = 0x8CDF
+	MAX_DRAW_BUFFERS_WEBGL      types.GLEnum = 0x8824
+)
+
+type DrawBuffers struct {
+	Extension
+}
+
+func LoadDrawBuffersExtension(glContext backend.Backend) *DrawBuffers {
+	return &DrawBuffers{
+		Extension: Extension{
+			value: glContext.Call("getExtension", string(DrawBuffersExtensionName)),
+		},
+	}
+}
+
+func (db *DrawBuffers) DrawBuffersWEBGL(buffers []types.GLEnum) {
+	db.value.Call("drawBuffersWEBGL", buffers)
+}
@@ -1,23 +1,28 @@
 package extensions
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type Name string
 
 type IExtension interface {
-	GetJs() js.Value
+	GetValue() backend.Value
 }
 
 type Extension struct {
-	js js.Value
+	value backend.Value
 }
 
-func LoadGenericExtension(glContext js.Value, name string) *Extension {
+func LoadGenericExtension(glContext backend.Backend, name string) *Extension {
 	return &Extension{
-		js: glContext.Call("getExtension", name),
+		value: glContext.Call("getExtension", name),
 	}
 }
 
-func (ext *Extension) GetJs() js.Value {
-	return ext.js
+func (ext *Extension) GetValue() backend.Value {
+	return ext.value
+}
+
+// Reports whether the browser exposed the extension, getExtension returns null otherwise
+func (ext *Extension) IsAvailable() bool {
+	return !ext.value.IsNull() && !ext.value.IsUndefined()
 }
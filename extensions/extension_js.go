@@ -0,0 +1,10 @@
+package extensions
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"syscall/js"
+)
+
+func (ext *Extension) GetJs() js.Value {
+	return backend.ToJs(ext.value)
+}
@@ -1,7 +1,36 @@
 package extensions
 
-import "github.com/nuberu/webgl/types"
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+)
+
+const InstancedArraysExtensionName Name = "ANGLE_instanced_arrays"
 
 const (
 	VERTEX_ATTRIB_ARRAY_DIVISOR_ANGLE types.GLEnum = 0x88FE
 )
+
+type InstancedArrays struct {
+	Extension
+}
+
+func LoadInstancedArraysExtension(glContext backend.Backend) *InstancedArrays {
+	return &InstancedArrays{
+		Extension: Extension{
+			value: glContext.Call("getExtension", string(InstancedArraysExtensionName)),
+		},
+	}
+}
+
+func (ia *InstancedArrays) DrawArraysInstancedANGLE(mode types.GLEnum, first int, count int, primCount int) {
+	ia.value.Call("drawArraysInstancedANGLE", uint32(mode), first, count, primCount)
+}
+
+func (ia *InstancedArrays) DrawElementsInstancedANGLE(mode types.GLEnum, count int, valueType types.GLEnum, offset int64, primCount int) {
+	ia.value.Call("drawElementsInstancedANGLE", uint32(mode), count, uint32(valueType), offset, primCount)
+}
+
+func (ia *InstancedArrays) VertexAttribDivisorANGLE(index int, divisor int) {
+	ia.value.Call("vertexAttribDivisorANGLE", index, divisor)
+}
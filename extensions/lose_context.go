@@ -1,6 +1,6 @@
 package extensions
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 const LoseContextExtensionName Name = "WEBGL_lose_context"
 
@@ -8,14 +8,19 @@ type LoseContext struct {
 	Extension
 }
 
-func LoadLoseContextExtension(glContext js.Value) *LoseContext {
+func LoadLoseContextExtension(glContext backend.Backend) *LoseContext {
 	return &LoseContext{
 		Extension: Extension{
-			js: glContext.Call("getExtension", string(LoseContextExtensionName)),
+			value: glContext.Call("getExtension", string(LoseContextExtensionName)),
 		},
 	}
 }
 
 func (lc *LoseContext) LoseContext() {
-	lc.js.Call("loseContext")
+	lc.value.Call("loseContext")
+}
+
+// Restores a context lost with LoseContext, the webglcontextrestored event follows
+func (lc *LoseContext) RestoreContext() {
+	lc.value.Call("restoreContext")
 }
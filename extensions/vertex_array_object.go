@@ -0,0 +1,40 @@
+package extensions
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+)
+
+const VertexArrayObjectExtensionName Name = "OES_vertex_array_object"
+
+const (
+	VERTEX_ARRAY_BINDING_OES types.GLEnum = 0x85B5
+)
+
+type VertexArrayObject struct {
+	Extension
+}
+
+func LoadVertexArrayObjectExtension(glContext backend.Backend) *VertexArrayObject {
+	return &VertexArrayObject{
+		Extension: Extension{
+			value: glContext.Call("getExtension", string(VertexArrayObjectExtensionName)),
+		},
+	}
+}
+
+func (vao *VertexArrayObject) CreateVertexArrayOES() *types.VertexArray {
+	return types.NewVertexArray(vao.value.Call("createVertexArrayOES"))
+}
+
+func (vao *VertexArrayObject) DeleteVertexArrayOES(arrayObject *types.VertexArray) {
+	vao.value.Call("deleteVertexArrayOES", arrayObject)
+}
+
+func (vao *VertexArrayObject) IsVertexArrayOES(arrayObject *types.VertexArray) bool {
+	return vao.value.Call("isVertexArrayOES", arrayObject).Bool()
+}
+
+func (vao *VertexArrayObject) BindVertexArrayOES(arrayObject *types.VertexArray) {
+	vao.value.Call("bindVertexArrayOES", arrayObject)
+}
@@ -0,0 +1,83 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/types"
+)
+
+// Fence waited by WaitSyncAsync or FenceAsync
+type fenceWait struct {
+	sync  *types.Sync
+	flags uint32
+	// Deleted once signaled, for the fences inserted by FenceAsync
+	owned bool
+	done  chan struct{}
+}
+
+// WebGL 2.0
+// Returns a channel closed once the fence is signaled, or once waiting on it fails (for
+// example after a context loss). On the contexts of WrapContext the fence is checked from
+// requestAnimationFrame callbacks, so the main thread never blocks, other backends need calls
+// to PollFences. The caller keeps ownership of the fence.
+func (c *RenderingContext) WaitSyncAsync(sync *types.Sync) <-chan struct{} {
+	done := make(chan struct{})
+	if !c.requireWebGL2("WaitSyncAsync") {
+		close(done)
+		return done
+	}
+	c.fences = append(c.fences, fenceWait{sync: sync, flags: uint32(SYNC_FLUSH_COMMANDS_BIT), done: done})
+	c.scheduleFencePoll()
+	return done
+}
+
+// WebGL 2.0
+// Inserts a fence after the commands issued so far and returns a channel closed once the GPU
+// has executed them, checked like the fences of WaitSyncAsync. The fence is deleted afterwards.
+// Useful to read back a PIXEL_PACK_BUFFER filled by ReadPixelsOffsetPointer without stalling:
+//
+//	gl.ReadPixelsOffsetPointer(0, 0, w, h, webgl.RGBA, webgl.UNSIGNED_BYTE, 0)
+//	done := gl.FenceAsync()
+//	go func() {
+//		<-done
+//		gl.GetBufferSubData(webgl.PIXEL_PACK_BUFFER, 0, pixels)
+//	}()
+//
+// The channel is received from a goroutine, as the callbacks of the browser events, like the
+// one of requestAnimationFrame, must not block.
+func (c *RenderingContext) FenceAsync() <-chan struct{} {
+	done := make(chan struct{})
+	sync := c.FenceSync(SYNC_GPU_COMMANDS_COMPLETE, 0)
+	if sync == nil {
+		close(done)
+		return done
+	}
+	c.fences = append(c.fences, fenceWait{sync: sync, flags: uint32(SYNC_FLUSH_COMMANDS_BIT), owned: true, done: done})
+	c.scheduleFencePoll()
+	return done
+}
+
+// WebGL 2.0
+// Checks the fences of WaitSyncAsync and FenceAsync without waiting, and closes the channels of
+// the signaled ones. The contexts of WrapContext call it from the browser event loop, with other
+// backends, like the headless ones, it is called once per frame by the code using the context.
+func (c *RenderingContext) PollFences() {
+	pending := c.fences[:0]
+	for _, wait := range c.fences {
+		if c.ClientWaitSync(wait.sync, wait.flags, 0) == TIMEOUT_EXPIRED {
+			wait.flags = 0
+			pending = append(pending, wait)
+			continue
+		}
+		if wait.owned {
+			c.DeleteSync(wait.sync)
+		}
+		close(wait.done)
+	}
+	c.fences = pending
+}
+
+// Lets the browser event loop check the fences, when the context has one
+func (c *RenderingContext) scheduleFencePoll() {
+	if c.armFencePoll != nil {
+		c.armFencePoll()
+	}
+}
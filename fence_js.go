@@ -0,0 +1,39 @@
+package webgl
+
+import (
+	"syscall/js"
+)
+
+// Polls the fences of WaitSyncAsync and FenceAsync from requestAnimationFrame callbacks, or
+// setTimeout ones where animation frames are not available, like in workers. The callback
+// re-arms itself while fences are pending, and is released once none is left.
+func (c *RenderingContext) pollFencesFromEventLoop() {
+	global := js.Global()
+	schedule := func(callback js.Func) {
+		if global.Get("requestAnimationFrame").Type() == js.TypeFunction {
+			global.Call("requestAnimationFrame", callback)
+		} else {
+			global.Call("setTimeout", callback, 0)
+		}
+	}
+
+	armed := false
+	c.armFencePoll = func() {
+		if armed {
+			return
+		}
+		armed = true
+		var callback js.Func
+		callback = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			c.PollFences()
+			if len(c.fences) > 0 {
+				schedule(callback)
+				return nil
+			}
+			callback.Release()
+			armed = false
+			return nil
+		})
+		schedule(callback)
+	}
+}
@@ -0,0 +1,40 @@
+package webgl_test
+
+import (
+	"github.com/nuberu/webgl"
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+// WebGL 2.0 context whose fences expire twice before being signaled
+func newFenceContext() js.Value {
+	return js.Global().Get("Function").New(`
+		globalThis.WebGL2RenderingContext = globalThis.WebGL2RenderingContext || class {};
+		const context = Object.create(WebGL2RenderingContext.prototype);
+		Object.assign(context, {
+			polls: 0,
+			deleted: 0,
+			fenceSync() { return {}; },
+			clientWaitSync() { return ++this.polls < 3 ? 0x911B : 0x911A; },
+			deleteSync() { this.deleted++; },
+			getError() { return 0; },
+		});
+		return context;`).Invoke()
+}
+
+func TestFenceAsyncPolledFromEventLoop(t *testing.T) {
+	context := newFenceContext()
+	gl := webgl.WrapContext(context)
+	select {
+	case <-gl.FenceAsync():
+	case <-time.After(time.Second):
+		t.Fatalf("the fence was not polled, clientWaitSync called %d times", context.Get("polls").Int())
+	}
+	if polls := context.Get("polls").Int(); polls != 3 {
+		t.Fatalf("clientWaitSync called %d times, expected 3", polls)
+	}
+	if deleted := context.Get("deleted").Int(); deleted != 1 {
+		t.Fatalf("deleteSync called %d times, expected 1", deleted)
+	}
+}
@@ -0,0 +1,262 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Counters of the calls made during a frame, between BeginFrame and EndFrame
+type FrameStats struct {
+	// Index of the frame, from 1
+	Frame    uint64
+	Duration time.Duration
+
+	DrawCalls int
+	// Vertices processed by the draws, instanced draws count every instance
+	Vertices int64
+	// Primitives drawn per mode, like TRIANGLES or LINES
+	Primitives map[types.GLEnum]int64
+
+	ProgramBinds int
+	TextureBinds int
+	BufferBinds  int
+
+	// Calls uploading data and their bytes. HTML elements count as uploads without bytes, calls
+	// allocating storage without data, like BufferDataBySize, are not uploads.
+	BufferUploads      int
+	BufferUploadBytes  int64
+	TextureUploads     int
+	TextureUploadBytes int64
+	UniformUploads     int
+	UniformUploadBytes int64
+}
+
+// Returns the primitives of every mode
+func (stats FrameStats) TotalPrimitives() int64 {
+	var total int64
+	for _, count := range stats.Primitives {
+		total += count
+	}
+	return total
+}
+
+// Frame counters of the context
+type frameState struct {
+	current FrameStats
+	last    FrameStats
+	started time.Time
+	frames  uint64
+}
+
+// Backend counting the calls, installed under the other modes by the first BeginFrame
+type statsBackend struct {
+	backend.Backend
+	frame *frameState
+}
+
+// Starts counting the calls of a frame. The counters are installed on the first call, under the
+// modes enabled before or after, so they count the calls issued to the backend: calls skipped by
+// the state cache or object checks are not counted.
+func (c *RenderingContext) BeginFrame() {
+	if c.statsBackend() == nil {
+		c.addInnerLayer(&statsBackend{frame: &c.frame})
+	}
+	c.frame.frames++
+	c.frame.current = FrameStats{Frame: c.frame.frames, Primitives: make(map[types.GLEnum]int64)}
+	c.frame.started = time.Now()
+}
+
+// Ends the frame started by BeginFrame and returns its counters, kept until the next EndFrame
+func (c *RenderingContext) EndFrame() FrameStats {
+	if c.frame.started.IsZero() {
+		return c.frame.last
+	}
+	c.frame.current.Duration = time.Since(c.frame.started)
+	c.frame.started = time.Time{}
+	c.frame.last = c.frame.current
+	return c.frame.last
+}
+
+// Returns the counters of the last ended frame
+func (c *RenderingContext) LastFrameStats() FrameStats {
+	return c.frame.last
+}
+
+// Returns the counters of the frame in progress
+func (c *RenderingContext) CurrentFrameStats() FrameStats {
+	stats := c.frame.current
+	stats.Primitives = make(map[types.GLEnum]int64, len(c.frame.current.Primitives))
+	for mode, count := range c.frame.current.Primitives {
+		stats.Primitives[mode] = count
+	}
+	if !c.frame.started.IsZero() {
+		stats.Duration = time.Since(c.frame.started)
+	}
+	return stats
+}
+
+// Counts a call made on an extension object, like the instanced draws of WebGL 1.0 contexts
+func (c *RenderingContext) countCall(method string, args ...interface{}) {
+	if stats := c.statsBackend(); stats != nil {
+		stats.count(method, args)
+	}
+}
+
+// Returns the counters among the wrappers of the backend, nil before the first BeginFrame
+func (c *RenderingContext) statsBackend() *statsBackend {
+	stats, _ := c.findLayer((*statsBackend)(nil)).(*statsBackend)
+	return stats
+}
+
+func (b *statsBackend) Unwrap() backend.Backend {
+	return b.Backend
+}
+
+func (b *statsBackend) wrap(inner backend.Backend) {
+	b.Backend = inner
+}
+
+func (b *statsBackend) Call(method string, args ...interface{}) backend.Value {
+	b.count(method, args)
+	return b.Backend.Call(method, args...)
+}
+
+func (b *statsBackend) count(method string, args []interface{}) {
+	stats := &b.frame.current
+	if stats.Primitives == nil {
+		// Calls before the first BeginFrame are not counted
+		return
+	}
+	switch method {
+	case "drawArrays":
+		stats.countDraw(args, 0, 2, -1)
+	case "drawArraysInstanced":
+		stats.countDraw(args, 0, 2, 3)
+	case "drawElements":
+		stats.countDraw(args, 0, 1, -1)
+	case "drawElementsInstanced":
+		stats.countDraw(args, 0, 1, 4)
+	case "drawRangeElements":
+		stats.countDraw(args, 0, 3, -1)
+	case "useProgram":
+		stats.ProgramBinds++
+	case "bindTexture":
+		stats.TextureBinds++
+	case "bindBuffer", "bindBufferBase", "bindBufferRange":
+		stats.BufferBinds++
+	case "bufferData":
+		// The usage argument comes between the data and its offset
+		if bytes, ok := uploadBytes(args, 1); ok {
+			stats.BufferUploads++
+			stats.BufferUploadBytes += bytes
+		}
+	case "bufferSubData":
+		if bytes, ok := uploadBytes(args, 0); ok {
+			stats.BufferUploads++
+			stats.BufferUploadBytes += bytes
+		}
+	case "texImage2D", "texImage3D", "texSubImage2D", "texSubImage3D",
+		"compressedTexImage2D", "compressedTexImage3D", "compressedTexSubImage2D", "compressedTexSubImage3D":
+		if bytes, ok := uploadBytes(args, 0); ok {
+			stats.TextureUploads++
+			stats.TextureUploadBytes += bytes
+		}
+	case "uniformBlockBinding":
+	default:
+		if strings.HasPrefix(method, "uniform") {
+			stats.UniformUploads++
+			stats.UniformUploadBytes += uniformBytes(args)
+		}
+	}
+}
+
+// Counts a draw from the indices of its mode, count and instance count arguments
+func (stats *FrameStats) countDraw(args []interface{}, modeArg, countArg, instancesArg int) {
+	if len(args) <= countArg {
+		return
+	}
+	mode := types.GLEnum(toFloat(args[modeArg]))
+	count := int64(toFloat(args[countArg]))
+	instances := int64(1)
+	if instancesArg >= 0 && instancesArg < len(args) {
+		instances = int64(toFloat(args[instancesArg]))
+	}
+	stats.DrawCalls++
+	stats.Vertices += count * instances
+	stats.Primitives[mode] += primitives(mode, count) * instances
+}
+
+func primitives(mode types.GLEnum, count int64) int64 {
+	var primitives int64
+	switch mode {
+	case POINTS:
+		primitives = count
+	case LINES:
+		primitives = count / 2
+	case LINE_STRIP:
+		primitives = count - 1
+	case LINE_LOOP:
+		primitives = count
+		if count < 2 {
+			primitives = 0
+		}
+	case TRIANGLES:
+		primitives = count / 3
+	case TRIANGLE_STRIP, TRIANGLE_FAN:
+		primitives = count - 2
+	}
+	if primitives < 0 {
+		return 0
+	}
+	return primitives
+}
+
+// Returns the bytes of the data argument of an upload, counted from its srcOffset and length
+// arguments when given, after skip other arguments. False when no data is passed.
+func uploadBytes(args []interface{}, skip int) (int64, bool) {
+	for i, arg := range args {
+		value := reflect.ValueOf(arg)
+		switch value.Kind() {
+		case reflect.Slice:
+			var srcOffset, length uint
+			if i+skip+1 < len(args) {
+				srcOffset = uint(toFloat(args[i+skip+1]))
+			}
+			if i+skip+2 < len(args) {
+				length = uint(toFloat(args[i+skip+2]))
+			}
+			return int64(dataLength(value.Len(), srcOffset, length)) * marshalledSize(value), true
+		case reflect.Struct, reflect.Interface, reflect.Ptr:
+			// Elements like images or canvases
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// Returns the bytes of the values of a uniform call, numbers count 4 bytes like in GLSL
+func uniformBytes(args []interface{}) int64 {
+	var bytes int64
+	for _, arg := range args {
+		value := reflect.ValueOf(arg)
+		switch value.Kind() {
+		case reflect.Slice:
+			bytes += int64(value.Len()) * marshalledSize(value)
+		case reflect.Int, reflect.Int32, reflect.Uint, reflect.Uint32, reflect.Float32, reflect.Float64:
+			bytes += 4
+		}
+	}
+	return bytes
+}
+
+// Size of the slice elements once marshalled, int and uint being converted to 32 bits
+func marshalledSize(slice reflect.Value) int64 {
+	switch slice.Type().Elem().Kind() {
+	case reflect.Int, reflect.Uint:
+		return 4
+	}
+	return int64(slice.Type().Elem().Size())
+}
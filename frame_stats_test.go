@@ -0,0 +1,117 @@
+package webgl_test
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/mock"
+	"github.com/nuberu/webgl/types"
+	"testing"
+)
+
+func checkDraws(t *testing.T, stats webgl.FrameStats, drawCalls int, vertices int64, primitives map[types.GLEnum]int64) {
+	t.Helper()
+	if stats.DrawCalls != drawCalls || stats.Vertices != vertices {
+		t.Errorf("got %d draws of %d vertices, expected %d draws of %d vertices", stats.DrawCalls, stats.Vertices, drawCalls, vertices)
+	}
+	if len(stats.Primitives) != len(primitives) {
+		t.Errorf("got primitives %v, expected %v", stats.Primitives, primitives)
+	}
+	for mode, count := range primitives {
+		if stats.Primitives[mode] != count {
+			t.Errorf("got %d primitives of mode %s, expected %d", stats.Primitives[mode], webgl.EnumName(mode), count)
+		}
+	}
+}
+
+func TestFrameStatsDraws(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	gl.DrawArrays(webgl.TRIANGLES, 0, 300)
+	gl.BeginFrame()
+	gl.DrawArrays(webgl.TRIANGLES, 3, 6)
+	gl.DrawElements(webgl.LINES, 4, webgl.UNSIGNED_SHORT, 8)
+	gl.DrawRangeElements(webgl.TRIANGLE_STRIP, 2, 9, 5, webgl.UNSIGNED_SHORT, 16)
+	gl.DrawArraysInstanced(webgl.TRIANGLES, 1, 3, 4)
+	gl.DrawElementsInstanced(webgl.POINTS, 10, webgl.UNSIGNED_SHORT, 2, 3)
+	stats := gl.EndFrame()
+	checkDraws(t, stats, 5, 6+4+5+3*4+10*3, map[types.GLEnum]int64{
+		webgl.TRIANGLES:      2 + 4,
+		webgl.LINES:          2,
+		webgl.TRIANGLE_STRIP: 3,
+		webgl.POINTS:         30,
+	})
+	if stats.Frame != 1 {
+		t.Errorf("got frame %d, expected 1", stats.Frame)
+	}
+}
+
+// WebGL 1.0 contexts draw instances through ANGLE_instanced_arrays
+func TestFrameStatsInstancedDrawsANGLE(t *testing.T) {
+	recorder := mock.New()
+	extension := mock.New()
+	recorder.Results["getExtension"] = extension
+	gl := webgl.WrapBackend(recorder, webgl.WebGL1)
+	gl.BeginFrame()
+	gl.DrawArraysInstanced(webgl.TRIANGLES, 1, 3, 4)
+	gl.DrawElementsInstanced(webgl.TRIANGLES, 6, webgl.UNSIGNED_SHORT, 2, 2)
+	checkDraws(t, gl.EndFrame(), 2, 12+12, map[types.GLEnum]int64{webgl.TRIANGLES: 4 + 4})
+	if calls := extension.CallsTo("drawArraysInstancedANGLE", "drawElementsInstancedANGLE"); len(calls) != 2 {
+		t.Errorf("got extension calls %v, expected both instanced draws", calls)
+	}
+}
+
+func TestFrameStatsBufferUploads(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	gl.BeginFrame()
+	gl.BufferData(webgl.ARRAY_BUFFER, make([]float32, 16), webgl.STATIC_DRAW)
+	// From srcOffset to the end, then length elements from srcOffset
+	gl.BufferDataWithOffset(webgl.ARRAY_BUFFER, make([]float32, 10), webgl.STATIC_DRAW, 2, 0)
+	gl.BufferDataWithOffset(webgl.ARRAY_BUFFER, make([]float32, 10), webgl.STATIC_DRAW, 2, 3)
+	gl.BufferDataUI16(webgl.ELEMENT_ARRAY_BUFFER, make([]uint16, 6), webgl.STATIC_DRAW)
+	gl.BufferSubData(webgl.ARRAY_BUFFER, 4, make([]float32, 4))
+	gl.BufferDataBySize(webgl.ARRAY_BUFFER, 1024, webgl.DYNAMIC_DRAW)
+	stats := gl.EndFrame()
+	if expected := int64(16*4 + 8*4 + 3*4 + 6*2 + 4*4); stats.BufferUploads != 5 || stats.BufferUploadBytes != expected {
+		t.Errorf("got %d buffer uploads of %d bytes, expected 5 uploads of %d bytes", stats.BufferUploads, stats.BufferUploadBytes, expected)
+	}
+}
+
+func TestFrameStatsUniformUploads(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	program := gl.CreateProgram()
+	location := gl.GetUniformLocation(program, "transform")
+	gl.BeginFrame()
+	gl.UniformMatrix4fv(location, false, make([]float32, 16))
+	gl.UniformMatrix3fv(location, false, make([]float32, 9))
+	gl.Uniform4fv(location, make([]float32, 8))
+	gl.Uniform1i(location, 2)
+	gl.UniformBlockBinding(program, 0, 1)
+	stats := gl.EndFrame()
+	if expected := int64(16*4 + 9*4 + 8*4 + 4); stats.UniformUploads != 4 || stats.UniformUploadBytes != expected {
+		t.Errorf("got %d uniform uploads of %d bytes, expected 4 uploads of %d bytes", stats.UniformUploads, stats.UniformUploadBytes, expected)
+	}
+}
+
+// Only the binds reaching the backend are counted, whichever mode is enabled first
+func TestFrameStatsWithStateCache(t *testing.T) {
+	for _, cacheFirst := range []bool{true, false} {
+		gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+		if cacheFirst {
+			gl.EnableStateCache()
+			gl.BeginFrame()
+		} else {
+			gl.BeginFrame()
+			gl.EnableStateCache()
+		}
+		program, buffer, texture := gl.CreateProgram(), gl.CreateBuffer(), gl.CreateTexture()
+		for i := 0; i < 3; i++ {
+			gl.UseProgram(program)
+			gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+			gl.BindTexture(webgl.TEXTURE_2D, texture)
+		}
+		gl.BindBuffer(webgl.ARRAY_BUFFER, nil)
+		stats := gl.EndFrame()
+		if stats.ProgramBinds != 1 || stats.BufferBinds != 2 || stats.TextureBinds != 1 {
+			t.Errorf("cache enabled first %v: got %d program, %d buffer and %d texture binds, expected 1, 2 and 1",
+				cacheFirst, stats.ProgramBinds, stats.BufferBinds, stats.TextureBinds)
+		}
+	}
+}
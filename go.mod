@@ -1 +1,3 @@
 module github.com/nuberu/webgl
+
+go 1.27.1
@@ -0,0 +1,137 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/extensions"
+	"github.com/nuberu/webgl/types"
+	"time"
+)
+
+type gpuTimerSample struct {
+	label string
+	query *types.Query
+}
+
+// Measures the GPU time of labelled render passes with EXT_disjoint_timer_query_webgl2.
+// Results arrive some frames later, Poll must be called once per frame to collect them.
+type GpuTimer struct {
+	gl *RenderingContext
+
+	// Samples of the current frame, and of previous frames still waiting for their results
+	frame   []gpuTimerSample
+	pending [][]gpuTimerSample
+	free    []*types.Query
+	active  *gpuTimerSample
+
+	results map[string]time.Duration
+}
+
+// WebGL 2.0
+func (c *RenderingContext) NewGpuTimer() (*GpuTimer, error) {
+	if !c.IsWebGL2() {
+		return nil, &VersionError{Method: "NewGpuTimer", Version: c.version, Required: WebGL2}
+	}
+	if !c.GetExtensionDisjointTimerQuery().IsAvailable() {
+		return nil, &ExtensionError{Method: "NewGpuTimer", Extension: extensions.DisjointTimerQueryWebGL2ExtensionName}
+	}
+	return &GpuTimer{
+		gl:      c,
+		results: make(map[string]time.Duration),
+	}, nil
+}
+
+// Starts timing a pass. Timer queries can not be nested, an active pass is ended first.
+func (t *GpuTimer) Begin(label string) {
+	if t.active != nil {
+		t.End()
+	}
+
+	var query *types.Query
+	if n := len(t.free); n > 0 {
+		query = t.free[n-1]
+		t.free = t.free[:n-1]
+	} else {
+		query = t.gl.CreateQuery()
+	}
+
+	t.active = &gpuTimerSample{label: label, query: query}
+	t.gl.BeginQuery(extensions.TIME_ELAPSED_EXT, query)
+}
+
+func (t *GpuTimer) End() {
+	if t.active == nil {
+		return
+	}
+	t.gl.EndQuery(extensions.TIME_ELAPSED_EXT)
+	t.frame = append(t.frame, *t.active)
+	t.active = nil
+}
+
+// Closes the current frame and collects every finished frame. The returned map holds,
+// for each label, the total GPU time of the most recent finished frame measuring it.
+// Frames affected by a disjoint event are dropped.
+func (t *GpuTimer) Poll() map[string]time.Duration {
+	t.End()
+	if len(t.frame) > 0 {
+		t.pending = append(t.pending, t.frame)
+		t.frame = nil
+	}
+
+	// Availability is checked before the disjoint flag, so a disjoint event happening while the
+	// queries ran is seen along with their results
+	available := 0
+	for available < len(t.pending) {
+		samples := t.pending[available]
+		if !t.gl.GetQueryParameterResultAvailable(samples[len(samples)-1].query) {
+			break
+		}
+		available++
+	}
+	if available == 0 {
+		return t.Results()
+	}
+
+	disjoint := t.gl.GetParameterGpuDisjointExt()
+	for _, samples := range t.pending[:available] {
+		if !disjoint {
+			totals := make(map[string]time.Duration)
+			for _, sample := range samples {
+				totals[sample.label] += time.Duration(t.gl.GetQueryParameterResult(sample.query))
+			}
+			for label, total := range totals {
+				t.results[label] = total
+			}
+		}
+
+		for _, sample := range samples {
+			t.free = append(t.free, sample.query)
+		}
+	}
+	t.pending = t.pending[available:]
+
+	return t.Results()
+}
+
+// Returns a copy of the last collected results
+func (t *GpuTimer) Results() map[string]time.Duration {
+	results := make(map[string]time.Duration, len(t.results))
+	for label, duration := range t.results {
+		results[label] = duration
+	}
+	return results
+}
+
+// Releases every query owned by the timer, including the ones in flight
+func (t *GpuTimer) Delete() {
+	t.End()
+	for _, samples := range append(t.pending, t.frame) {
+		for _, sample := range samples {
+			t.gl.DeleteQuery(sample.query)
+		}
+	}
+	for _, query := range t.free {
+		t.gl.DeleteQuery(query)
+	}
+	t.frame = nil
+	t.pending = nil
+	t.free = nil
+}
@@ -0,0 +1,75 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"reflect"
+)
+
+// Backend wrapping the one of the context for a mode, like the debug checks or the state cache
+type layer interface {
+	backend.Backend
+	backend.Wrapper
+	// Replaces the wrapped backend
+	wrap(inner backend.Backend)
+}
+
+// Returns the layer of the same type as kind among the wrappers of the backend, nil when the mode
+// is not enabled. Kind is a nil pointer of the layer type, like (*stateCache)(nil).
+func (c *RenderingContext) findLayer(kind layer) layer {
+	for b := c.backend; ; {
+		l, ok := b.(layer)
+		if !ok {
+			return nil
+		}
+		if reflect.TypeOf(l) == reflect.TypeOf(kind) {
+			return l
+		}
+		b = l.Unwrap()
+	}
+}
+
+// Installs a layer on top of the backend
+func (c *RenderingContext) addLayer(l layer) {
+	l.wrap(c.backend)
+	c.backend = l
+}
+
+// Installs a layer under the other layers, right above the backend given to the context
+func (c *RenderingContext) addInnerLayer(l layer) {
+	var outer layer
+	b := c.backend
+	for {
+		current, ok := b.(layer)
+		if !ok {
+			break
+		}
+		outer, b = current, current.Unwrap()
+	}
+	l.wrap(b)
+	if outer == nil {
+		c.backend = l
+	} else {
+		outer.wrap(l)
+	}
+}
+
+// Removes the layer of the same type as kind from the wrappers of the backend, wherever it is.
+// Returns false when the mode is not enabled.
+func (c *RenderingContext) removeLayer(kind layer) bool {
+	var outer layer
+	for b := c.backend; ; {
+		l, ok := b.(layer)
+		if !ok {
+			return false
+		}
+		if reflect.TypeOf(l) == reflect.TypeOf(kind) {
+			if outer == nil {
+				c.backend = l.Unwrap()
+			} else {
+				outer.wrap(l.Unwrap())
+			}
+			return true
+		}
+		outer, b = l, l.Unwrap()
+	}
+}
@@ -0,0 +1,494 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/types"
+	"sort"
+)
+
+// Estimated GPU memory of the buffers, textures and renderbuffers created by the context
+type MemoryStats struct {
+	Buffers       int64
+	Textures      int64
+	Renderbuffers int64
+
+	// Objects holding memory, largest first
+	Objects []ObjectMemory
+}
+
+// Estimated memory of an object, in bytes
+type ObjectMemory struct {
+	Object types.Tracked
+	Bytes  int64
+}
+
+func (stats MemoryStats) Total() int64 {
+	return stats.Buffers + stats.Textures + stats.Renderbuffers
+}
+
+// Returns the estimated memory used by the objects of the context. Sizes are computed from the
+// calls specifying the data of the objects: buffer sizes, texture images with their mip levels
+// and compressed blocks, and renderbuffer storage with its samples. Drivers may pad or compress
+// them, so the estimate is a lower bound meant for budgets. Data specified through GetJs is missed.
+func (c *RenderingContext) MemoryStats() MemoryStats {
+	var stats MemoryStats
+	for _, usage := range c.memory.objects {
+		bytes := usage.bytes()
+		switch usage.handle.(type) {
+		case *types.Buffer:
+			stats.Buffers += bytes
+		case *types.Texture:
+			stats.Textures += bytes
+		case *types.RenderBuffer:
+			stats.Renderbuffers += bytes
+		}
+		if bytes > 0 {
+			stats.Objects = append(stats.Objects, ObjectMemory{Object: usage.handle, Bytes: bytes})
+		}
+	}
+	sort.Slice(stats.Objects, func(i, j int) bool {
+		if stats.Objects[i].Bytes != stats.Objects[j].Bytes {
+			return stats.Objects[i].Bytes > stats.Objects[j].Bytes
+		}
+		return stats.Objects[i].Object.GetObject().ID() < stats.Objects[j].Object.GetObject().ID()
+	})
+	return stats
+}
+
+// Returns the estimated memory of a buffer, texture or renderbuffer, see MemoryStats
+func (c *RenderingContext) MemoryUsage(handle types.Tracked) int64 {
+	if handle.GetValue() == nil {
+		return 0
+	}
+	if usage, ok := c.memory.objects[handle.GetObject().ID()]; ok {
+		return usage.bytes()
+	}
+	return 0
+}
+
+// Bindings locating the object specified by a call, and the memory of the objects
+type memoryState struct {
+	activeTexture  types.GLEnum // Offset from TEXTURE0
+	buffers        map[types.GLEnum]*types.Buffer
+	elementBuffers map[*types.VertexArray]*types.Buffer
+	vertexArray    *types.VertexArray
+	textures       map[textureSlot]*types.Texture
+	renderbuffer   *types.RenderBuffer
+
+	objects map[uint64]*objectMemory
+}
+
+type textureSlot struct {
+	unit   types.GLEnum
+	target types.GLEnum
+}
+
+// Memory of an object, buffers and renderbuffers have a single image
+type objectMemory struct {
+	handle types.Tracked
+	images map[textureImage]imageMemory
+}
+
+// Image of a texture level, target is the face of cube maps
+type textureImage struct {
+	target types.GLEnum
+	level  int
+}
+
+type imageMemory struct {
+	width, height, depth int
+	// Bytes per pixel of uncompressed images, 0 when compressed
+	pixelSize int
+	bytes     int64
+}
+
+func (usage *objectMemory) bytes() int64 {
+	var bytes int64
+	for _, image := range usage.images {
+		bytes += image.bytes
+	}
+	return bytes
+}
+
+func (m *memoryState) reset() {
+	*m = memoryState{}
+}
+
+// Units are stored from 0, the initial unit being TEXTURE0
+func (m *memoryState) setActiveTexture(unit types.GLEnum) {
+	m.activeTexture = unit - TEXTURE0
+}
+
+func (m *memoryState) bindBuffer(target types.GLEnum, buffer *types.Buffer) {
+	if target == ELEMENT_ARRAY_BUFFER {
+		// The element array binding is part of the vertex array state
+		if m.elementBuffers == nil {
+			m.elementBuffers = make(map[*types.VertexArray]*types.Buffer)
+		}
+		m.elementBuffers[m.vertexArray] = buffer
+		return
+	}
+	if m.buffers == nil {
+		m.buffers = make(map[types.GLEnum]*types.Buffer)
+	}
+	m.buffers[target] = buffer
+}
+
+func (m *memoryState) bindTexture(target types.GLEnum, texture *types.Texture) {
+	if m.textures == nil {
+		m.textures = make(map[textureSlot]*types.Texture)
+	}
+	m.textures[textureSlot{unit: m.activeTexture, target: target}] = texture
+}
+
+func (m *memoryState) bindRenderbuffer(renderbuffer *types.RenderBuffer) {
+	m.renderbuffer = renderbuffer
+}
+
+func (m *memoryState) bindVertexArray(vertexArray *types.VertexArray) {
+	m.vertexArray = vertexArray
+}
+
+// Deleted objects free their memory and are unbound
+func (m *memoryState) forget(handle types.Tracked) {
+	delete(m.objects, handle.GetObject().ID())
+	switch obj := handle.(type) {
+	case *types.Buffer:
+		for target, buffer := range m.buffers {
+			if buffer == obj {
+				delete(m.buffers, target)
+			}
+		}
+		for vertexArray, buffer := range m.elementBuffers {
+			if buffer == obj {
+				delete(m.elementBuffers, vertexArray)
+			}
+		}
+	case *types.Texture:
+		for slot, texture := range m.textures {
+			if texture == obj {
+				delete(m.textures, slot)
+			}
+		}
+	case *types.RenderBuffer:
+		if m.renderbuffer == obj {
+			m.renderbuffer = nil
+		}
+	case *types.VertexArray:
+		delete(m.elementBuffers, obj)
+		if m.vertexArray == obj {
+			m.vertexArray = nil
+		}
+	}
+}
+
+// Returns the memory of an object, nil for null and untracked handles
+func (m *memoryState) object(handle types.Tracked) *objectMemory {
+	if handle.GetValue() == nil || handle.GetObject().ID() == 0 {
+		return nil
+	}
+	id := handle.GetObject().ID()
+	usage, ok := m.objects[id]
+	if !ok {
+		if m.objects == nil {
+			m.objects = make(map[uint64]*objectMemory)
+		}
+		usage = &objectMemory{handle: handle, images: make(map[textureImage]imageMemory)}
+		m.objects[id] = usage
+	}
+	return usage
+}
+
+func (m *memoryState) boundBuffer(target types.GLEnum) *objectMemory {
+	if target == ELEMENT_ARRAY_BUFFER {
+		return m.object(m.elementBuffers[m.vertexArray])
+	}
+	return m.object(m.buffers[target])
+}
+
+// Returns the texture bound for an image target, cube map faces give the cube map
+func (m *memoryState) boundTexture(target types.GLEnum) *objectMemory {
+	if target >= TEXTURE_CUBE_MAP_POSITIVE_X && target <= TEXTURE_CUBE_MAP_NEGATIVE_Z {
+		target = TEXTURE_CUBE_MAP
+	}
+	return m.object(m.textures[textureSlot{unit: m.activeTexture, target: target}])
+}
+
+// Records the size of the data store of the buffer bound to target
+func (m *memoryState) specifyBuffer(target types.GLEnum, bytes int64) {
+	if usage := m.boundBuffer(target); usage != nil {
+		usage.images = map[textureImage]imageMemory{{}: {bytes: bytes}}
+	}
+}
+
+// Records an uncompressed image of the texture bound to target
+func (m *memoryState) specifyImage(target types.GLEnum, level int, internalFormat, dataType types.GLEnum, width, height, depth int) {
+	usage := m.boundTexture(target)
+	if usage == nil {
+		return
+	}
+	size := pixelSize(internalFormat, dataType)
+	usage.images[textureImage{target: target, level: level}] = imageMemory{
+		width:     width,
+		height:    height,
+		depth:     depth,
+		pixelSize: size,
+		bytes:     int64(width) * int64(height) * int64(depth) * int64(size),
+	}
+}
+
+// Records a compressed image of the texture bound to target. Formats without a known block size
+// use the size of the data, -1 when not given.
+func (m *memoryState) specifyCompressedImage(target types.GLEnum, level int, format types.GLEnum, width, height, depth int, dataBytes int64) {
+	usage := m.boundTexture(target)
+	if usage == nil {
+		return
+	}
+	bytes, ok := compressedSize(format, width, height, depth)
+	if !ok {
+		if dataBytes < 0 {
+			return
+		}
+		bytes = dataBytes
+	}
+	usage.images[textureImage{target: target, level: level}] = imageMemory{
+		width:  width,
+		height: height,
+		depth:  depth,
+		bytes:  bytes,
+	}
+}
+
+// Records the immutable storage of every level of the texture bound to target
+func (m *memoryState) specifyStorage(target types.GLEnum, levels int, internalFormat types.GLEnum, width, height, depth int) {
+	usage := m.boundTexture(target)
+	if usage == nil {
+		return
+	}
+	usage.images = make(map[textureImage]imageMemory)
+	for _, face := range textureFaces(target) {
+		w, h, d := width, height, depth
+		for level := 0; level < levels; level++ {
+			image := textureImage{target: face, level: level}
+			if bytes, ok := compressedSize(internalFormat, w, h, d); ok {
+				usage.images[image] = imageMemory{width: w, height: h, depth: d, bytes: bytes}
+			} else {
+				size := pixelSize(internalFormat, NONE)
+				usage.images[image] = imageMemory{width: w, height: h, depth: d, pixelSize: size, bytes: int64(w) * int64(h) * int64(d) * int64(size)}
+			}
+			w, h, d = mipSize(target, w, h, d)
+		}
+	}
+}
+
+// Records the levels generated from the base image of the texture bound to target
+func (m *memoryState) generateMipmap(target types.GLEnum) {
+	usage := m.boundTexture(target)
+	if usage == nil {
+		return
+	}
+	for _, face := range textureFaces(target) {
+		base, ok := usage.images[textureImage{target: face}]
+		if !ok || base.pixelSize == 0 {
+			continue
+		}
+		w, h, d := base.width, base.height, base.depth
+		for level := 1; w > 1 || h > 1 || (target == TEXTURE_3D && d > 1); level++ {
+			w, h, d = mipSize(target, w, h, d)
+			usage.images[textureImage{target: face, level: level}] = imageMemory{
+				width:     w,
+				height:    h,
+				depth:     d,
+				pixelSize: base.pixelSize,
+				bytes:     int64(w) * int64(h) * int64(d) * int64(base.pixelSize),
+			}
+		}
+	}
+}
+
+// Records the storage of the bound renderbuffer, multisampled ones store every sample
+func (m *memoryState) specifyRenderbuffer(internalFormat types.GLEnum, samples int, width, height int) {
+	usage := m.object(m.renderbuffer)
+	if usage == nil {
+		return
+	}
+	if samples < 1 {
+		samples = 1
+	}
+	bytes := int64(width) * int64(height) * int64(samples) * int64(pixelSize(internalFormat, NONE))
+	usage.images = map[textureImage]imageMemory{{}: {width: width, height: height, depth: 1, bytes: bytes}}
+}
+
+// Returns the number of elements read from data, srcOffset and length count elements
+func dataLength(length int, srcOffset, override uint) int {
+	if override > 0 {
+		return int(override)
+	}
+	if int(srcOffset) > length {
+		return 0
+	}
+	return length - int(srcOffset)
+}
+
+func textureFaces(target types.GLEnum) []types.GLEnum {
+	if target == TEXTURE_CUBE_MAP {
+		return []types.GLEnum{
+			TEXTURE_CUBE_MAP_POSITIVE_X, TEXTURE_CUBE_MAP_NEGATIVE_X,
+			TEXTURE_CUBE_MAP_POSITIVE_Y, TEXTURE_CUBE_MAP_NEGATIVE_Y,
+			TEXTURE_CUBE_MAP_POSITIVE_Z, TEXTURE_CUBE_MAP_NEGATIVE_Z,
+		}
+	}
+	return []types.GLEnum{target}
+}
+
+// Size of the next mip level, the layers of array textures are not reduced
+func mipSize(target types.GLEnum, width, height, depth int) (int, int, int) {
+	half := func(size int) int {
+		if size > 1 {
+			return size / 2
+		}
+		return 1
+	}
+	if target == TEXTURE_3D {
+		return half(width), half(height), half(depth)
+	}
+	return half(width), half(height), depth
+}
+
+// Bytes per pixel of the sized internal formats
+var formatSizes = map[types.GLEnum]int{
+	R8: 1, R8_SNORM: 1, R8UI: 1, R8I: 1, R16F: 2, R16UI: 2, R16I: 2, R32F: 4, R32UI: 4, R32I: 4,
+	RG8: 2, RG8_SNORM: 2, RG8UI: 2, RG8I: 2, RG16F: 4, RG16UI: 4, RG16I: 4, RG32F: 8, RG32UI: 8, RG32I: 8,
+	RGB8: 3, SRGB8: 3, RGB8_SNORM: 3, RGB8UI: 3, RGB8I: 3, RGB565: 2, R11F_G11F_B10F: 4, RGB9_E5: 4,
+	RGB16F: 6, RGB16UI: 6, RGB16I: 6, RGB32F: 12, RGB32UI: 12, RGB32I: 12,
+	RGBA8: 4, SRGB8_ALPHA8: 4, RGBA8_SNORM: 4, RGBA8UI: 4, RGBA8I: 4, RGB5_A1: 2, RGBA4: 2, RGB10_A2: 4, RGB10_A2UI: 4,
+	RGBA16F: 8, RGBA16UI: 8, RGBA16I: 8, RGBA32F: 16, RGBA32UI: 16, RGBA32I: 16,
+	DEPTH_COMPONENT16: 2, DEPTH_COMPONENT24: 4, DEPTH_COMPONENT32F: 4, DEPTH24_STENCIL8: 4, DEPTH32F_STENCIL8: 8,
+	STENCIL_INDEX8: 1, DEPTH_STENCIL: 4,
+}
+
+// Returns the bytes per pixel of an internal format, unsized WebGL 1.0 formats take the size
+// of their channels in the data type
+func pixelSize(internalFormat, dataType types.GLEnum) int {
+	if size, ok := formatSizes[internalFormat]; ok {
+		return size
+	}
+	switch dataType {
+	case UNSIGNED_SHORT_5_6_5, UNSIGNED_SHORT_4_4_4_4, UNSIGNED_SHORT_5_5_5_1:
+		return 2
+	case UNSIGNED_INT_24_8:
+		return 4
+	}
+	channels := 4
+	switch internalFormat {
+	case ALPHA, LUMINANCE, DEPTH_COMPONENT:
+		channels = 1
+	case LUMINANCE_ALPHA:
+		channels = 2
+	case RGB:
+		channels = 3
+	}
+	switch dataType {
+	case SHORT, UNSIGNED_SHORT, HALF_FLOAT, HALF_FLOAT_OES:
+		return channels * 2
+	case INT, UNSIGNED_INT, FLOAT:
+		return channels * 4
+	}
+	return channels
+}
+
+// Block of a compressed format, images smaller than minBlocks blocks take the minimum size
+type compressedBlock struct {
+	width, height int
+	bytes         int64
+	minBlocks     int
+}
+
+var compressedBlocks = map[types.GLEnum]compressedBlock{
+	// ETC2 and EAC, WebGL 2.0 and WEBGL_compressed_texture_etc
+	COMPRESSED_R11_EAC:                        {4, 4, 8, 1},
+	COMPRESSED_SIGNED_R11_EAC:                 {4, 4, 8, 1},
+	COMPRESSED_RG11_EAC:                       {4, 4, 16, 1},
+	COMPRESSED_SIGNED_RG11_EAC:                {4, 4, 16, 1},
+	COMPRESSED_RGB8_ETC2:                      {4, 4, 8, 1},
+	COMPRESSED_SRGB8_ETC2:                     {4, 4, 8, 1},
+	COMPRESSED_RGB8_PUNCHTHROUGH_ALPHA1_ETC2:  {4, 4, 8, 1},
+	COMPRESSED_SRGB8_PUNCHTHROUGH_ALPHA1_ETC2: {4, 4, 8, 1},
+	COMPRESSED_RGBA8_ETC2_EAC:                 {4, 4, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ETC2_EAC:          {4, 4, 16, 1},
+	// WEBGL_compressed_texture_etc1
+	COMPRESSED_RGB_ETC1_WEBGL: {4, 4, 8, 1},
+	// WEBGL_compressed_texture_atc
+	COMPRESSED_RGB_ATC_WEBGL:                     {4, 4, 8, 1},
+	COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL:     {4, 4, 16, 1},
+	COMPRESSED_RGBA_ATC_INTERPOLATED_ALPHA_WEBGL: {4, 4, 16, 1},
+	// WEBGL_compressed_texture_s3tc and WEBGL_compressed_texture_s3tc_srgb
+	COMPRESSED_RGB_S3TC_DXT1_EXT:        {4, 4, 8, 1},
+	COMPRESSED_RGBA_S3TC_DXT1_EXT:       {4, 4, 8, 1},
+	COMPRESSED_RGBA_S3TC_DXT3_EXT:       {4, 4, 16, 1},
+	COMPRESSED_RGBA_S3TC_DXT5_EXT:       {4, 4, 16, 1},
+	COMPRESSED_SRGB_S3TC_DXT1_EXT:       {4, 4, 8, 1},
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT1_EXT: {4, 4, 8, 1},
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT3_EXT: {4, 4, 16, 1},
+	COMPRESSED_SRGB_ALPHA_S3TC_DXT5_EXT: {4, 4, 16, 1},
+	// EXT_texture_compression_rgtc
+	COMPRESSED_RED_RGTC1_EXT:              {4, 4, 8, 1},
+	COMPRESSED_SIGNED_RED_RGTC1_EXT:       {4, 4, 8, 1},
+	COMPRESSED_RED_GREEN_RGTC2_EXT:        {4, 4, 16, 1},
+	COMPRESSED_SIGNED_RED_GREEN_RGTC2_EXT: {4, 4, 16, 1},
+	// EXT_texture_compression_bptc
+	COMPRESSED_RGBA_BPTC_UNORM_EXT:         {4, 4, 16, 1},
+	COMPRESSED_SRGB_ALPHA_BPTC_UNORM_EXT:   {4, 4, 16, 1},
+	COMPRESSED_RGB_BPTC_SIGNED_FLOAT_EXT:   {4, 4, 16, 1},
+	COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT_EXT: {4, 4, 16, 1},
+	// WEBGL_compressed_texture_pvrtc, 4 and 2 bits per pixel
+	COMPRESSED_RGB_PVRTC_4BPPV1_IMG:  {4, 4, 8, 2},
+	COMPRESSED_RGB_PVRTC_2BPPV1_IMG:  {8, 4, 8, 2},
+	COMPRESSED_RGBA_PVRTC_4BPPV1_IMG: {4, 4, 8, 2},
+	COMPRESSED_RGBA_PVRTC_2BPPV1_IMG: {8, 4, 8, 2},
+	// WEBGL_compressed_texture_astc, 16 bytes per block of any size
+	COMPRESSED_RGBA_ASTC_4x4_KHR:           {4, 4, 16, 1},
+	COMPRESSED_RGBA_ASTC_5x4_KHR:           {5, 4, 16, 1},
+	COMPRESSED_RGBA_ASTC_5x5_KHR:           {5, 5, 16, 1},
+	COMPRESSED_RGBA_ASTC_6x5_KHR:           {6, 5, 16, 1},
+	COMPRESSED_RGBA_ASTC_6x6_KHR:           {6, 6, 16, 1},
+	COMPRESSED_RGBA_ASTC_8x5_KHR:           {8, 5, 16, 1},
+	COMPRESSED_RGBA_ASTC_8x6_KHR:           {8, 6, 16, 1},
+	COMPRESSED_RGBA_ASTC_8x8_KHR:           {8, 8, 16, 1},
+	COMPRESSED_RGBA_ASTC_10x5_KHR:          {10, 5, 16, 1},
+	COMPRESSED_RGBA_ASTC_10x6_KHR:          {10, 6, 16, 1},
+	COMPRESSED_RGBA_ASTC_10x8_KHR:          {10, 8, 16, 1},
+	COMPRESSED_RGBA_ASTC_10x10_KHR:         {10, 10, 16, 1},
+	COMPRESSED_RGBA_ASTC_12x10_KHR:         {12, 10, 16, 1},
+	COMPRESSED_RGBA_ASTC_12x12_KHR:         {12, 12, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR:   {4, 4, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x4_KHR:   {5, 4, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_5x5_KHR:   {5, 5, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x5_KHR:   {6, 5, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_6x6_KHR:   {6, 6, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x5_KHR:   {8, 5, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x6_KHR:   {8, 6, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_8x8_KHR:   {8, 8, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x5_KHR:  {10, 5, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x6_KHR:  {10, 6, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x8_KHR:  {10, 8, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_10x10_KHR: {10, 10, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x10_KHR: {12, 10, 16, 1},
+	COMPRESSED_SRGB8_ALPHA8_ASTC_12x12_KHR: {12, 12, 16, 1},
+}
+
+// Returns the size of a compressed image, false for other formats
+func compressedSize(format types.GLEnum, width, height, depth int) (int64, bool) {
+	block, ok := compressedBlocks[format]
+	if !ok {
+		return 0, false
+	}
+	blocksX := (width + block.width - 1) / block.width
+	blocksY := (height + block.height - 1) / block.height
+	if blocksX < block.minBlocks {
+		blocksX = block.minBlocks
+	}
+	if blocksY < block.minBlocks {
+		blocksY = block.minBlocks
+	}
+	return int64(blocksX) * int64(blocksY) * int64(depth) * block.bytes, true
+}
@@ -0,0 +1,186 @@
+package webgl_test
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/mock"
+	"github.com/nuberu/webgl/types"
+	"testing"
+)
+
+func newTextureContext() (*webgl.RenderingContext, *types.Texture) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	texture := gl.CreateTexture()
+	gl.BindTexture(webgl.TEXTURE_2D, texture)
+	return gl, texture
+}
+
+func TestMemoryTextureFormats(t *testing.T) {
+	for _, test := range []struct {
+		internalFormat, format, dataType types.GLEnum
+		width, height                    int
+		bytes                            int64
+	}{
+		{webgl.RGBA, webgl.RGBA, webgl.UNSIGNED_BYTE, 4, 4, 64},
+		{webgl.RGB, webgl.RGB, webgl.UNSIGNED_SHORT_5_6_5, 4, 4, 32},
+		{webgl.LUMINANCE, webgl.LUMINANCE, webgl.UNSIGNED_BYTE, 8, 2, 16},
+		{webgl.RGBA, webgl.RGBA, webgl.FLOAT, 2, 2, 64},
+		{webgl.RGBA16F, webgl.RGBA, webgl.HALF_FLOAT, 4, 4, 128},
+		{webgl.RGB32F, webgl.RGB, webgl.FLOAT, 2, 2, 48},
+		{webgl.R11F_G11F_B10F, webgl.RGB, webgl.FLOAT, 2, 2, 16},
+		{webgl.DEPTH24_STENCIL8, webgl.DEPTH_STENCIL, webgl.UNSIGNED_INT_24_8, 2, 2, 16},
+	} {
+		gl, texture := newTextureContext()
+		gl.TexImage2DOffset(webgl.TEXTURE_2D, 0, test.internalFormat, test.width, test.height, 0, test.format, test.dataType, 0)
+		if bytes := gl.MemoryUsage(texture); bytes != test.bytes {
+			t.Errorf("%s %dx%d: got %d bytes, expected %d", webgl.EnumName(test.internalFormat), test.width, test.height, bytes, test.bytes)
+		}
+	}
+}
+
+// Images smaller than a block take a whole block, or the minimum of the format
+func TestMemoryCompressedFormats(t *testing.T) {
+	for _, test := range []struct {
+		format        types.GLEnum
+		width, height int
+		imageSize     int
+		bytes         int64
+	}{
+		{webgl.COMPRESSED_RGB_S3TC_DXT1_EXT, 8, 8, 0, 32},
+		{webgl.COMPRESSED_RGBA_S3TC_DXT5_EXT, 5, 5, 0, 64},
+		{webgl.COMPRESSED_RGBA_S3TC_DXT5_EXT, 1, 1, 0, 16},
+		{webgl.COMPRESSED_RGBA_ATC_EXPLICIT_ALPHA_WEBGL, 4, 4, 0, 16},
+		{webgl.COMPRESSED_RGBA_ASTC_8x8_KHR, 10, 10, 0, 64},
+		{webgl.COMPRESSED_RGB_PVRTC_4BPPV1_IMG, 4, 4, 0, 32},
+		{webgl.COMPRESSED_RGBA_PVRTC_2BPPV1_IMG, 8, 4, 0, 32},
+		// Unknown formats take the size of the data
+		{0x1234, 16, 16, 100, 100},
+	} {
+		gl, texture := newTextureContext()
+		gl.CompressedTexImage2DOffset(webgl.TEXTURE_2D, 0, test.format, test.width, test.height, 0, test.imageSize, 0)
+		if bytes := gl.MemoryUsage(texture); bytes != test.bytes {
+			t.Errorf("%s %dx%d: got %d bytes, expected %d", webgl.EnumName(test.format), test.width, test.height, bytes, test.bytes)
+		}
+	}
+}
+
+func TestMemoryTexStorageLevels(t *testing.T) {
+	for _, test := range []struct {
+		target               types.GLEnum
+		levels               int
+		width, height, depth int
+		bytes                int64
+		internalFormat       types.GLEnum
+		description          string
+	}{
+		{webgl.TEXTURE_2D, 4, 8, 8, 1, 256 + 64 + 16 + 4, webgl.RGBA8, "2D mip chain"},
+		{webgl.TEXTURE_2D, 2, 8, 1, 1, 32 + 16, webgl.RGBA8, "levels of a 1 pixel high image"},
+		{webgl.TEXTURE_CUBE_MAP, 3, 4, 4, 1, 6 * (64 + 16 + 4), webgl.RGBA8, "6 cube map faces"},
+		{webgl.TEXTURE_2D_ARRAY, 2, 4, 4, 3, 3 * (64 + 16), webgl.RGBA8, "array layers kept by the levels"},
+		{webgl.TEXTURE_3D, 3, 4, 4, 4, 256 + 32 + 4, webgl.RGBA8, "3D levels halving the depth"},
+		{webgl.TEXTURE_2D, 2, 8, 8, 1, 32 + 8, webgl.COMPRESSED_RGB8_ETC2, "compressed levels"},
+	} {
+		gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+		texture := gl.CreateTexture()
+		gl.BindTexture(test.target, texture)
+		if test.depth > 1 {
+			gl.TexStorage3D(test.target, test.levels, test.internalFormat, test.width, test.height, test.depth)
+		} else {
+			gl.TexStorage2D(test.target, test.levels, test.internalFormat, test.width, test.height)
+		}
+		if bytes := gl.MemoryUsage(texture); bytes != test.bytes {
+			t.Errorf("%s: got %d bytes, expected %d", test.description, bytes, test.bytes)
+		}
+	}
+}
+
+func TestMemoryGenerateMipmap(t *testing.T) {
+	gl, texture := newTextureContext()
+	gl.TexImage2Db(webgl.TEXTURE_2D, 0, webgl.RGBA, 4, 2, 0, webgl.RGBA, make([]byte, 32))
+	gl.GenerateMipmap(webgl.TEXTURE_2D)
+	if bytes := gl.MemoryUsage(texture); bytes != 32+8+4 {
+		t.Fatalf("got %d bytes, expected %d", bytes, 32+8+4)
+	}
+}
+
+// Faces are specified on their own targets and count for the cube map bound on the active unit
+func TestMemoryCubeMapFaces(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	texture := gl.CreateTexture()
+	other := gl.CreateTexture()
+	gl.ActiveTexture(uint32(webgl.TEXTURE1))
+	gl.BindTexture(webgl.TEXTURE_CUBE_MAP, texture)
+	gl.ActiveTexture(uint32(webgl.TEXTURE0))
+	gl.BindTexture(webgl.TEXTURE_CUBE_MAP, other)
+	gl.ActiveTexture(uint32(webgl.TEXTURE1))
+	gl.TexImage2Db(webgl.TEXTURE_CUBE_MAP_POSITIVE_X, 0, webgl.RGBA, 2, 2, 0, webgl.RGBA, make([]byte, 16))
+	gl.TexImage2Db(webgl.TEXTURE_CUBE_MAP_NEGATIVE_Z, 0, webgl.RGBA, 2, 2, 0, webgl.RGBA, make([]byte, 16))
+	if bytes := gl.MemoryUsage(texture); bytes != 32 {
+		t.Errorf("got %d bytes for the cube map, expected 32", bytes)
+	}
+	if bytes := gl.MemoryUsage(other); bytes != 0 {
+		t.Errorf("got %d bytes for the cube map of the other unit, expected 0", bytes)
+	}
+}
+
+// The element array buffer binding belongs to the bound vertex array
+func TestMemoryElementBuffersPerVertexArray(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	first, second := gl.CreateVertexArray(), gl.CreateVertexArray()
+	buffer := gl.CreateBuffer()
+	gl.BindVertexArray(first)
+	gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, buffer)
+	gl.BindVertexArray(second)
+	gl.BufferDataBySize(webgl.ELEMENT_ARRAY_BUFFER, 100, webgl.STATIC_DRAW)
+	if bytes := gl.MemoryUsage(buffer); bytes != 0 {
+		t.Errorf("got %d bytes for the buffer bound to another vertex array, expected 0", bytes)
+	}
+	gl.BindVertexArray(first)
+	gl.BufferDataBySize(webgl.ELEMENT_ARRAY_BUFFER, 64, webgl.STATIC_DRAW)
+	if bytes := gl.MemoryUsage(buffer); bytes != 64 {
+		t.Errorf("got %d bytes, expected 64", bytes)
+	}
+}
+
+func newMemoryContext() *webgl.RenderingContext {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	gl.BindBuffer(webgl.ARRAY_BUFFER, gl.CreateBuffer())
+	gl.BufferData(webgl.ARRAY_BUFFER, make([]float32, 16), webgl.STATIC_DRAW)
+	gl.BindTexture(webgl.TEXTURE_2D, gl.CreateTexture())
+	gl.TexStorage2D(webgl.TEXTURE_2D, 1, webgl.RGBA8, 4, 4)
+	gl.BindRenderBuffer(webgl.RENDERBUFFER, gl.CreateRenderBuffer())
+	gl.RenderbufferStorageMultisample(webgl.RENDERBUFFER, 4, webgl.DEPTH_COMPONENT16, 4, 4)
+	return gl
+}
+
+func TestMemoryStats(t *testing.T) {
+	stats := newMemoryContext().MemoryStats()
+	if stats.Buffers != 64 || stats.Textures != 64 || stats.Renderbuffers != 128 || stats.Total() != 256 {
+		t.Fatalf("got %+v, expected 64 bytes of buffers, 64 of textures and 128 of renderbuffers", stats)
+	}
+	if len(stats.Objects) != 3 || stats.Objects[0].Bytes != 128 {
+		t.Fatalf("got objects %+v, expected 3 with the renderbuffer first", stats.Objects)
+	}
+}
+
+func TestMemoryReleasedObjects(t *testing.T) {
+	gl := newMemoryContext()
+	for _, object := range gl.MemoryStats().Objects {
+		switch handle := object.Object.(type) {
+		case *types.Buffer:
+			gl.DeleteBuffer(handle)
+		case *types.Texture:
+			gl.DeleteTexture(handle)
+		case *types.RenderBuffer:
+			gl.DeleteRenderBuffer(handle)
+		}
+	}
+	if stats := gl.MemoryStats(); stats.Total() != 0 || len(stats.Objects) != 0 {
+		t.Errorf("got %+v after deleting the objects, expected nothing", stats)
+	}
+
+	gl = newMemoryContext()
+	gl.NotifyContextLost()
+	if stats := gl.MemoryStats(); stats.Total() != 0 || len(stats.Objects) != 0 {
+		t.Errorf("got %+v after a context loss, expected nothing", stats)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !webgldebug
+
+package webgl
+
+// Creation stacks are only captured in webgldebug builds
+func creationStack() []byte {
+	return nil
+}
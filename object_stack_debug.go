@@ -0,0 +1,10 @@
+//go:build webgldebug
+
+package webgl
+
+import "runtime/debug"
+
+// Captures the stack of the Create* call of an object, shown by DumpLeaks
+func creationStack() []byte {
+	return debug.Stack()
+}
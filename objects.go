@@ -0,0 +1,162 @@
+package webgl
+
+import (
+	"fmt"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Objects created by the context and not deleted yet
+type objectRegistry struct {
+	nextId uint64
+	live   map[uint64]types.Tracked
+}
+
+// Stamps a created object with an identifier and registers it as live until it is deleted
+func (c *RenderingContext) track(handle types.Tracked) {
+	obj := handle.GetObject()
+	c.adopt(obj)
+	c.objects.nextId++
+	obj.Track(c.objects.nextId, creationStack())
+	if c.objects.live == nil {
+		c.objects.live = make(map[uint64]types.Tracked)
+	}
+	c.objects.live[obj.ID()] = handle
+}
+
+// Marks an object deleted before its delete call, which is skipped for nil handles. Deleting an
+// object twice raises a DeletedObjectError, and deleting an object of a lost context a
+// LostObjectError, and skips the call too.
+func (c *RenderingContext) release(method string, handle types.Tracked) bool {
+	if handle.GetValue() == nil {
+		return false
+	}
+	obj := handle.GetObject()
+	if obj.IsLost() {
+		c.raise(&LostObjectError{Method: method, Object: handle})
+		return false
+	}
+	if obj.IsDeleted() {
+		c.raise(&DeletedObjectError{Method: method, Object: handle})
+		return false
+	}
+	obj.MarkDeleted()
+	delete(c.objects.live, obj.ID())
+	c.memory.forget(handle)
+	return true
+}
+
+// Objects of a lost context cannot be deleted anymore, they are not leaks
+func (c *RenderingContext) forgetObjects() {
+	c.objects.live = nil
+}
+
+// Returns the objects created by the context and not deleted yet, in creation order. Handles
+// returned by the getters, like GetParameterArrayBufferBinding, are not tracked: the listed
+// handle of an object is the one returned by its create call.
+func (c *RenderingContext) LiveObjects() []types.Tracked {
+	objects := make([]types.Tracked, 0, len(c.objects.live))
+	for _, handle := range c.objects.live {
+		objects = append(objects, handle)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].GetObject().ID() < objects[j].GetObject().ID()
+	})
+	return objects
+}
+
+// Returns the number of live objects per type, like "Buffer" or "Texture"
+func (c *RenderingContext) ObjectCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, handle := range c.objects.live {
+		counts[objectType(handle)]++
+	}
+	return counts
+}
+
+// Writes the live objects, with their creation stack in webgldebug builds, and returns their
+// number. Called once the application released its resources, it lists the leaked ones.
+func (c *RenderingContext) DumpLeaks(w io.Writer) int {
+	objects := c.LiveObjects()
+	for _, handle := range objects {
+		fmt.Fprintln(w, ObjectName(handle))
+		if stack := handle.GetObject().Stack(); stack != nil {
+			fmt.Fprintf(w, "%s\n", stack)
+		}
+	}
+	return len(objects)
+}
+
+// Names an object with its type, identifier and label, like `Texture#4 "albedo"`. Handles
+// returned by the getters are not tracked, they have the identifier 0 and no label.
+func ObjectName(handle types.Tracked) string {
+	if handle.GetValue() == nil {
+		return "null"
+	}
+	obj := handle.GetObject()
+	name := fmt.Sprintf("%s#%d", objectType(handle), obj.ID())
+	if label := obj.Label(); label != "" {
+		name += fmt.Sprintf(" %q", label)
+	}
+	return name
+}
+
+func objectType(handle types.Tracked) string {
+	return reflect.TypeOf(handle).Elem().Name()
+}
+
+// Backend rejecting the calls using deleted objects, installed by EnableObjectChecks
+type objectCheckBackend struct {
+	backend.Backend
+	context *RenderingContext
+}
+
+// Checks the objects passed to every call, and skips the calls using a deleted object with a
+// DeletedObjectError returned by GetError, or an object of a lost context with a LostObjectError,
+// instead of the silent INVALID_OPERATION of WebGL. Handles returned by the getters are not checked.
+func (c *RenderingContext) EnableObjectChecks() {
+	if !c.IsObjectChecksEnabled() {
+		c.addLayer(&objectCheckBackend{context: c})
+	}
+}
+
+func (c *RenderingContext) DisableObjectChecks() {
+	c.removeLayer((*objectCheckBackend)(nil))
+}
+
+func (c *RenderingContext) IsObjectChecksEnabled() bool {
+	return c.findLayer((*objectCheckBackend)(nil)) != nil
+}
+
+func (b *objectCheckBackend) Unwrap() backend.Backend {
+	return b.Backend
+}
+
+func (b *objectCheckBackend) wrap(inner backend.Backend) {
+	b.Backend = inner
+}
+
+func (b *objectCheckBackend) Call(method string, args ...interface{}) backend.Value {
+	// Deletes are checked by the context, the object is marked deleted before
+	if !strings.HasPrefix(method, "delete") {
+		for _, arg := range args {
+			handle, ok := arg.(types.Tracked)
+			if !ok || handle.GetValue() == nil {
+				continue
+			}
+			if handle.GetObject().IsLost() {
+				b.context.raise(&LostObjectError{Method: method, Object: handle})
+				return backend.Undefined()
+			}
+			if handle.GetObject().IsDeleted() {
+				b.context.raise(&DeletedObjectError{Method: method, Object: handle})
+				return backend.Undefined()
+			}
+		}
+	}
+	return b.Backend.Call(method, args...)
+}
@@ -0,0 +1,125 @@
+package webgl_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/mock"
+	"github.com/nuberu/webgl/types"
+	"testing"
+)
+
+func expectDeletedObjectError(t *testing.T, gl *webgl.RenderingContext, method string, object types.Tracked) {
+	t.Helper()
+	var deleted *webgl.DeletedObjectError
+	if err := gl.GetError(); !errors.As(err, &deleted) {
+		t.Fatalf("got error %v, expected a DeletedObjectError", err)
+	}
+	if deleted.Method != method || deleted.Object != object {
+		t.Fatalf("got %q, expected the error of %s on %s", deleted, method, webgl.ObjectName(object))
+	}
+}
+
+func TestDeleteTwice(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	gl.DeleteBuffer(buffer)
+	if !buffer.IsDeleted() {
+		t.Fatal("the buffer is not marked deleted")
+	}
+	gl.DeleteBuffer(buffer)
+	expectDeletedObjectError(t, gl, "DeleteBuffer", buffer)
+	if calls := recorder.CallsTo("deleteBuffer"); len(calls) != 1 {
+		t.Fatalf("got delete calls %v, expected one", calls)
+	}
+}
+
+func TestDeleteNil(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	gl.DeleteBuffer(nil)
+	gl.DeleteTexture(nil)
+	gl.DeleteProgram(nil)
+	gl.DeleteVertexArray(nil)
+	if err := recorder.ExpectCalls(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLiveObjects(t *testing.T) {
+	gl := webgl.WrapBackend(mock.New(), webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	texture := gl.CreateTexture()
+	texture.SetLabel("albedo")
+	deleted := gl.CreateBuffer()
+	program := gl.CreateProgram()
+	gl.DeleteBuffer(deleted)
+
+	live := gl.LiveObjects()
+	expected := []types.Tracked{buffer, texture, program}
+	if len(live) != len(expected) {
+		t.Fatalf("got live objects %v, expected %v", live, expected)
+	}
+	for i := range expected {
+		if live[i] != expected[i] {
+			t.Fatalf("got live object %s at %d, expected %s", webgl.ObjectName(live[i]), i, webgl.ObjectName(expected[i]))
+		}
+	}
+
+	counts := gl.ObjectCounts()
+	if len(counts) != 3 || counts["Buffer"] != 1 || counts["Texture"] != 1 || counts["Program"] != 1 {
+		t.Fatalf("got counts %v, expected a buffer, a texture and a program", counts)
+	}
+
+	var leaks bytes.Buffer
+	if count := gl.DumpLeaks(&leaks); count != 3 {
+		t.Fatalf("DumpLeaks returned %d, expected 3", count)
+	}
+	if report := leaks.String(); report != "Buffer#1\nTexture#2 \"albedo\"\nProgram#4\n" {
+		t.Fatalf("got leak report %q", report)
+	}
+
+	gl.NotifyContextLost()
+	if live := gl.LiveObjects(); len(live) != 0 {
+		t.Fatalf("got live objects %v after a context loss", live)
+	}
+}
+
+func TestObjectChecks(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	gl.EnableObjectChecks()
+	buffer := gl.CreateBuffer()
+	gl.DeleteBuffer(buffer)
+	recorder.Reset()
+
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	expectDeletedObjectError(t, gl, "bindBuffer", buffer)
+	if err := recorder.ExpectCalls(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Null handles and live objects pass
+	recorder.Reset()
+	live := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, live)
+	gl.BindBuffer(webgl.ARRAY_BUFFER, nil)
+	err := recorder.ExpectCalls(
+		mock.NewCall("createBuffer"),
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, live),
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gl.DisableObjectChecks()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	if calls := recorder.CallsTo("bindBuffer"); len(calls) != 3 {
+		t.Fatalf("got binds %v, expected the deleted buffer bound once the checks are disabled", calls)
+	}
+}
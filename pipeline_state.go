@@ -0,0 +1,257 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/types"
+)
+
+// Blending of the fragments with the framebuffer, BLEND when enabled
+type BlendState struct {
+	Enabled bool
+
+	SrcRGB   types.GLEnum
+	DstRGB   types.GLEnum
+	SrcAlpha types.GLEnum
+	DstAlpha types.GLEnum
+
+	EquationRGB   types.GLEnum
+	EquationAlpha types.GLEnum
+
+	Color [4]float32
+}
+
+// Stencil test and operations of one face
+type StencilFaceState struct {
+	Func      types.GLEnum
+	Ref       int
+	ValueMask uint32
+
+	Fail      types.GLEnum
+	DepthFail types.GLEnum
+	Pass      types.GLEnum
+
+	WriteMask uint32
+}
+
+// Depth and stencil tests, DEPTH_TEST and STENCIL_TEST when enabled
+type DepthStencilState struct {
+	DepthTest  bool
+	DepthFunc  types.GLEnum
+	DepthWrite bool
+
+	StencilTest bool
+	Front       StencilFaceState
+	Back        StencilFaceState
+}
+
+// Rasterization of the primitives: face culling, polygon offset, scissor test and color writes
+type RasterState struct {
+	CullFace  bool
+	CullMode  types.GLEnum
+	FrontFace types.GLEnum
+
+	PolygonOffset       bool
+	PolygonOffsetFactor float32
+	PolygonOffsetUnits  float32
+
+	ScissorTest bool
+	ColorMask   [4]bool
+}
+
+// Fixed function state of a draw, like a pipeline descriptor. Set it with ApplyState instead of
+// the separate calls, so no state leaks from a pass into the next one.
+type PipelineState struct {
+	Blend        BlendState
+	DepthStencil DepthStencilState
+	Raster       RasterState
+}
+
+// Blending disabled, replacing the destination with the source
+func DefaultBlendState() BlendState {
+	return BlendState{
+		SrcRGB:        ONE,
+		DstRGB:        ZERO,
+		SrcAlpha:      ONE,
+		DstAlpha:      ZERO,
+		EquationRGB:   FUNC_ADD,
+		EquationAlpha: FUNC_ADD,
+	}
+}
+
+// Source over blending of colors with non premultiplied alpha
+func AlphaBlendState() BlendState {
+	return BlendState{
+		Enabled:       true,
+		SrcRGB:        SRC_ALPHA,
+		DstRGB:        ONE_MINUS_SRC_ALPHA,
+		SrcAlpha:      ONE,
+		DstAlpha:      ONE_MINUS_SRC_ALPHA,
+		EquationRGB:   FUNC_ADD,
+		EquationAlpha: FUNC_ADD,
+	}
+}
+
+// Stencil face passing every fragment and keeping the stencil buffer
+func DefaultStencilFaceState() StencilFaceState {
+	return StencilFaceState{
+		Func:      ALWAYS,
+		ValueMask: 0xFFFFFFFF,
+		Fail:      KEEP,
+		DepthFail: KEEP,
+		Pass:      KEEP,
+		WriteMask: 0xFFFFFFFF,
+	}
+}
+
+// Depth and stencil tests disabled, with depth writes
+func DefaultDepthStencilState() DepthStencilState {
+	return DepthStencilState{
+		DepthFunc:  LESS,
+		DepthWrite: true,
+		Front:      DefaultStencilFaceState(),
+		Back:       DefaultStencilFaceState(),
+	}
+}
+
+// Culling disabled, counter clockwise front faces and every color channel written
+func DefaultRasterState() RasterState {
+	return RasterState{
+		CullMode:  BACK,
+		FrontFace: CCW,
+		ColorMask: [4]bool{true, true, true, true},
+	}
+}
+
+// State of a new context
+func DefaultPipelineState() PipelineState {
+	return PipelineState{
+		Blend:        DefaultBlendState(),
+		DepthStencil: DefaultDepthStencilState(),
+		Raster:       DefaultRasterState(),
+	}
+}
+
+// Sets the fixed function state, issuing only the calls changing the state applied before. The
+// first call sets everything, 18 calls. So does the first one after InvalidateStateCache or a
+// separate call setting this state, like Enable, BlendFunc, DepthMask or StencilOp, as the state
+// applied before is not known anymore.
+func (c *RenderingContext) ApplyState(state PipelineState) {
+	previous, all := c.appliedState, c.appliedState == nil
+	if all {
+		previous = &PipelineState{}
+	}
+	c.applyBlendState(&previous.Blend, &state.Blend, all)
+	c.applyDepthStencilState(&previous.DepthStencil, &state.DepthStencil, all)
+	c.applyRasterState(&previous.Raster, &state.Raster, all)
+	c.appliedState = &state
+}
+
+// Returns the state set by the last ApplyState, false when a separate call changed it since
+func (c *RenderingContext) AppliedState() (PipelineState, bool) {
+	if c.appliedState == nil {
+		return PipelineState{}, false
+	}
+	return *c.appliedState, true
+}
+
+func (c *RenderingContext) applyBlendState(previous, state *BlendState, all bool) {
+	c.applyCapability(BLEND, previous.Enabled, state.Enabled, all)
+	if all || previous.SrcRGB != state.SrcRGB || previous.DstRGB != state.DstRGB ||
+		previous.SrcAlpha != state.SrcAlpha || previous.DstAlpha != state.DstAlpha {
+		if state.SrcRGB == state.SrcAlpha && state.DstRGB == state.DstAlpha {
+			c.backend.Call("blendFunc", state.SrcRGB, state.DstRGB)
+		} else {
+			c.backend.Call("blendFuncSeparate", state.SrcRGB, state.DstRGB, state.SrcAlpha, state.DstAlpha)
+		}
+	}
+	if all || previous.EquationRGB != state.EquationRGB || previous.EquationAlpha != state.EquationAlpha {
+		if state.EquationRGB == state.EquationAlpha {
+			c.backend.Call("blendEquation", state.EquationRGB)
+		} else {
+			c.backend.Call("blendEquationSeparate", state.EquationRGB, state.EquationAlpha)
+		}
+	}
+	if all || previous.Color != state.Color {
+		c.backend.Call("blendColor", state.Color[0], state.Color[1], state.Color[2], state.Color[3])
+	}
+}
+
+func (c *RenderingContext) applyDepthStencilState(previous, state *DepthStencilState, all bool) {
+	c.applyCapability(DEPTH_TEST, previous.DepthTest, state.DepthTest, all)
+	if all || previous.DepthFunc != state.DepthFunc {
+		c.backend.Call("depthFunc", state.DepthFunc)
+	}
+	if all || previous.DepthWrite != state.DepthWrite {
+		c.backend.Call("depthMask", state.DepthWrite)
+	}
+
+	c.applyCapability(STENCIL_TEST, previous.StencilTest, state.StencilTest, all)
+	front, back := &state.Front, &state.Back
+	frontFunc := all || previous.Front.Func != front.Func || previous.Front.Ref != front.Ref || previous.Front.ValueMask != front.ValueMask
+	backFunc := all || previous.Back.Func != back.Func || previous.Back.Ref != back.Ref || previous.Back.ValueMask != back.ValueMask
+	if frontFunc && backFunc && front.Func == back.Func && front.Ref == back.Ref && front.ValueMask == back.ValueMask {
+		c.backend.Call("stencilFunc", front.Func, front.Ref, front.ValueMask)
+	} else {
+		if frontFunc {
+			c.backend.Call("stencilFuncSeparate", FRONT, front.Func, front.Ref, front.ValueMask)
+		}
+		if backFunc {
+			c.backend.Call("stencilFuncSeparate", BACK, back.Func, back.Ref, back.ValueMask)
+		}
+	}
+
+	frontOp := all || previous.Front.Fail != front.Fail || previous.Front.DepthFail != front.DepthFail || previous.Front.Pass != front.Pass
+	backOp := all || previous.Back.Fail != back.Fail || previous.Back.DepthFail != back.DepthFail || previous.Back.Pass != back.Pass
+	if frontOp && backOp && front.Fail == back.Fail && front.DepthFail == back.DepthFail && front.Pass == back.Pass {
+		c.backend.Call("stencilOp", front.Fail, front.DepthFail, front.Pass)
+	} else {
+		if frontOp {
+			c.backend.Call("stencilOpSeparate", FRONT, front.Fail, front.DepthFail, front.Pass)
+		}
+		if backOp {
+			c.backend.Call("stencilOpSeparate", BACK, back.Fail, back.DepthFail, back.Pass)
+		}
+	}
+
+	frontMask := all || previous.Front.WriteMask != front.WriteMask
+	backMask := all || previous.Back.WriteMask != back.WriteMask
+	if frontMask && backMask && front.WriteMask == back.WriteMask {
+		c.backend.Call("stencilMask", front.WriteMask)
+	} else {
+		if frontMask {
+			c.backend.Call("stencilMaskSeparate", FRONT, front.WriteMask)
+		}
+		if backMask {
+			c.backend.Call("stencilMaskSeparate", BACK, back.WriteMask)
+		}
+	}
+}
+
+func (c *RenderingContext) applyRasterState(previous, state *RasterState, all bool) {
+	c.applyCapability(CULL_FACE, previous.CullFace, state.CullFace, all)
+	if all || previous.CullMode != state.CullMode {
+		c.backend.Call("cullFace", state.CullMode)
+	}
+	if all || previous.FrontFace != state.FrontFace {
+		c.backend.Call("frontFace", state.FrontFace)
+	}
+	c.applyCapability(POLYGON_OFFSET_FILL, previous.PolygonOffset, state.PolygonOffset, all)
+	if all || previous.PolygonOffsetFactor != state.PolygonOffsetFactor || previous.PolygonOffsetUnits != state.PolygonOffsetUnits {
+		c.backend.Call("polygonOffset", state.PolygonOffsetFactor, state.PolygonOffsetUnits)
+	}
+	c.applyCapability(SCISSOR_TEST, previous.ScissorTest, state.ScissorTest, all)
+	if all || previous.ColorMask != state.ColorMask {
+		mask := state.ColorMask
+		c.backend.Call("colorMask", mask[0], mask[1], mask[2], mask[3])
+	}
+}
+
+func (c *RenderingContext) applyCapability(capability types.GLEnum, previous, enabled, all bool) {
+	if !all && previous == enabled {
+		return
+	}
+	if enabled {
+		c.backend.Call("enable", capability)
+	} else {
+		c.backend.Call("disable", capability)
+	}
+}
@@ -0,0 +1,93 @@
+package webgl_test
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend/mock"
+	"testing"
+)
+
+// Calls setting the whole default state
+var defaultStateCalls = []mock.Call{
+	mock.NewCall("disable", webgl.BLEND),
+	mock.NewCall("blendFunc", webgl.ONE, webgl.ZERO),
+	mock.NewCall("blendEquation", webgl.FUNC_ADD),
+	mock.NewCall("blendColor", 0, 0, 0, 0),
+	mock.NewCall("disable", webgl.DEPTH_TEST),
+	mock.NewCall("depthFunc", webgl.LESS),
+	mock.NewCall("depthMask", true),
+	mock.NewCall("disable", webgl.STENCIL_TEST),
+	mock.NewCall("stencilFunc", webgl.ALWAYS, 0, uint32(0xFFFFFFFF)),
+	mock.NewCall("stencilOp", webgl.KEEP, webgl.KEEP, webgl.KEEP),
+	mock.NewCall("stencilMask", uint32(0xFFFFFFFF)),
+	mock.NewCall("disable", webgl.CULL_FACE),
+	mock.NewCall("cullFace", webgl.BACK),
+	mock.NewCall("frontFace", webgl.CCW),
+	mock.NewCall("disable", webgl.POLYGON_OFFSET_FILL),
+	mock.NewCall("polygonOffset", 0, 0),
+	mock.NewCall("disable", webgl.SCISSOR_TEST),
+	mock.NewCall("colorMask", true, true, true, true),
+}
+
+func TestApplyStateFirstCallSetsEverything(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	gl.ApplyState(webgl.DefaultPipelineState())
+	if err := recorder.ExpectCalls(defaultStateCalls...); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gl.AppliedState(); !ok {
+		t.Fatal("AppliedState reported no applied state")
+	}
+}
+
+func TestApplyStateSkipsUnchangedState(t *testing.T) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+	state := webgl.DefaultPipelineState()
+	gl.ApplyState(state)
+	recorder.Reset()
+
+	gl.ApplyState(state)
+	if err := recorder.ExpectCalls(); err != nil {
+		t.Fatal(err)
+	}
+
+	state.DepthStencil.DepthWrite = false
+	gl.ApplyState(state)
+	state.Blend = webgl.AlphaBlendState()
+	gl.ApplyState(state)
+	state.DepthStencil.Back.WriteMask = 0
+	gl.ApplyState(state)
+	err := recorder.ExpectCalls(
+		mock.NewCall("depthMask", false),
+		mock.NewCall("enable", webgl.BLEND),
+		mock.NewCall("blendFuncSeparate", webgl.SRC_ALPHA, webgl.ONE_MINUS_SRC_ALPHA, webgl.ONE, webgl.ONE_MINUS_SRC_ALPHA),
+		mock.NewCall("stencilMaskSeparate", webgl.BACK, uint32(0)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Separate calls change the state behind ApplyState, the next one sets everything again
+func TestApplyStateAfterSeparateCalls(t *testing.T) {
+	for name, change := range map[string]func(gl *webgl.RenderingContext){
+		"Disable":              func(gl *webgl.RenderingContext) { gl.Disable(webgl.BLEND) },
+		"InvalidateStateCache": func(gl *webgl.RenderingContext) { gl.InvalidateStateCache() },
+	} {
+		recorder := mock.New()
+		gl := webgl.WrapBackend(recorder, webgl.WebGL2)
+		state := webgl.DefaultPipelineState()
+		gl.ApplyState(state)
+		change(gl)
+		if _, ok := gl.AppliedState(); ok {
+			t.Errorf("%s: AppliedState still reported the applied state", name)
+		}
+		recorder.Reset()
+
+		gl.ApplyState(state)
+		if err := recorder.ExpectCalls(defaultStateCalls...); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
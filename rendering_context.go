@@ -2,289 +2,613 @@ package webgl
 
 import (
 	"errors"
+	"github.com/nuberu/webgl/backend"
 	"github.com/nuberu/webgl/extensions"
 	"github.com/nuberu/webgl/types"
-	"syscall/js"
+)
+
+const (
+	WebGL1 uint = 1
+	WebGL2 uint = 2
 )
 
 // WebGL context wrapper
 type RenderingContext struct {
 	loaded  bool
-	js      js.Value
+	backend backend.Backend
 	version uint
 
+	// Error raised on the Go side, returned by the next GetError call
+	pendingError error
+	// Set once getError reported CONTEXT_LOST_WEBGL, until the context is restored
+	contextLost bool
+	// Objects created since the last restoration, invalidated by a context loss
+	generation *types.Generation
+	loss       contextLossState
+	// Objects created and not deleted yet
+	objects objectRegistry
+	// Bindings and estimated memory of the objects
+	memory memoryState
+	// Counters of the frame started by BeginFrame
+	frame frameState
+	// Fences waited by WaitSyncAsync, checked by PollFences
+	fences []fenceWait
+	// Schedules PollFences on the browser event loop, nil for the backends without one
+	armFencePoll func()
+
+	// Extensions backing WebGL 2.0 features on WebGL 1.0 contexts, loaded on first use
+	vertexArrayExt     *extensions.VertexArrayObject
+	instancedArraysExt *extensions.InstancedArrays
+	drawBuffersExt     *extensions.DrawBuffers
+
+	// State set by the last ApplyState, nil once a separate call changed it
+	appliedState *PipelineState
+
 	// Constant values
 }
 
-func WrapContext(jsContext js.Value) *RenderingContext {
-	context := &RenderingContext{
-		loaded: true,
-		js:     jsContext,
+// Creates a context dispatching its calls to a backend implementing the given WebGL version,
+// like backend.NewHeadless on native targets
+func WrapBackend(b backend.Backend, version uint) *RenderingContext {
+	return &RenderingContext{
+		loaded:     true,
+		backend:    b,
+		version:    version,
+		generation: types.NewGeneration(),
 	}
+}
+
+// Returns the backend the calls are dispatched to
+func (c *RenderingContext) GetBackend() backend.Backend {
+	return c.backend
+}
+
+// Returns the negotiated WebGL version, WebGL1 or WebGL2
+func (c *RenderingContext) GetVersion() uint {
+	return c.version
+}
 
-	return context
+func (c *RenderingContext) IsWebGL2() bool {
+	return c.version >= WebGL2
 }
 
-func FromCanvas(canvasEl js.Value) (*RenderingContext, error) {
-	jsContext := canvasEl.Call("getContext", "webgl")
-	if jsContext == js.Undefined() {
-		jsContext = canvasEl.Call("getContext", "experimental-webgl")
+// Checks that the context supports a WebGL 2.0 method, otherwise records a
+// VersionError for the next GetError call.
+func (c *RenderingContext) requireWebGL2(method string) bool {
+	if c.version >= WebGL2 {
+		return true
 	}
-	if jsContext == js.Undefined() {
-		return nil, errors.New("browser might not support webgl")
+	c.raise(&VersionError{
+		Method:   method,
+		Version:  c.version,
+		Required: WebGL2,
+	})
+	return false
+}
+
+// Keeps the first Go side error until it is read by GetError, like the GL error flag
+func (c *RenderingContext) raise(err error) {
+	if c.pendingError == nil {
+		c.pendingError = err
 	}
-	return WrapContext(jsContext), nil
 }
 
-func (c *RenderingContext) GetJs() js.Value {
-	return c.js
+// Returns the OES_vertex_array_object extension for WebGL 1.0 contexts, or nil with an
+// ExtensionError raised when it is not available.
+func (c *RenderingContext) vertexArrayObject(method string) *extensions.VertexArrayObject {
+	if c.vertexArrayExt == nil {
+		c.vertexArrayExt = extensions.LoadVertexArrayObjectExtension(c.backend)
+	}
+	if !c.vertexArrayExt.IsAvailable() {
+		c.raise(&ExtensionError{
+			Method:    method,
+			Extension: extensions.VertexArrayObjectExtensionName,
+		})
+		return nil
+	}
+	return c.vertexArrayExt
 }
 
-func (c *RenderingContext) GetDrawingBufferWidth() int {
-	return c.js.Get("drawingBufferWidth").Int()
+// Returns the ANGLE_instanced_arrays extension for WebGL 1.0 contexts, or nil with an
+// ExtensionError raised when it is not available.
+func (c *RenderingContext) instancedArrays(method string) *extensions.InstancedArrays {
+	if c.instancedArraysExt == nil {
+		c.instancedArraysExt = extensions.LoadInstancedArraysExtension(c.backend)
+	}
+	if !c.instancedArraysExt.IsAvailable() {
+		c.raise(&ExtensionError{
+			Method:    method,
+			Extension: extensions.InstancedArraysExtensionName,
+		})
+		return nil
+	}
+	return c.instancedArraysExt
 }
 
-func (c *RenderingContext) GetDrawingBufferHeight() int {
-	return c.js.Get("drawingBufferHeight").Int()
+// Returns the WEBGL_draw_buffers extension for WebGL 1.0 contexts, or nil with an
+// ExtensionError raised when it is not available.
+func (c *RenderingContext) drawBuffers(method string) *extensions.DrawBuffers {
+	if !c.hasDrawBuffers() {
+		c.raise(&ExtensionError{
+			Method:    method,
+			Extension: extensions.DrawBuffersExtensionName,
+		})
+		return nil
+	}
+	return c.drawBuffersExt
+}
+
+// Whether the WEBGL_draw_buffers extension is available, without raising an error
+func (c *RenderingContext) hasDrawBuffers() bool {
+	if c.drawBuffersExt == nil {
+		c.drawBuffersExt = extensions.LoadDrawBuffersExtension(c.backend)
+	}
+	return c.drawBuffersExt.IsAvailable()
 }
 
-func (c *RenderingContext) GetCanvas() js.Value {
-	return c.js.Get("canvas")
+func (c *RenderingContext) GetDrawingBufferWidth() int {
+	return c.backend.Get("drawingBufferWidth").Int()
+}
+
+func (c *RenderingContext) GetDrawingBufferHeight() int {
+	return c.backend.Get("drawingBufferHeight").Int()
 }
 
 // Specifies which texture unit to make active
 func (c *RenderingContext) ActiveTexture(textureUnit uint32) {
-	c.js.Call("activeTexture", textureUnit)
+	c.backend.Call("activeTexture", textureUnit)
+	c.memory.setActiveTexture(types.GLEnum(textureUnit))
 }
 
 func (c *RenderingContext) AttachShader(program *types.Program, shader *types.Shader) {
-	c.js.Call("attachShader", program.GetJs(), shader.GetJs())
+	c.backend.Call("attachShader", program, shader)
 }
 
-func (c *RenderingContext) BindAttribLocation(program *types.Program, index int, name string) {
-	if program == nil {
-		c.js.Call("bindAttribLocation", js.Null(), index, name)
-	} else {
-		c.js.Call("bindAttribLocation", program.GetJs(), index, name)
+// WebGL 2.0
+// Target is ANY_SAMPLES_PASSED, ANY_SAMPLES_PASSED_CONSERVATIVE, TRANSFORM_FEEDBACK_PRIMITIVES_WRITTEN
+// or TIME_ELAPSED_EXT when the disjoint timer query extension is enabled
+func (c *RenderingContext) BeginQuery(target types.GLEnum, query *types.Query) {
+	if !c.requireWebGL2("BeginQuery") {
+		return
 	}
+	c.backend.Call("beginQuery", target, query)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) BeginTransformFeedback(primitiveMode types.GLEnum) {
+	if !c.requireWebGL2("BeginTransformFeedback") {
+		return
+	}
+	c.backend.Call("beginTransformFeedback", primitiveMode)
+}
+
+func (c *RenderingContext) BindAttribLocation(program *types.Program, index int, name string) {
+	c.backend.Call("bindAttribLocation", program, index, name)
 }
 
 func (c *RenderingContext) BindBuffer(target types.GLEnum, buffer *types.Buffer) {
-	if buffer == nil {
-		c.js.Call("bindBuffer", uint32(target), js.Null())
-	} else {
-		c.js.Call("bindBuffer", uint32(target), buffer.GetJs())
+	c.backend.Call("bindBuffer", target, buffer)
+	c.memory.bindBuffer(target, buffer)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) BindBufferBase(target types.GLEnum, index uint, buffer *types.Buffer) {
+	if !c.requireWebGL2("BindBufferBase") {
+		return
 	}
+	c.backend.Call("bindBufferBase", target, index, buffer)
+	c.memory.bindBuffer(target, buffer)
 }
 
-func (c *RenderingContext) BindFrameBuffer(target types.GLEnum, buffer *types.FrameBuffer) {
-	if buffer == nil {
-		c.js.Call("bindFramebuffer", uint32(target), js.Null())
-	} else {
-		c.js.Call("bindFramebuffer", uint32(target), buffer.GetJs())
+// WebGL 2.0
+func (c *RenderingContext) BindBufferRange(target types.GLEnum, index uint, buffer *types.Buffer, offset int, size int) {
+	if !c.requireWebGL2("BindBufferRange") {
+		return
 	}
+	c.backend.Call("bindBufferRange", target, index, buffer, offset, size)
+	c.memory.bindBuffer(target, buffer)
+}
+
+func (c *RenderingContext) BindFrameBuffer(target types.GLEnum, buffer *types.FrameBuffer) {
+	c.backend.Call("bindFramebuffer", target, buffer)
 }
 
 func (c *RenderingContext) BindRenderBuffer(target types.GLEnum, buffer *types.RenderBuffer) {
-	if buffer == nil {
-		c.js.Call("bindFramebuffer", uint32(target), js.Null())
-	} else {
-		c.js.Call("bindRenderbuffer", uint32(target), buffer.GetJs())
+	c.backend.Call("bindRenderbuffer", target, buffer)
+	c.memory.bindRenderbuffer(buffer)
+}
+
+// WebGL 2.0
+// Overrides the sampling state of the texture bound to the unit, nil restores it
+func (c *RenderingContext) BindSampler(unit uint, sampler *types.Sampler) {
+	if !c.requireWebGL2("BindSampler") {
+		return
 	}
+	c.backend.Call("bindSampler", unit, sampler)
 }
 
 func (c *RenderingContext) BindTexture(target types.GLEnum, texture *types.Texture) {
-	if texture == nil {
-		c.js.Call("bindTexture", uint32(target), js.Null())
+	c.backend.Call("bindTexture", target, texture)
+	c.memory.bindTexture(target, texture)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) BindTransformFeedback(target types.GLEnum, transformFeedback *types.TransformFeedback) {
+	if !c.requireWebGL2("BindTransformFeedback") {
+		return
+	}
+	c.backend.Call("bindTransformFeedback", target, transformFeedback)
+}
+
+// Uses the native WebGL 2.0 call or the OES_vertex_array_object extension
+func (c *RenderingContext) BindVertexArray(vertexArray *types.VertexArray) {
+	if c.IsWebGL2() {
+		c.backend.Call("bindVertexArray", vertexArray)
+	} else if ext := c.vertexArrayObject("BindVertexArray"); ext != nil {
+		ext.BindVertexArrayOES(vertexArray)
+		c.InvalidateState(VERTEX_ARRAY_BINDING, ELEMENT_ARRAY_BUFFER_BINDING)
 	} else {
-		c.js.Call("bindTexture", uint32(target), texture.GetJs())
+		return
 	}
+	c.memory.bindVertexArray(vertexArray)
+}
+
+// WebGL 2.0
+// Mask is a combination of COLOR_BUFFER_BIT, DEPTH_BUFFER_BIT and STENCIL_BUFFER_BIT, filter is
+// NEAREST or LINEAR. Blitting from a multisampled framebuffer resolves it.
+func (c *RenderingContext) BlitFramebuffer(srcX0, srcY0, srcX1, srcY1 int, dstX0, dstY0, dstX1, dstY1 int, mask uint32, filter types.GLEnum) {
+	if !c.requireWebGL2("BlitFramebuffer") {
+		return
+	}
+	c.backend.Call("blitFramebuffer", srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
 }
 
 func (c *RenderingContext) BlendColor(r, g, b, a float32) {
-	c.js.Call("blendColor", r, g, b, a)
+	c.appliedState = nil
+	c.backend.Call("blendColor", r, g, b, a)
 }
 
 func (c *RenderingContext) BlendEquation(mode types.GLEnum) {
-	c.js.Call("blendEquation", uint32(mode))
+	c.appliedState = nil
+	c.backend.Call("blendEquation", mode)
 }
 
 func (c *RenderingContext) BlendEquationSeparate(modeRGB types.GLEnum, modeAlpha types.GLEnum) {
-	c.js.Call("blendEquationSeparate", uint32(modeRGB), uint32(modeAlpha))
+	c.appliedState = nil
+	c.backend.Call("blendEquationSeparate", modeRGB, modeAlpha)
 }
 
 func (c *RenderingContext) BlendFunc(sFactor types.GLEnum, dFactor types.GLEnum) {
-	c.js.Call("blendFunc", uint32(sFactor), uint32(dFactor))
+	c.appliedState = nil
+	c.backend.Call("blendFunc", sFactor, dFactor)
 }
 
 func (c *RenderingContext) BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha types.GLEnum) {
-	c.js.Call("blendFuncSeparate", uint32(srcRGB), uint32(dstRGB), uint32(srcAlpha), uint32(dstAlpha))
+	c.appliedState = nil
+	c.backend.Call("blendFuncSeparate", srcRGB, dstRGB, srcAlpha, dstAlpha)
 }
 
 func (c *RenderingContext) BufferDataBySize(target types.GLEnum, size int, usage types.GLEnum) {
-	c.js.Call("bufferData", uint32(target), size, uint32(usage))
+	c.backend.Call("bufferData", target, size, usage)
+	c.memory.specifyBuffer(target, int64(size))
 }
 
 func (c *RenderingContext) BufferData(target types.GLEnum, srcData []float32, usage types.GLEnum) {
-	c.js.Call("bufferData", uint32(target), js.TypedArrayOf(srcData), uint32(usage))
+	c.backend.Call("bufferData", target, srcData, usage)
+	c.memory.specifyBuffer(target, int64(len(srcData)*4))
 }
 
 func (c *RenderingContext) BufferDataI(target types.GLEnum, srcData []int, usage types.GLEnum) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage))
+	c.backend.Call("bufferData", target, srcData, usage)
+	c.memory.specifyBuffer(target, int64(len(srcData)*4))
 }
 
 func (c *RenderingContext) BufferDataUI(target types.GLEnum, srcData []uint32, usage types.GLEnum) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage))
+	c.backend.Call("bufferData", target, srcData, usage)
+	c.memory.specifyBuffer(target, int64(len(srcData)*4))
 }
 
 func (c *RenderingContext) BufferDataUI16(target types.GLEnum, srcData []uint16, usage types.GLEnum) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage))
+	c.backend.Call("bufferData", target, srcData, usage)
+	c.memory.specifyBuffer(target, int64(len(srcData)*2))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferDataWithOffset(target types.GLEnum, srcData []float32, usage types.GLEnum, srcOffset, length uint) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage), srcOffset, length)
+	if !c.requireWebGL2("BufferDataWithOffset") {
+		return
+	}
+	c.backend.Call("bufferData", target, srcData, usage, srcOffset, length)
+	c.memory.specifyBuffer(target, int64(dataLength(len(srcData), srcOffset, length)*4))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferDataIWithOffset(target types.GLEnum, srcData []int, usage types.GLEnum, srcOffset, length uint) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage), srcOffset, length)
+	if !c.requireWebGL2("BufferDataIWithOffset") {
+		return
+	}
+	c.backend.Call("bufferData", target, srcData, usage, srcOffset, length)
+	c.memory.specifyBuffer(target, int64(dataLength(len(srcData), srcOffset, length)*4))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferDataUIWithOffset(target types.GLEnum, srcData []uint, usage types.GLEnum, srcOffset, length uint) {
-	c.js.Call("bufferData",  uint32(target), js.TypedArrayOf(srcData), uint32(usage), srcOffset, length)
+	if !c.requireWebGL2("BufferDataUIWithOffset") {
+		return
+	}
+	c.backend.Call("bufferData", target, srcData, usage, srcOffset, length)
+	c.memory.specifyBuffer(target, int64(dataLength(len(srcData), srcOffset, length)*4))
+}
+
+func (c *RenderingContext) BufferDataB(target types.GLEnum, srcData []byte, usage types.GLEnum) {
+	c.backend.Call("bufferData", target, srcData, usage)
+	c.memory.specifyBuffer(target, int64(len(srcData)*1))
 }
 
 func (c *RenderingContext) BufferSubData(target types.GLEnum, offset int, srcData []float32) {
-	c.js.Call("bufferSubData",  uint32(target), offset, js.TypedArrayOf(srcData))
+	c.backend.Call("bufferSubData", target, offset, srcData)
+}
+
+func (c *RenderingContext) BufferSubDataB(target types.GLEnum, offset int, srcData []byte) {
+	c.backend.Call("bufferSubData", target, offset, srcData)
 }
 
 func (c *RenderingContext) BufferSubDataI(target types.GLEnum, offset int, srcData []int) {
-	c.js.Call("bufferSubData",  uint32(target), offset, js.TypedArrayOf(srcData))
+	c.backend.Call("bufferSubData", target, offset, srcData)
 }
 
 func (c *RenderingContext) BufferSubDataUI(target types.GLEnum, offset int, srcData []uint) {
-	c.js.Call("bufferSubData",  uint32(target), offset, js.TypedArrayOf(srcData))
+	c.backend.Call("bufferSubData", target, offset, srcData)
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferSubDataWithOffset(target types.GLEnum, dstByteOffset int, srcData []float32, srcOffset, length uint) {
-	c.js.Call("bufferSubData",  uint32(target), dstByteOffset, js.TypedArrayOf(srcData), srcOffset, length)
+	if !c.requireWebGL2("BufferSubDataWithOffset") {
+		return
+	}
+	c.backend.Call("bufferSubData", target, dstByteOffset, srcData, srcOffset, length)
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferSubDataIWithOffset(target types.GLEnum, dstByteOffset int, srcData []int, srcOffset, length uint) {
-	c.js.Call("bufferSubData",  uint32(target), dstByteOffset, js.TypedArrayOf(srcData), srcOffset, length)
+	if !c.requireWebGL2("BufferSubDataIWithOffset") {
+		return
+	}
+	c.backend.Call("bufferSubData", target, dstByteOffset, srcData, srcOffset, length)
 }
 
 // WebGL 2.0
 func (c *RenderingContext) BufferSubDataUIWithOffset(target types.GLEnum, dstByteOffset int, srcData []uint, srcOffset, length uint) {
-	c.js.Call("bufferSubData",  uint32(target), dstByteOffset, js.TypedArrayOf(srcData), srcOffset, length)
+	if !c.requireWebGL2("BufferSubDataUIWithOffset") {
+		return
+	}
+	c.backend.Call("bufferSubData", target, dstByteOffset, srcData, srcOffset, length)
 }
 
 func (c *RenderingContext) CheckFrameBufferStatus(target types.GLEnum) types.GLEnum {
-	return types.GLEnum(c.js.Call("checkFramebufferStatus",  uint32(target)).Int())
+	return types.GLEnum(c.backend.Call("checkFramebufferStatus", target).Int())
+}
+
+// WebGL 2.0
+// Returns CONDITION_SATISFIED, ALREADY_SIGNALED, TIMEOUT_EXPIRED or WAIT_FAILED.
+// Timeout is in nanoseconds and can not exceed MAX_CLIENT_WAIT_TIMEOUT_WEBGL.
+func (c *RenderingContext) ClientWaitSync(sync *types.Sync, flags uint32, timeout uint64) types.GLEnum {
+	if !c.requireWebGL2("ClientWaitSync") {
+		return WAIT_FAILED
+	}
+	return types.GLEnum(c.backend.Call("clientWaitSync", sync, flags, timeout).Int())
 }
 
 func (c *RenderingContext) Clear(mask uint32) {
-	c.js.Call("clear", mask)
+	c.backend.Call("clear", mask)
+}
+
+// WebGL 2.0
+// Clears a single draw buffer of an integer color attachment, buffer must be COLOR
+func (c *RenderingContext) ClearBufferiv(buffer types.GLEnum, drawBuffer int, values []int32) {
+	if !c.requireWebGL2("ClearBufferiv") {
+		return
+	}
+	c.backend.Call("clearBufferiv", buffer, drawBuffer, values)
+}
+
+// WebGL 2.0
+// Clears a single draw buffer of an unsigned integer color attachment, buffer must be COLOR
+func (c *RenderingContext) ClearBufferuiv(buffer types.GLEnum, drawBuffer int, values []uint32) {
+	if !c.requireWebGL2("ClearBufferuiv") {
+		return
+	}
+	c.backend.Call("clearBufferuiv", buffer, drawBuffer, values)
+}
+
+// WebGL 2.0
+// Clears a single draw buffer, buffer is COLOR or DEPTH
+func (c *RenderingContext) ClearBufferfv(buffer types.GLEnum, drawBuffer int, values []float32) {
+	if !c.requireWebGL2("ClearBufferfv") {
+		return
+	}
+	c.backend.Call("clearBufferfv", buffer, drawBuffer, values)
+}
+
+// WebGL 2.0
+// Clears depth and stencil at once, buffer must be DEPTH_STENCIL
+func (c *RenderingContext) ClearBufferfi(buffer types.GLEnum, drawBuffer int, depth float32, stencil int) {
+	if !c.requireWebGL2("ClearBufferfi") {
+		return
+	}
+	c.backend.Call("clearBufferfi", buffer, drawBuffer, depth, stencil)
 }
 
 func (c *RenderingContext) ClearColor(r, g, b, a float32) {
-	c.js.Call("clearColor", r, g, b, a)
+	c.backend.Call("clearColor", r, g, b, a)
 }
 
 func (c *RenderingContext) ClearDepth(depth float32) {
-	c.js.Call("clearDepth", depth)
+	c.backend.Call("clearDepth", depth)
 }
 
 func (c *RenderingContext) ClearStencil(s int) {
-	c.js.Call("clearStencil", s)
+	c.backend.Call("clearStencil", s)
 }
 
 func (c *RenderingContext) ColorMask(r, g, b, a float32) {
-	c.js.Call("colorMask", r, g, b, a)
+	c.appliedState = nil
+	c.backend.Call("colorMask", r, g, b, a)
 }
 
 func (c *RenderingContext) Commit() {
-	c.js.Call("commit")
+	c.backend.Call("commit")
 }
 
 func (c *RenderingContext) CompileShader(shader *types.Shader) {
-	c.js.Call("compileShader", shader.GetJs())
+	c.backend.Call("compileShader", shader)
 }
 
 func (c *RenderingContext) CompressedTexImage2D(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, border int) {
-	c.js.Call("compressedTexImage2D", uint32(target), level, uint32(internalFormat), width, height, border)
+	c.backend.Call("compressedTexImage2D", target, level, internalFormat, width, height, border)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, 1, -1)
 }
 
 func (c *RenderingContext) CompressedTexImage2DIn(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, border int, pixels []float32) {
-	c.js.Call("compressedTexImage2D", uint32(target), level, uint32(internalFormat), width, height, border, js.TypedArrayOf(pixels))
+	c.backend.Call("compressedTexImage2D", target, level, internalFormat, width, height, border, pixels)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, 1, int64(len(pixels)*4))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) CompressedTexImage2DOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, border int, imageSize int, offset int) {
-	c.js.Call("compressedTexImage2D", uint32(target), level, uint32(internalFormat), width, height, border, imageSize, offset)
+	if !c.requireWebGL2("CompressedTexImage2DOffset") {
+		return
+	}
+	c.backend.Call("compressedTexImage2D", target, level, internalFormat, width, height, border, imageSize, offset)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, 1, int64(imageSize))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) CompressedTexImage2DFromOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, border int, srcData []float32, srcOffset int, srcLengthOverride int) {
-	c.js.Call("compressedTexImage2D", uint32(target), level, uint32(internalFormat), width, height, border, js.TypedArrayOf(srcData), srcOffset, srcLengthOverride)
+	if !c.requireWebGL2("CompressedTexImage2DFromOffset") {
+		return
+	}
+	c.backend.Call("compressedTexImage2D", target, level, internalFormat, width, height, border, srcData, srcOffset, srcLengthOverride)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, 1, int64(dataLength(len(srcData), uint(srcOffset), uint(srcLengthOverride))*4))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) CompressedTexImage3DOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, depth int, border int, imageSize int, offset int) {
-	c.js.Call("compressedTexImage3D", uint32(target), level, uint32(internalFormat), width, height, depth, border, imageSize, offset)
+	if !c.requireWebGL2("CompressedTexImage3DOffset") {
+		return
+	}
+	c.backend.Call("compressedTexImage3D", target, level, internalFormat, width, height, depth, border, imageSize, offset)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, depth, int64(imageSize))
 }
 
 // WebGL 2.0
 func (c *RenderingContext) CompressedTexImage3DFromOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width int, height int, depth int, border int, srcData []float32, srcOffset int, srcLengthOverride int) {
-	c.js.Call("compressedTexImage3D", uint32(target), level, uint32(internalFormat), width, height, depth, border, js.TypedArrayOf(srcData), srcOffset, srcLengthOverride)
+	if !c.requireWebGL2("CompressedTexImage3DFromOffset") {
+		return
+	}
+	c.backend.Call("compressedTexImage3D", target, level, internalFormat, width, height, depth, border, srcData, srcOffset, srcLengthOverride)
+	c.memory.specifyCompressedImage(target, level, internalFormat, width, height, depth, int64(dataLength(len(srcData), uint(srcOffset), uint(srcLengthOverride))*4))
 }
 
 func (c *RenderingContext) CompressedTexSubImage2D(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum) {
-	c.js.Call("compressedTexSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format))
+	c.backend.Call("compressedTexSubImage2D", target, level, xOffset, yOffset, width, height, format)
 }
 
 func (c *RenderingContext) CompressedTexSubImage2DIn(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, pixels []float32) {
-	c.js.Call("compressedTexSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), js.TypedArrayOf(pixels))
+	c.backend.Call("compressedTexSubImage2D", target, level, xOffset, yOffset, width, height, format, pixels)
 }
 
 func (c *RenderingContext) CompressedTexSubImage2DFrom(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, imageSize int, offset int) {
-	c.js.Call("compressedTexSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), imageSize, offset)
+	c.backend.Call("compressedTexSubImage2D", target, level, xOffset, yOffset, width, height, format, imageSize, offset)
 }
 
 func (c *RenderingContext) CompressedTexSubImage2DFromOffset(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, srcData []float32, srcOffset int, srcLengthOverride int) {
-	c.js.Call("compressedTexSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), js.TypedArrayOf(srcData), srcOffset, srcLengthOverride)
+	c.backend.Call("compressedTexSubImage2D", target, level, xOffset, yOffset, width, height, format, srcData, srcOffset, srcLengthOverride)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) CompressedTexSubImage3D(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, srcData []byte) {
+	if !c.requireWebGL2("CompressedTexSubImage3D") {
+		return
+	}
+	c.backend.Call("compressedTexSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, srcData)
+}
+
+// WebGL 2.0
+// Reads the data from the buffer bound to PIXEL_UNPACK_BUFFER, starting at offset bytes
+func (c *RenderingContext) CompressedTexSubImage3DOffset(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, imageSize int, offset int) {
+	if !c.requireWebGL2("CompressedTexSubImage3DOffset") {
+		return
+	}
+	c.backend.Call("compressedTexSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, imageSize, offset)
 }
 
 func (c *RenderingContext) CopyTexImage2D(target types.GLEnum, level int, internalFormat types.GLEnum, x, y int, width, height int, border int) {
-	c.js.Call("copyTexImage2D", uint32(target), level, uint32(internalFormat), x, y, width, height, border)
+	c.backend.Call("copyTexImage2D", target, level, internalFormat, x, y, width, height, border)
+	c.memory.specifyImage(target, level, internalFormat, UNSIGNED_BYTE, width, height, 1)
 }
 
 func (c *RenderingContext) CopyTexSubImage2D(target types.GLEnum, level int, xOffset, yOffset int, x, y int, width, height int) {
-	c.js.Call("copyTexSubImage2D", uint32(target), level, xOffset, yOffset, x, y, width, height)
+	c.backend.Call("copyTexSubImage2D", target, level, xOffset, yOffset, x, y, width, height)
+}
+
+// WebGL 2.0
+// Copies a rectangle of the read framebuffer into a layer of a 3D or array texture
+func (c *RenderingContext) CopyTexSubImage3D(target types.GLEnum, level int, xOffset, yOffset, zOffset int, x, y int, width, height int) {
+	if !c.requireWebGL2("CopyTexSubImage3D") {
+		return
+	}
+	c.backend.Call("copyTexSubImage3D", target, level, xOffset, yOffset, zOffset, x, y, width, height)
 }
 
 func (c *RenderingContext) CreateBuffer() *types.Buffer {
-	return types.NewBuffer(c.js.Call("createBuffer"))
+	buffer := types.NewBuffer(c.backend.Call("createBuffer"))
+	c.track(buffer)
+	return buffer
 }
 
 func (c *RenderingContext) CreateFrameBuffer() *types.FrameBuffer {
-	return types.NewFrameBuffer(c.js.Call("createFramebuffer"))
+	frameBuffer := types.NewFrameBuffer(c.backend.Call("createFramebuffer"))
+	c.track(frameBuffer)
+	return frameBuffer
 }
 
 func (c *RenderingContext) CreateProgram() *types.Program {
-	return types.NewProgram(c.js.Call("createProgram"))
+	program := types.NewProgram(c.backend.Call("createProgram"))
+	c.track(program)
+	return program
+}
+
+// WebGL 2.0
+func (c *RenderingContext) CreateQuery() *types.Query {
+	if !c.requireWebGL2("CreateQuery") {
+		return nil
+	}
+	query := types.NewQuery(c.backend.Call("createQuery"))
+	c.track(query)
+	return query
 }
 
 func (c *RenderingContext) CreateRenderBuffer() *types.RenderBuffer {
-	return types.NewRenderBuffer(c.js.Call("createRenderbuffer"))
+	renderBuffer := types.NewRenderBuffer(c.backend.Call("createRenderbuffer"))
+	c.track(renderBuffer)
+	return renderBuffer
+}
+
+// WebGL 2.0
+func (c *RenderingContext) CreateSampler() *types.Sampler {
+	if !c.requireWebGL2("CreateSampler") {
+		return nil
+	}
+	sampler := types.NewSampler(c.backend.Call("createSampler"))
+	c.track(sampler)
+	return sampler
 }
 
 func (c *RenderingContext) CreateShader(shaderType types.GLEnum) *types.Shader {
-	return types.NewShader(c.js.Call("createShader", uint32(shaderType)))
+	shader := types.NewShader(c.backend.Call("createShader", shaderType))
+	c.track(shader)
+	return shader
 }
 
 func (c *RenderingContext) CreateFragmentShader() *types.Shader {
@@ -296,103 +620,280 @@ func (c *RenderingContext) CreateVertexShader() *types.Shader {
 }
 
 func (c *RenderingContext) CreateTexture() *types.Texture {
-	return types.NewTexture(c.js.Call("createTexture"))
+	texture := types.NewTexture(c.backend.Call("createTexture"))
+	c.track(texture)
+	return texture
+}
+
+// WebGL 2.0
+func (c *RenderingContext) CreateTransformFeedback() *types.TransformFeedback {
+	if !c.requireWebGL2("CreateTransformFeedback") {
+		return nil
+	}
+	transformFeedback := types.NewTransformFeedback(c.backend.Call("createTransformFeedback"))
+	c.track(transformFeedback)
+	return transformFeedback
+}
+
+// Uses the native WebGL 2.0 call or the OES_vertex_array_object extension
+func (c *RenderingContext) CreateVertexArray() *types.VertexArray {
+	var vertexArray *types.VertexArray
+	if c.IsWebGL2() {
+		vertexArray = types.NewVertexArray(c.backend.Call("createVertexArray"))
+	} else if ext := c.vertexArrayObject("CreateVertexArray"); ext != nil {
+		vertexArray = ext.CreateVertexArrayOES()
+	} else {
+		return nil
+	}
+	c.track(vertexArray)
+	return vertexArray
 }
 
 func (c *RenderingContext) CullFace(mode types.GLEnum) {
-	c.js.Call("cullFace", uint32(mode))
+	c.appliedState = nil
+	c.backend.Call("cullFace", mode)
 }
 
 func (c *RenderingContext) DeleteBuffer(buffer *types.Buffer) {
-	c.js.Call("deleteBuffer", buffer.GetJs())
+	if c.release("DeleteBuffer", buffer) {
+		c.backend.Call("deleteBuffer", buffer)
+	}
 }
 
 func (c *RenderingContext) DeleteFrameBuffer(framebuffer *types.FrameBuffer) {
-	c.js.Call("deleteFramebuffer", framebuffer.GetJs())
+	if c.release("DeleteFrameBuffer", framebuffer) {
+		c.backend.Call("deleteFramebuffer", framebuffer)
+	}
 }
 
 func (c *RenderingContext) DeleteProgram(program *types.Program) {
-	c.js.Call("deleteProgram", program.GetJs())
+	if c.release("DeleteProgram", program) {
+		c.backend.Call("deleteProgram", program)
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) DeleteQuery(query *types.Query) {
+	if !c.requireWebGL2("DeleteQuery") || !c.release("DeleteQuery", query) {
+		return
+	}
+	c.backend.Call("deleteQuery", query)
 }
 
 func (c *RenderingContext) DeleteRenderBuffer(renderbuffer *types.RenderBuffer) {
-	c.js.Call("deleteFramebuffer", renderbuffer.GetJs())
+	if c.release("DeleteRenderBuffer", renderbuffer) {
+		c.backend.Call("deleteRenderbuffer", renderbuffer)
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) DeleteSampler(sampler *types.Sampler) {
+	if !c.requireWebGL2("DeleteSampler") || !c.release("DeleteSampler", sampler) {
+		return
+	}
+	c.backend.Call("deleteSampler", sampler)
 }
 
 func (c *RenderingContext) DeleteShader(shader *types.Shader) {
-	c.js.Call("deleteShader", shader.GetJs())
+	if c.release("DeleteShader", shader) {
+		c.backend.Call("deleteShader", shader)
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) DeleteSync(sync *types.Sync) {
+	if !c.requireWebGL2("DeleteSync") || !c.release("DeleteSync", sync) {
+		return
+	}
+	c.backend.Call("deleteSync", sync)
 }
 
 func (c *RenderingContext) DeleteTexture(texture *types.Texture) {
-	c.js.Call("deleteTexture", texture.GetJs())
+	if c.release("DeleteTexture", texture) {
+		c.backend.Call("deleteTexture", texture)
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) DeleteTransformFeedback(transformFeedback *types.TransformFeedback) {
+	if !c.requireWebGL2("DeleteTransformFeedback") || !c.release("DeleteTransformFeedback", transformFeedback) {
+		return
+	}
+	c.backend.Call("deleteTransformFeedback", transformFeedback)
+}
+
+// Uses the native WebGL 2.0 call or the OES_vertex_array_object extension
+func (c *RenderingContext) DeleteVertexArray(vertexArray *types.VertexArray) {
+	if !c.release("DeleteVertexArray", vertexArray) {
+		return
+	}
+	if c.IsWebGL2() {
+		c.backend.Call("deleteVertexArray", vertexArray)
+	} else if ext := c.vertexArrayObject("DeleteVertexArray"); ext != nil {
+		ext.DeleteVertexArrayOES(vertexArray)
+		c.InvalidateState(VERTEX_ARRAY_BINDING, ELEMENT_ARRAY_BUFFER_BINDING)
+	}
 }
 
 func (c *RenderingContext) DepthFunc(depth types.GLEnum) {
-	c.js.Call("depthFunc", uint32(depth))
+	c.appliedState = nil
+	c.backend.Call("depthFunc", depth)
 }
 
 func (c *RenderingContext) DepthMask(flag bool) {
-	c.js.Call("depthMask", flag)
+	c.appliedState = nil
+	c.backend.Call("depthMask", flag)
 }
 
 func (c *RenderingContext) DepthRange(zNear, zFar float32) {
-	c.js.Call("depthRange", zNear, zFar)
+	c.backend.Call("depthRange", zNear, zFar)
 }
 
 func (c *RenderingContext) DetachShader(program *types.Program, shader *types.Shader) {
-	c.js.Call("detachShader", program.GetJs(), shader.GetJs())
+	c.backend.Call("detachShader", program, shader)
 }
 
 func (c *RenderingContext) Disable(cap types.GLEnum) {
-	c.js.Call("disable", uint32(cap))
+	c.appliedState = nil
+	c.backend.Call("disable", cap)
 }
 
 func (c *RenderingContext) DisableVertexAttribArray(index int) {
-	c.js.Call("disableVertexAttribArray", index)
+	c.backend.Call("disableVertexAttribArray", index)
 }
 
 func (c *RenderingContext) DrawArrays(mode types.GLEnum, first int, count int) {
-	c.js.Call("drawArrays", uint32(mode), first, count)
+	c.backend.Call("drawArrays", mode, first, count)
+}
+
+// Uses the native WebGL 2.0 call or the WEBGL_draw_buffers extension
+func (c *RenderingContext) DrawBuffers(buffers []types.GLEnum) {
+	if c.IsWebGL2() {
+		c.backend.Call("drawBuffers", buffers)
+	} else if ext := c.drawBuffers("DrawBuffers"); ext != nil {
+		ext.DrawBuffersWEBGL(buffers)
+	}
 }
 
 func (c *RenderingContext) DrawElements(mode types.GLEnum, count int, valueType types.GLEnum, offset int64) {
-	c.js.Call("drawElements", uint32(mode), count, uint32(valueType), offset)
+	c.backend.Call("drawElements", mode, count, valueType, offset)
+}
+
+// WebGL 2.0
+// Like DrawElements, start and end being the smallest and largest index values read
+func (c *RenderingContext) DrawRangeElements(mode types.GLEnum, start, end uint, count int, valueType types.GLEnum, offset int64) {
+	if !c.requireWebGL2("DrawRangeElements") {
+		return
+	}
+	c.backend.Call("drawRangeElements", mode, start, end, count, valueType, offset)
+}
+
+// Uses the native WebGL 2.0 call or the ANGLE_instanced_arrays extension
+func (c *RenderingContext) DrawArraysInstanced(mode types.GLEnum, first int, count int, instanceCount int) {
+	if c.IsWebGL2() {
+		c.backend.Call("drawArraysInstanced", mode, first, count, instanceCount)
+	} else if ext := c.instancedArrays("DrawArraysInstanced"); ext != nil {
+		ext.DrawArraysInstancedANGLE(mode, first, count, instanceCount)
+		c.countCall("drawArraysInstanced", mode, first, count, instanceCount)
+	}
+}
+
+// Uses the native WebGL 2.0 call or the ANGLE_instanced_arrays extension
+func (c *RenderingContext) DrawElementsInstanced(mode types.GLEnum, count int, valueType types.GLEnum, offset int64, instanceCount int) {
+	if c.IsWebGL2() {
+		c.backend.Call("drawElementsInstanced", mode, count, valueType, offset, instanceCount)
+	} else if ext := c.instancedArrays("DrawElementsInstanced"); ext != nil {
+		ext.DrawElementsInstancedANGLE(mode, count, valueType, offset, instanceCount)
+		c.countCall("drawElementsInstanced", mode, count, valueType, offset, instanceCount)
+	}
 }
 
 func (c *RenderingContext) Enable(cap types.GLEnum) {
-	c.js.Call("enable", uint32(cap))
+	c.appliedState = nil
+	c.backend.Call("enable", cap)
 }
 
 func (c *RenderingContext) EnableVertexAttribArray(index int) {
-	c.js.Call("enableVertexAttribArray", index)
+	c.backend.Call("enableVertexAttribArray", index)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) EndQuery(target types.GLEnum) {
+	if !c.requireWebGL2("EndQuery") {
+		return
+	}
+	c.backend.Call("endQuery", target)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) EndTransformFeedback() {
+	if !c.requireWebGL2("EndTransformFeedback") {
+		return
+	}
+	c.backend.Call("endTransformFeedback")
+}
+
+// WebGL 2.0
+// Condition must be SYNC_GPU_COMMANDS_COMPLETE and flags 0
+func (c *RenderingContext) FenceSync(condition types.GLEnum, flags uint32) *types.Sync {
+	if !c.requireWebGL2("FenceSync") {
+		return nil
+	}
+	sync := types.NewSync(c.backend.Call("fenceSync", condition, flags))
+	c.track(sync)
+	return sync
 }
 
+// Blocks until the previous calls have completed. With a command buffer it replays the buffered
+// calls first.
+func (c *RenderingContext) Finish() {
+	c.backend.Call("finish")
+}
+
+// Deprecated: Misspelled, use Finish
 func (c *RenderingContext) Finnish() {
-	c.js.Call("finnish")
+	c.Finish()
 }
 
 func (c *RenderingContext) Flush() {
-	c.js.Call("flush")
+	c.backend.Call("flush")
+}
+
+// Runs the calls buffered by a batched context, like one made by WrapContextBatched. To be
+// called once per frame, does nothing for other contexts.
+func (c *RenderingContext) FlushCommands() {
+	backend.Flush(c.backend)
 }
 
 func (c *RenderingContext) FrameBufferRenderbuffer(target types.GLEnum, attachment types.GLEnum, renderBufferTarget types.GLEnum, renderBuffer *types.RenderBuffer) {
-	c.js.Call("framebufferRenderbuffer", uint32(target), uint32(attachment), uint32(renderBufferTarget), renderBuffer.GetJs())
+	c.backend.Call("framebufferRenderbuffer", target, attachment, renderBufferTarget, renderBuffer)
 }
 
 func (c *RenderingContext) FrameBufferTexture2D(target types.GLEnum, attachment types.GLEnum, texTarget types.GLEnum, texture *types.Texture, level int) {
-	c.js.Call("framebufferTexture2D", uint32(target), uint32(attachment), uint32(texTarget), texture.GetJs(), level)
+	c.backend.Call("framebufferTexture2D", target, attachment, texTarget, texture, level)
+}
+
+// WebGL 2.0
+// Attaches a single layer of a 3D or 2D array texture
+func (c *RenderingContext) FrameBufferTextureLayer(target types.GLEnum, attachment types.GLEnum, texture *types.Texture, level int, layer int) {
+	if !c.requireWebGL2("FrameBufferTextureLayer") {
+		return
+	}
+	c.backend.Call("framebufferTextureLayer", target, attachment, texture, level, layer)
 }
 
 func (c *RenderingContext) FrontFace(mode types.GLEnum) {
-	c.js.Call("frontFace", uint32(mode))
+	c.appliedState = nil
+	c.backend.Call("frontFace", mode)
 }
 
 func (c *RenderingContext) GenerateMipmap(target types.GLEnum) {
-	c.js.Call("generateMipmap", uint32(target))
+	c.backend.Call("generateMipmap", target)
+	c.memory.generateMipmap(target)
 }
 
 func (c *RenderingContext) GetActiveAttrib(program *types.Program, index uint) *types.ActiveInfo {
-	info := c.js.Call("getActiveAttrib", program.GetJs(), index)
+	info := c.backend.Call("getActiveAttrib", program, index)
 	return types.NewActiveInfo(
 		info.Get("name").String(),
 		info.Get("size").Int(),
@@ -401,7 +902,7 @@ func (c *RenderingContext) GetActiveAttrib(program *types.Program, index uint) *
 }
 
 func (c *RenderingContext) GetActiveUniform(program *types.Program, index uint) *types.ActiveInfo {
-	info := c.js.Call("getActiveUniform", program.GetJs(), index)
+	info := c.backend.Call("getActiveUniform", program, index)
 	return types.NewActiveInfo(
 		info.Get("name").String(),
 		info.Get("size").Int(),
@@ -409,9 +910,150 @@ func (c *RenderingContext) GetActiveUniform(program *types.Program, index uint)
 	)
 }
 
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockName(program *types.Program, uniformBlockIndex uint) string {
+	if !c.requireWebGL2("GetActiveUniformBlockName") {
+		return ""
+	}
+	return c.backend.Call("getActiveUniformBlockName", program, uniformBlockIndex).String()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameter(program *types.Program, uniformBlockIndex uint, pName types.GLEnum) backend.Value {
+	if !c.requireWebGL2("GetActiveUniformBlockParameter") {
+		return backend.Null()
+	}
+	return c.backend.Call("getActiveUniformBlockParameter", program, uniformBlockIndex, pName)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterBinding(program *types.Program, uniformBlockIndex uint) uint {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterBinding") {
+		return 0
+	}
+	return uint(c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_BINDING).Int())
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterDataSize(program *types.Program, uniformBlockIndex uint) int {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterDataSize") {
+		return 0
+	}
+	return c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_DATA_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterActiveUniforms(program *types.Program, uniformBlockIndex uint) int {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterActiveUniforms") {
+		return 0
+	}
+	return c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_ACTIVE_UNIFORMS).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterActiveUniformIndices(program *types.Program, uniformBlockIndex uint) []uint {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterActiveUniformIndices") {
+		return nil
+	}
+	arrJs := c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES)
+	arr := make([]uint, arrJs.Length())
+	for i := 0; i < arrJs.Length(); i++ {
+		arr[i] = uint(arrJs.Index(i).Int())
+	}
+	return arr
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterReferencedByVertexShader(program *types.Program, uniformBlockIndex uint) bool {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterReferencedByVertexShader") {
+		return false
+	}
+	return c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_REFERENCED_BY_VERTEX_SHADER).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformBlockParameterReferencedByFragmentShader(program *types.Program, uniformBlockIndex uint) bool {
+	if !c.requireWebGL2("GetActiveUniformBlockParameterReferencedByFragmentShader") {
+		return false
+	}
+	return c.GetActiveUniformBlockParameter(program, uniformBlockIndex, UNIFORM_BLOCK_REFERENCED_BY_FRAGMENT_SHADER).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniforms(program *types.Program, uniformIndices []uint, pName types.GLEnum) backend.Value {
+	if !c.requireWebGL2("GetActiveUniforms") {
+		return backend.Null()
+	}
+	indicesJs := make([]interface{}, len(uniformIndices))
+	for i, index := range uniformIndices {
+		indicesJs[i] = index
+	}
+	return c.backend.Call("getActiveUniforms", program, indicesJs, pName)
+}
+
+func (c *RenderingContext) getActiveUniformsInt(program *types.Program, uniformIndices []uint, pName types.GLEnum) []int {
+	arrJs := c.GetActiveUniforms(program, uniformIndices, pName)
+	if arrJs.IsNull() || arrJs.IsUndefined() {
+		return nil
+	}
+	arr := make([]int, arrJs.Length())
+	for i := 0; i < arrJs.Length(); i++ {
+		arr[i] = arrJs.Index(i).Int()
+	}
+	return arr
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsType(program *types.Program, uniformIndices []uint) []types.GLEnum {
+	values := c.getActiveUniformsInt(program, uniformIndices, UNIFORM_TYPE)
+	arr := make([]types.GLEnum, len(values))
+	for i, value := range values {
+		arr[i] = types.GLEnum(value)
+	}
+	return arr
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsSize(program *types.Program, uniformIndices []uint) []int {
+	return c.getActiveUniformsInt(program, uniformIndices, UNIFORM_SIZE)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsBlockIndex(program *types.Program, uniformIndices []uint) []int {
+	return c.getActiveUniformsInt(program, uniformIndices, UNIFORM_BLOCK_INDEX)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsOffset(program *types.Program, uniformIndices []uint) []int {
+	return c.getActiveUniformsInt(program, uniformIndices, UNIFORM_OFFSET)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsArrayStride(program *types.Program, uniformIndices []uint) []int {
+	return c.getActiveUniformsInt(program, uniformIndices, UNIFORM_ARRAY_STRIDE)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsMatrixStride(program *types.Program, uniformIndices []uint) []int {
+	return c.getActiveUniformsInt(program, uniformIndices, UNIFORM_MATRIX_STRIDE)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetActiveUniformsIsRowMajor(program *types.Program, uniformIndices []uint) []bool {
+	arrJs := c.GetActiveUniforms(program, uniformIndices, UNIFORM_IS_ROW_MAJOR)
+	if arrJs.IsNull() || arrJs.IsUndefined() {
+		return nil
+	}
+	arr := make([]bool, arrJs.Length())
+	for i := 0; i < arrJs.Length(); i++ {
+		arr[i] = arrJs.Index(i).Bool()
+	}
+	return arr
+}
+
 func (c *RenderingContext) GetAttachedShaders(program *types.Program) []*types.Shader {
-	shadersJs := c.js.Call("getAttachedShaders", program.GetJs())
-	shaders := make([]*types.Shader, 0, shadersJs.Length())
+	shadersJs := c.backend.Call("getAttachedShaders", program)
+	shaders := make([]*types.Shader, shadersJs.Length())
 	for i := 0; i < shadersJs.Length(); i++ {
 		shaders[i] = types.NewShader(shadersJs.Index(i))
 	}
@@ -419,22 +1061,31 @@ func (c *RenderingContext) GetAttachedShaders(program *types.Program) []*types.S
 }
 
 func (c *RenderingContext) GetAttribLocation(program *types.Program, name string) int {
-	return c.js.Call("getAttribLocation", program.GetJs(), name).Int()
+	return c.backend.Call("getAttribLocation", program, name).Int()
 }
 
 func (c *RenderingContext) GetBufferParameter(target types.GLEnum, pName types.GLEnum) int {
-	return c.js.Call("getBufferParameter", uint32(target), uint32(pName)).Int()
+	return c.backend.Call("getBufferParameter", target, pName).Int()
+}
+
+// WebGL 2.0
+// Copies the contents of the buffer bound to target, starting at srcByteOffset, into dstData
+func (c *RenderingContext) GetBufferSubData(target types.GLEnum, srcByteOffset int, dstData []byte) {
+	if !c.requireWebGL2("GetBufferSubData") {
+		return
+	}
+	c.backend.Call("getBufferSubData", target, srcByteOffset, dstData)
 }
 
 func (c *RenderingContext) GetContextAttributes() *types.Attributes {
-	attrJs := c.js.Call("getContextAttributes")
-	if attrJs == js.Undefined() {
+	attrJs := c.backend.Call("getContextAttributes")
+	if attrJs.IsUndefined() {
 		return nil
 	} else {
 		return &types.Attributes{
 			Alpha:                        attrJs.Get("alpha").Bool(),
-			Antialias:                    attrJs.Get("alpha").Bool(),
-			Depth:                        attrJs.Get("antialias").Bool(),
+			Antialias:                    attrJs.Get("antialias").Bool(),
+			Depth:                        attrJs.Get("depth").Bool(),
 			FailIfMajorPerformanceCaveat: attrJs.Get("failIfMajorPerformanceCaveat").Bool(),
 			PowerPreference:              types.PowerPreference(attrJs.Get("powerPreference").String()),
 			PremultipliedAlpha:           attrJs.Get("premultipliedAlpha").Bool(),
@@ -446,50 +1097,90 @@ func (c *RenderingContext) GetContextAttributes() *types.Attributes {
 	}
 }
 
+// Returns the pending error of the context, a GLError for WebGL error flags. Once the context
+// is lost, ErrContextLost is returned until it is restored.
 func (c *RenderingContext) GetError() error {
-	errorJs := c.js.Call("getError")
-
-	switch types.GLEnum(errorJs.Int()) {
-	case NO_ERROR:
-		return nil
-	case INVALID_ENUM:
-		return errors.New("invalid enum")
-	case INVALID_VALUE:
-		return errors.New("invalid value")
-	case INVALID_OPERATION:
-		return errors.New("invalid operation")
-	case INVALID_FRAMEBUFFER_OPERATION:
-		return errors.New("invalid framebuffer operation")
-	case OUT_OF_MEMORY:
-		return errors.New("out of memory")
-	case CONTEXT_LOST_WEBGL:
-		return errors.New("context lost webgl")
+	if c.pendingError != nil {
+		err := c.pendingError
+		c.pendingError = nil
+		return err
 	}
+	return glError(c.getError())
+}
 
-	return errors.New("unknown error")
+// Drains every pending error, including the error raised on the Go side, into a joined error.
+// Returns nil when there are none.
+func (c *RenderingContext) GetErrors() error {
+	var errs []error
+	if c.pendingError != nil {
+		errs = append(errs, c.pendingError)
+		c.pendingError = nil
+	}
+	for i := 0; i < maxErrorFlags; i++ {
+		code := c.getError()
+		if code == NO_ERROR {
+			break
+		}
+		errs = append(errs, GLError{Code: code})
+		if code == CONTEXT_LOST_WEBGL {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebGL reports CONTEXT_LOST_WEBGL once, the loss is remembered and the context checked until
+// it is restored
+func (c *RenderingContext) getError() types.GLEnum {
+	code := types.GLEnum(c.backend.Call("getError").Int())
+	if code == CONTEXT_LOST_WEBGL {
+		c.contextLost = true
+	} else if code == NO_ERROR && c.contextLost {
+		if c.IsContextLost() {
+			return CONTEXT_LOST_WEBGL
+		}
+		c.contextLost = false
+	}
+	return code
 }
 
 func (c *RenderingContext) GetExtension(name string) *extensions.Extension {
-	return extensions.LoadGenericExtension(c.js, name)
+	return extensions.LoadGenericExtension(c.backend, name)
 }
 
 func (c *RenderingContext) GetExtensionLoseContext() *extensions.LoseContext {
-	return extensions.LoadLoseContextExtension(c.js)
+	return extensions.LoadLoseContextExtension(c.backend)
+}
+
+func (c *RenderingContext) GetExtensionDrawBuffers() *extensions.DrawBuffers {
+	return extensions.LoadDrawBuffersExtension(c.backend)
+}
+
+func (c *RenderingContext) GetExtensionInstancedArrays() *extensions.InstancedArrays {
+	return extensions.LoadInstancedArraysExtension(c.backend)
+}
+
+func (c *RenderingContext) GetExtensionDisjointTimerQuery() *extensions.DisjointTimerQuery {
+	return extensions.LoadDisjointTimerQueryExtension(c.backend)
+}
+
+func (c *RenderingContext) GetExtensionVertexArrayObject() *extensions.VertexArrayObject {
+	return extensions.LoadVertexArrayObjectExtension(c.backend)
 }
 
 // TODO: Add other extensions
 
 func (c *RenderingContext) GetFrameBufferAttachmentParameterInt(target types.GLEnum, attachment types.GLEnum, pName types.GLEnum) int {
-	return c.js.Call("getFramebufferAttachmentParameter", uint32(target), uint32(attachment), uint32(pName)).Int()
+	return c.backend.Call("getFramebufferAttachmentParameter", target, attachment, pName).Int()
 }
 
 func (c *RenderingContext) GetFrameBufferAttachmentParameterEnum(target types.GLEnum, attachment types.GLEnum, pName types.GLEnum) types.GLEnum {
-	return types.GLEnum(c.js.Call("getFramebufferAttachmentParameter", uint32(target), uint32(attachment), uint32(pName)).Int())
+	return types.GLEnum(c.backend.Call("getFramebufferAttachmentParameter", target, attachment, pName).Int())
 }
 
 func (c *RenderingContext) GetFrameBufferAttachmentParameterRenderBuffer(target types.GLEnum, attachment types.GLEnum, pName types.GLEnum) *types.RenderBuffer {
-	bufferJs := c.js.Call("getFramebufferAttachmentParameter", uint32(target), uint32(attachment), uint32(pName))
-	if bufferJs != js.Undefined() && bufferJs != js.Null() {
+	bufferJs := c.backend.Call("getFramebufferAttachmentParameter", target, attachment, pName)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
 		return types.NewRenderBuffer(bufferJs)
 	} else {
 		return nil
@@ -497,24 +1188,24 @@ func (c *RenderingContext) GetFrameBufferAttachmentParameterRenderBuffer(target
 }
 
 func (c *RenderingContext) GetFrameBufferAttachmentParameterTexture(target types.GLEnum, attachment types.GLEnum, pName types.GLEnum) *types.Texture {
-	textureJs := c.js.Call("getFramebufferAttachmentParameter", uint32(target), uint32(attachment), uint32(pName))
-	if textureJs != js.Undefined() && textureJs != js.Null() {
+	textureJs := c.backend.Call("getFramebufferAttachmentParameter", target, attachment, pName)
+	if !textureJs.IsUndefined() && !textureJs.IsNull() {
 		return types.NewTexture(textureJs)
 	} else {
 		return nil
 	}
 }
 
-func (c *RenderingContext) GetParameter(pName types.GLEnum) js.Value {
-	return c.js.Call("getParameter", uint32(pName))
+func (c *RenderingContext) GetParameter(pName types.GLEnum) backend.Value {
+	return c.backend.Call("getParameter", pName)
 }
 
 func (c *RenderingContext) GetParameterActiveTexture() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", ACTIVE_TEXTURE).Int())
+	return types.GLEnum(c.backend.Call("getParameter", ACTIVE_TEXTURE).Int())
 }
 
 func (c *RenderingContext) GetParameterAliasedLineWidthRange() [2]float32 {
-	arrJs := c.js.Call("getParameter", ALIASED_LINE_WIDTH_RANGE)
+	arrJs := c.backend.Call("getParameter", ALIASED_LINE_WIDTH_RANGE)
 	var arr [2]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -522,7 +1213,7 @@ func (c *RenderingContext) GetParameterAliasedLineWidthRange() [2]float32 {
 }
 
 func (c *RenderingContext) GetParameterAliasedPointSizeRange() [2]float32 {
-	arrJs := c.js.Call("getParameter", ALIASED_POINT_SIZE_RANGE)
+	arrJs := c.backend.Call("getParameter", ALIASED_POINT_SIZE_RANGE)
 	var arr [2]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -530,12 +1221,12 @@ func (c *RenderingContext) GetParameterAliasedPointSizeRange() [2]float32 {
 }
 
 func (c *RenderingContext) GetParameterAlphaBits() int {
-	return c.js.Call("getParameter", ALPHA_BITS).Int()
+	return c.backend.Call("getParameter", ALPHA_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterArrayBufferBinding() *types.Buffer {
-	bufferJs := c.js.Call("getParameter", ARRAY_BUFFER_BINDING)
-	if bufferJs != js.Undefined() && bufferJs != js.Null() {
+	bufferJs := c.backend.Call("getParameter", ARRAY_BUFFER_BINDING)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
 		return types.NewBuffer(bufferJs)
 	} else {
 		return nil
@@ -543,11 +1234,11 @@ func (c *RenderingContext) GetParameterArrayBufferBinding() *types.Buffer {
 }
 
 func (c *RenderingContext) GetParameterBlend() bool {
-	return c.js.Call("getParameter", BLEND).Bool()
+	return c.backend.Call("getParameter", BLEND).Bool()
 }
 
 func (c *RenderingContext) GetParameterBlendColor() [4]float32 {
-	arrJs := c.js.Call("getParameter", BLEND_COLOR)
+	arrJs := c.backend.Call("getParameter", BLEND_COLOR)
 	var arr [4]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -557,39 +1248,39 @@ func (c *RenderingContext) GetParameterBlendColor() [4]float32 {
 }
 
 func (c *RenderingContext) GetParameterBlendDstAlpha() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_DST_ALPHA).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_DST_ALPHA).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendDstRgb() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_DST_RGB).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_DST_RGB).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendEquation() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_EQUATION).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_EQUATION).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendEquationAlpha() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_EQUATION_ALPHA).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_EQUATION_ALPHA).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendEquationRgb() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_EQUATION_RGB).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_EQUATION_RGB).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendSrcAlpha() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_SRC_ALPHA).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_SRC_ALPHA).Int())
 }
 
 func (c *RenderingContext) GetParameterBlendSrcRgb() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", BLEND_SRC_RGB).Int())
+	return types.GLEnum(c.backend.Call("getParameter", BLEND_SRC_RGB).Int())
 }
 
 func (c *RenderingContext) GetParameterBlueBits() int {
-	return c.js.Call("getParameter", BLUE_BITS).Int()
+	return c.backend.Call("getParameter", BLUE_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterColorClearValue() [4]float32 {
-	arrJs := c.js.Call("getParameter", COLOR_CLEAR_VALUE)
+	arrJs := c.backend.Call("getParameter", COLOR_CLEAR_VALUE)
 	var arr [4]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -599,7 +1290,7 @@ func (c *RenderingContext) GetParameterColorClearValue() [4]float32 {
 }
 
 func (c *RenderingContext) GetParameterColorWritemask() [4]bool {
-	arrJs := c.js.Call("getParameter", COLOR_WRITEMASK)
+	arrJs := c.backend.Call("getParameter", COLOR_WRITEMASK)
 	var arr [4]bool
 	arr[0] = arrJs.Index(0).Bool()
 	arr[1] = arrJs.Index(1).Bool()
@@ -609,7 +1300,7 @@ func (c *RenderingContext) GetParameterColorWritemask() [4]bool {
 }
 
 func (c *RenderingContext) GetParameterCompressedTextureFormats() []types.GLEnum {
-	arrJs := c.js.Call("getParameter", COMPRESSED_TEXTURE_FORMATS)
+	arrJs := c.backend.Call("getParameter", COMPRESSED_TEXTURE_FORMATS)
 	arr := make([]types.GLEnum, arrJs.Length())
 	for i := 0; i < arrJs.Length(); i++ {
 		arr[i] = types.GLEnum(arrJs.Index(i).Int())
@@ -618,16 +1309,16 @@ func (c *RenderingContext) GetParameterCompressedTextureFormats() []types.GLEnum
 }
 
 func (c *RenderingContext) GetParameterCullFace() bool {
-	return c.js.Call("getParameter", CULL_FACE).Bool()
+	return c.backend.Call("getParameter", CULL_FACE).Bool()
 }
 
 func (c *RenderingContext) GetParameterCullFaceMode() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", CULL_FACE_MODE).Int())
+	return types.GLEnum(c.backend.Call("getParameter", CULL_FACE_MODE).Int())
 }
 
 func (c *RenderingContext) GetParameterCurrentProgram() *types.Program {
-	programJs := c.js.Call("getParameter", CURRENT_PROGRAM)
-	if programJs != js.Undefined() && programJs != js.Null() {
+	programJs := c.backend.Call("getParameter", CURRENT_PROGRAM)
+	if !programJs.IsUndefined() && !programJs.IsNull() {
 		return types.NewProgram(programJs)
 	} else {
 		return nil
@@ -635,16 +1326,38 @@ func (c *RenderingContext) GetParameterCurrentProgram() *types.Program {
 }
 
 func (c *RenderingContext) GetParameterDepthBits() float32 {
-	return float32(c.js.Call("getParameter", DEPTH_BITS).Float())
+	return float32(c.backend.Call("getParameter", DEPTH_BITS).Float())
+}
+
+func (c *RenderingContext) GetParameterDrawBuffer(index int) types.GLEnum {
+	if c.IsWebGL2() {
+		return types.GLEnum(c.backend.Call("getParameter", DRAW_BUFFER0+types.GLEnum(index)).Int())
+	} else if c.drawBuffers("GetParameterDrawBuffer") != nil {
+		return types.GLEnum(c.backend.Call("getParameter", extensions.DRAW_BUFFER0_WEBGL+types.GLEnum(index)).Int())
+	}
+	return BACK
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterDrawFrameBufferBinding() *types.FrameBuffer {
+	if !c.requireWebGL2("GetParameterDrawFrameBufferBinding") {
+		return nil
+	}
+	frameBufferJs := c.backend.Call("getParameter", DRAW_FRAMEBUFFER_BINDING)
+	if !frameBufferJs.IsUndefined() && !frameBufferJs.IsNull() {
+		return types.NewFrameBuffer(frameBufferJs)
+	} else {
+		return nil
+	}
 }
 
 func (c *RenderingContext) GetParameterDepthFunc() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", DEPTH_FUNC).Int())
+	return types.GLEnum(c.backend.Call("getParameter", DEPTH_FUNC).Int())
 }
 
 func (c *RenderingContext) GetParameterElementArrayBufferBinding() *types.Buffer {
-	bufferJs := c.js.Call("getParameter", ELEMENT_ARRAY_BUFFER_BINDING)
-	if bufferJs != js.Undefined() && bufferJs != js.Null() {
+	bufferJs := c.backend.Call("getParameter", ELEMENT_ARRAY_BUFFER_BINDING)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
 		return types.NewBuffer(bufferJs)
 	} else {
 		return nil
@@ -652,8 +1365,8 @@ func (c *RenderingContext) GetParameterElementArrayBufferBinding() *types.Buffer
 }
 
 func (c *RenderingContext) GetParameterFrameBufferBinding() *types.FrameBuffer {
-	frameBufferJs := c.js.Call("getParameter", FRAMEBUFFER_BINDING)
-	if frameBufferJs != js.Undefined() && frameBufferJs != js.Null() {
+	frameBufferJs := c.backend.Call("getParameter", FRAMEBUFFER_BINDING)
+	if !frameBufferJs.IsUndefined() && !frameBufferJs.IsNull() {
 		return types.NewFrameBuffer(frameBufferJs)
 	} else {
 		return nil
@@ -661,71 +1374,160 @@ func (c *RenderingContext) GetParameterFrameBufferBinding() *types.FrameBuffer {
 }
 
 func (c *RenderingContext) GetParameterFrontFace() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", FRONT_FACE).Int())
+	return types.GLEnum(c.backend.Call("getParameter", FRONT_FACE).Int())
+}
+
+// Needs the disjoint timer query extension, a true value invalidates the timer queries in flight
+func (c *RenderingContext) GetParameterGpuDisjointExt() bool {
+	return c.backend.Call("getParameter", extensions.GPU_DISJOINT_EXT).Bool()
 }
 
 func (c *RenderingContext) GetParameterGenerateMipmapHint() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", GENERATE_MIPMAP_HINT).Int())
+	return types.GLEnum(c.backend.Call("getParameter", GENERATE_MIPMAP_HINT).Int())
 }
 
 func (c *RenderingContext) GetParameterGreenBits() int {
-	return c.js.Call("getParameter", GREEN_BITS).Int()
+	return c.backend.Call("getParameter", GREEN_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterImplementationColorReadFormat() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", IMPLEMENTATION_COLOR_READ_FORMAT).Int())
+	return types.GLEnum(c.backend.Call("getParameter", IMPLEMENTATION_COLOR_READ_FORMAT).Int())
 }
 
 func (c *RenderingContext) GetParameterImplementationColorReadType() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", IMPLEMENTATION_COLOR_READ_TYPE).Int())
+	return types.GLEnum(c.backend.Call("getParameter", IMPLEMENTATION_COLOR_READ_TYPE).Int())
 }
 
 func (c *RenderingContext) GetParameterLineWidth() float32 {
-	return float32(c.js.Call("getParameter", LINE_WIDTH).Float())
+	return float32(c.backend.Call("getParameter", LINE_WIDTH).Float())
 }
 
 func (c *RenderingContext) GetParameterCombinedTextureImageUnits() int {
-	return c.js.Call("getParameter", MAX_COMBINED_TEXTURE_IMAGE_UNITS).Int()
+	return c.backend.Call("getParameter", MAX_COMBINED_TEXTURE_IMAGE_UNITS).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMax3DTextureSize() int {
+	if !c.requireWebGL2("GetParameterMax3DTextureSize") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_3D_TEXTURE_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxArrayTextureLayers() int {
+	if !c.requireWebGL2("GetParameterMaxArrayTextureLayers") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_ARRAY_TEXTURE_LAYERS).Int()
+}
+
+// Uses MAX_COLOR_ATTACHMENTS_WEBGL on WebGL 1.0, a context without WEBGL_draw_buffers has a single one
+func (c *RenderingContext) GetParameterMaxColorAttachments() int {
+	if c.IsWebGL2() {
+		return c.backend.Call("getParameter", MAX_COLOR_ATTACHMENTS).Int()
+	} else if c.hasDrawBuffers() {
+		return c.backend.Call("getParameter", MAX_COLOR_ATTACHMENTS_WEBGL).Int()
+	}
+	return 1
 }
 
 func (c *RenderingContext) GetParameterMaxCubeMapTextureSize() int {
-	return c.js.Call("getParameter", MAX_CUBE_MAP_TEXTURE_SIZE).Int()
+	return c.backend.Call("getParameter", MAX_CUBE_MAP_TEXTURE_SIZE).Int()
+}
+
+// Uses MAX_DRAW_BUFFERS_WEBGL on WebGL 1.0, a context without WEBGL_draw_buffers has a single one
+func (c *RenderingContext) GetParameterMaxDrawBuffers() int {
+	if c.IsWebGL2() {
+		return c.backend.Call("getParameter", MAX_DRAW_BUFFERS).Int()
+	} else if c.hasDrawBuffers() {
+		return c.backend.Call("getParameter", MAX_DRAW_BUFFERS_WEBGL).Int()
+	}
+	return 1
 }
 
 func (c *RenderingContext) GetParameterMaxFragmentUniformVectors() int {
-	return c.js.Call("getParameter", MAX_FRAGMENT_UNIFORM_VECTORS).Int()
+	return c.backend.Call("getParameter", MAX_FRAGMENT_UNIFORM_VECTORS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxRenderBufferSize() int {
-	return c.js.Call("getParameter", MAX_RENDERBUFFER_SIZE).Int()
+	return c.backend.Call("getParameter", MAX_RENDERBUFFER_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxSamples() int {
+	if !c.requireWebGL2("GetParameterMaxSamples") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_SAMPLES).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxTextureImageUnits() int {
-	return c.js.Call("getParameter", MAX_TEXTURE_IMAGE_UNITS).Int()
+	return c.backend.Call("getParameter", MAX_TEXTURE_IMAGE_UNITS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxTextureSize() int {
-	return c.js.Call("getParameter", MAX_TEXTURE_SIZE).Int()
+	return c.backend.Call("getParameter", MAX_TEXTURE_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxTransformFeedbackInterleavedComponents() int {
+	if !c.requireWebGL2("GetParameterMaxTransformFeedbackInterleavedComponents") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_TRANSFORM_FEEDBACK_INTERLEAVED_COMPONENTS).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxTransformFeedbackSeparateAttribs() int {
+	if !c.requireWebGL2("GetParameterMaxTransformFeedbackSeparateAttribs") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_TRANSFORM_FEEDBACK_SEPARATE_ATTRIBS).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxTransformFeedbackSeparateComponents() int {
+	if !c.requireWebGL2("GetParameterMaxTransformFeedbackSeparateComponents") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_TRANSFORM_FEEDBACK_SEPARATE_COMPONENTS).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxUniformBlockSize() int {
+	if !c.requireWebGL2("GetParameterMaxUniformBlockSize") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_UNIFORM_BLOCK_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterMaxUniformBufferBindings() int {
+	if !c.requireWebGL2("GetParameterMaxUniformBufferBindings") {
+		return 0
+	}
+	return c.backend.Call("getParameter", MAX_UNIFORM_BUFFER_BINDINGS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxVaryingVectors() int {
-	return c.js.Call("getParameter", MAX_VARYING_VECTORS).Int()
+	return c.backend.Call("getParameter", MAX_VARYING_VECTORS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxVertexAttribs() int {
-	return c.js.Call("getParameter", MAX_VERTEX_ATTRIBS).Int()
+	return c.backend.Call("getParameter", MAX_VERTEX_ATTRIBS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxVertexTextureImageUnits() int {
-	return c.js.Call("getParameter", MAX_VERTEX_TEXTURE_IMAGE_UNITS).Int()
+	return c.backend.Call("getParameter", MAX_VERTEX_TEXTURE_IMAGE_UNITS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxVertexUniformVectors() int {
-	return c.js.Call("getParameter", MAX_VERTEX_UNIFORM_VECTORS).Int()
+	return c.backend.Call("getParameter", MAX_VERTEX_UNIFORM_VECTORS).Int()
 }
 
 func (c *RenderingContext) GetParameterMaxViewportDims() [2]float32 {
-	arrJs := c.js.Call("getParameter", MAX_VIEWPORT_DIMS)
+	arrJs := c.backend.Call("getParameter", MAX_VIEWPORT_DIMS)
 	var arr [2]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -733,28 +1535,49 @@ func (c *RenderingContext) GetParameterMaxViewportDims() [2]float32 {
 }
 
 func (c *RenderingContext) GetParameterPackAlignment() int {
-	return c.js.Call("getParameter", PACK_ALIGNMENT).Int()
+	return c.backend.Call("getParameter", PACK_ALIGNMENT).Int()
 }
 
 func (c *RenderingContext) GetParameterPolygonOffsetFactor() float32 {
-	return float32(c.js.Call("getParameter", POLYGON_OFFSET_FACTOR).Float())
+	return float32(c.backend.Call("getParameter", POLYGON_OFFSET_FACTOR).Float())
 }
 
 func (c *RenderingContext) GetParameterPolygonOffsetFill() bool {
-	return c.js.Call("getParameter", POLYGON_OFFSET_FILL).Bool()
+	return c.backend.Call("getParameter", POLYGON_OFFSET_FILL).Bool()
 }
 
 func (c *RenderingContext) GetParameterPolygonOffsetUnits() float32 {
-	return float32(c.js.Call("getParameter", POLYGON_OFFSET_UNITS).Float())
+	return float32(c.backend.Call("getParameter", POLYGON_OFFSET_UNITS).Float())
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterReadBuffer() types.GLEnum {
+	if !c.requireWebGL2("GetParameterReadBuffer") {
+		return 0
+	}
+	return types.GLEnum(c.backend.Call("getParameter", READ_BUFFER).Int())
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterReadFrameBufferBinding() *types.FrameBuffer {
+	if !c.requireWebGL2("GetParameterReadFrameBufferBinding") {
+		return nil
+	}
+	frameBufferJs := c.backend.Call("getParameter", READ_FRAMEBUFFER_BINDING)
+	if !frameBufferJs.IsUndefined() && !frameBufferJs.IsNull() {
+		return types.NewFrameBuffer(frameBufferJs)
+	} else {
+		return nil
+	}
 }
 
 func (c *RenderingContext) GetParameterRedBits() int {
-	return c.js.Call("getParameter", RED_BITS).Int()
+	return c.backend.Call("getParameter", RED_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterRenderBufferBinding() *types.RenderBuffer {
-	bufferJs := c.js.Call("getParameter", RENDERBUFFER_BINDING)
-	if bufferJs != js.Undefined() && bufferJs != js.Null() {
+	bufferJs := c.backend.Call("getParameter", RENDERBUFFER_BINDING)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
 		return types.NewRenderBuffer(bufferJs)
 	} else {
 		return nil
@@ -762,27 +1585,41 @@ func (c *RenderingContext) GetParameterRenderBufferBinding() *types.RenderBuffer
 }
 
 func (c *RenderingContext) GetParameterRenderer() string {
-	return c.js.Call("getParameter", RENDERER).String()
+	return c.backend.Call("getParameter", RENDERER).String()
 }
 
 func (c *RenderingContext) GetParameterSampleBuffers() int {
-	return c.js.Call("getParameter", SAMPLE_BUFFERS).Int()
+	return c.backend.Call("getParameter", SAMPLE_BUFFERS).Int()
 }
 
 func (c *RenderingContext) GetParameterSampleCoverageInvert() bool {
-	return c.js.Call("getParameter", SAMPLE_COVERAGE_INVERT).Bool()
+	return c.backend.Call("getParameter", SAMPLE_COVERAGE_INVERT).Bool()
 }
 
 func (c *RenderingContext) GetParameterSampleCoverageValue() float32 {
-	return float32(c.js.Call("getParameter", SAMPLE_COVERAGE_VALUE).Float())
+	return float32(c.backend.Call("getParameter", SAMPLE_COVERAGE_VALUE).Float())
 }
 
 func (c *RenderingContext) GetParameterSamples() int {
-	return c.js.Call("getParameter", SAMPLES).Int()
+	return c.backend.Call("getParameter", SAMPLES).Int()
+}
+
+// WebGL 2.0
+// Sampler bound to the active texture unit
+func (c *RenderingContext) GetParameterSamplerBinding() *types.Sampler {
+	if !c.requireWebGL2("GetParameterSamplerBinding") {
+		return nil
+	}
+	samplerJs := c.backend.Call("getParameter", SAMPLER_BINDING)
+	if !samplerJs.IsUndefined() && !samplerJs.IsNull() {
+		return types.NewSampler(samplerJs)
+	} else {
+		return nil
+	}
 }
 
 func (c *RenderingContext) GetParameterScissorBox() [4]bool {
-	arrJs := c.js.Call("getParameter", SCISSOR_BOX)
+	arrJs := c.backend.Call("getParameter", SCISSOR_BOX)
 	var arr [4]bool
 	arr[0] = arrJs.Index(0).Bool()
 	arr[1] = arrJs.Index(1).Bool()
@@ -792,88 +1629,88 @@ func (c *RenderingContext) GetParameterScissorBox() [4]bool {
 }
 
 func (c *RenderingContext) GetParameterScissorTest() bool {
-	return c.js.Call("getParameter", SCISSOR_TEST).Bool()
+	return c.backend.Call("getParameter", SCISSOR_TEST).Bool()
 }
 
 func (c *RenderingContext) GetParameterShadingLanguageVersion() string {
-	return c.js.Call("getParameter", SHADING_LANGUAGE_VERSION).String()
+	return c.backend.Call("getParameter", SHADING_LANGUAGE_VERSION).String()
 }
 
 func (c *RenderingContext) GetParameterStencilBackFail() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_BACK_FAIL).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_BACK_FAIL).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBackFunc() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_BACK_FUNC).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_BACK_FUNC).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBackPassDepthFail() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_BACK_PASS_DEPTH_FAIL).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_BACK_PASS_DEPTH_FAIL).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBackPassDepthPass() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_BACK_PASS_DEPTH_PASS).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_BACK_PASS_DEPTH_PASS).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBackRef() int {
-	return c.js.Call("getParameter", STENCIL_BACK_REF).Int()
+	return c.backend.Call("getParameter", STENCIL_BACK_REF).Int()
 }
 
 func (c *RenderingContext) GetParameterStencilBackValueMask() uint {
-	return uint(c.js.Call("getParameter", STENCIL_BACK_VALUE_MASK).Int())
+	return uint(c.backend.Call("getParameter", STENCIL_BACK_VALUE_MASK).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBackWritemask() uint {
-	return uint(c.js.Call("getParameter", STENCIL_BACK_WRITEMASK).Int())
+	return uint(c.backend.Call("getParameter", STENCIL_BACK_WRITEMASK).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilBits() int {
-	return c.js.Call("getParameter", STENCIL_BITS).Int()
+	return c.backend.Call("getParameter", STENCIL_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterStencilClearValue() int {
-	return c.js.Call("getParameter", STENCIL_CLEAR_VALUE).Int()
+	return c.backend.Call("getParameter", STENCIL_CLEAR_VALUE).Int()
 }
 
 func (c *RenderingContext) GetParameterStencilFail() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_FAIL).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_FAIL).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilFunc() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_FUNC).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_FUNC).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilPassDepthFail() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_PASS_DEPTH_FAIL).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_PASS_DEPTH_FAIL).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilPassDepthPass() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", STENCIL_PASS_DEPTH_PASS).Int())
+	return types.GLEnum(c.backend.Call("getParameter", STENCIL_PASS_DEPTH_PASS).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilRef() int {
-	return c.js.Call("getParameter", STENCIL_REF).Int()
+	return c.backend.Call("getParameter", STENCIL_REF).Int()
 }
 
 func (c *RenderingContext) GetParameterStencilTest() bool {
-	return c.js.Call("getParameter", STENCIL_TEST).Bool()
+	return c.backend.Call("getParameter", STENCIL_TEST).Bool()
 }
 
 func (c *RenderingContext) GetParameterStencilValueMask() uint {
-	return uint(c.js.Call("getParameter", STENCIL_VALUE_MASK).Int())
+	return uint(c.backend.Call("getParameter", STENCIL_VALUE_MASK).Int())
 }
 
 func (c *RenderingContext) GetParameterStencilWritemask() uint {
-	return uint(c.js.Call("getParameter", STENCIL_WRITEMASK).Int())
+	return uint(c.backend.Call("getParameter", STENCIL_WRITEMASK).Int())
 }
 
 func (c *RenderingContext) GetParameterSubpixelBits() int {
-	return c.js.Call("getParameter", SUBPIXEL_BITS).Int()
+	return c.backend.Call("getParameter", SUBPIXEL_BITS).Int()
 }
 
 func (c *RenderingContext) GetParameterTextureBinding2D() *types.Texture {
-	textureJs := c.js.Call("getParameter", TEXTURE_BINDING_2D)
-	if textureJs != js.Undefined() && textureJs != js.Null() {
+	textureJs := c.backend.Call("getParameter", TEXTURE_BINDING_2D)
+	if !textureJs.IsUndefined() && !textureJs.IsNull() {
 		return types.NewTexture(textureJs)
 	} else {
 		return nil
@@ -881,40 +1718,139 @@ func (c *RenderingContext) GetParameterTextureBinding2D() *types.Texture {
 }
 
 func (c *RenderingContext) GetParameterTextureBindingCubeMap() *types.Texture {
-	textureJs := c.js.Call("getParameter", TEXTURE_BINDING_CUBE_MAP)
-	if textureJs != js.Undefined() && textureJs != js.Null() {
+	textureJs := c.backend.Call("getParameter", TEXTURE_BINDING_CUBE_MAP)
+	if !textureJs.IsUndefined() && !textureJs.IsNull() {
 		return types.NewTexture(textureJs)
 	} else {
 		return nil
 	}
 }
 
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTextureBinding2DArray() *types.Texture {
+	if !c.requireWebGL2("GetParameterTextureBinding2DArray") {
+		return nil
+	}
+	textureJs := c.backend.Call("getParameter", TEXTURE_BINDING_2D_ARRAY)
+	if !textureJs.IsUndefined() && !textureJs.IsNull() {
+		return types.NewTexture(textureJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTextureBinding3D() *types.Texture {
+	if !c.requireWebGL2("GetParameterTextureBinding3D") {
+		return nil
+	}
+	textureJs := c.backend.Call("getParameter", TEXTURE_BINDING_3D)
+	if !textureJs.IsUndefined() && !textureJs.IsNull() {
+		return types.NewTexture(textureJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTransformFeedbackActive() bool {
+	if !c.requireWebGL2("GetParameterTransformFeedbackActive") {
+		return false
+	}
+	return c.backend.Call("getParameter", TRANSFORM_FEEDBACK_ACTIVE).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTransformFeedbackBinding() *types.TransformFeedback {
+	if !c.requireWebGL2("GetParameterTransformFeedbackBinding") {
+		return nil
+	}
+	transformFeedbackJs := c.backend.Call("getParameter", TRANSFORM_FEEDBACK_BINDING)
+	if !transformFeedbackJs.IsUndefined() && !transformFeedbackJs.IsNull() {
+		return types.NewTransformFeedback(transformFeedbackJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTransformFeedbackBufferBinding() *types.Buffer {
+	if !c.requireWebGL2("GetParameterTransformFeedbackBufferBinding") {
+		return nil
+	}
+	bufferJs := c.backend.Call("getParameter", TRANSFORM_FEEDBACK_BUFFER_BINDING)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
+		return types.NewBuffer(bufferJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterTransformFeedbackPaused() bool {
+	if !c.requireWebGL2("GetParameterTransformFeedbackPaused") {
+		return false
+	}
+	return c.backend.Call("getParameter", TRANSFORM_FEEDBACK_PAUSED).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterUniformBufferBinding() *types.Buffer {
+	if !c.requireWebGL2("GetParameterUniformBufferBinding") {
+		return nil
+	}
+	bufferJs := c.backend.Call("getParameter", UNIFORM_BUFFER_BINDING)
+	if !bufferJs.IsUndefined() && !bufferJs.IsNull() {
+		return types.NewBuffer(bufferJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetParameterUniformBufferOffsetAlignment() int {
+	if !c.requireWebGL2("GetParameterUniformBufferOffsetAlignment") {
+		return 0
+	}
+	return c.backend.Call("getParameter", UNIFORM_BUFFER_OFFSET_ALIGNMENT).Int()
+}
+
 func (c *RenderingContext) GetParameterUnpackAlignment() int {
-	return c.js.Call("getParameter", UNPACK_ALIGNMENT).Int()
+	return c.backend.Call("getParameter", UNPACK_ALIGNMENT).Int()
 }
 
 func (c *RenderingContext) GetParameterUnpackColorspaceConversionWebGL() types.GLEnum {
-	return types.GLEnum(c.js.Call("getParameter", UNPACK_COLORSPACE_CONVERSION_WEBGL).Int())
+	return types.GLEnum(c.backend.Call("getParameter", UNPACK_COLORSPACE_CONVERSION_WEBGL).Int())
 }
 
 func (c *RenderingContext) GetParameterUnpackFlipYWebGL() bool {
-	return c.js.Call("getParameter", UNPACK_FLIP_Y_WEBGL).Bool()
+	return c.backend.Call("getParameter", UNPACK_FLIP_Y_WEBGL).Bool()
 }
 
 func (c *RenderingContext) GetParameterUnpackPremultiplyAlphaWebGL() bool {
-	return c.js.Call("getParameter", UNPACK_PREMULTIPLY_ALPHA_WEBGL).Bool()
+	return c.backend.Call("getParameter", UNPACK_PREMULTIPLY_ALPHA_WEBGL).Bool()
 }
 
 func (c *RenderingContext) GetParameterVendor() string {
-	return c.js.Call("getParameter", VENDOR).String()
+	return c.backend.Call("getParameter", VENDOR).String()
 }
 
 func (c *RenderingContext) GetParameterVersion() string {
-	return c.js.Call("getParameter", VERSION).String()
+	return c.backend.Call("getParameter", VERSION).String()
+}
+
+// Uses VERTEX_ARRAY_BINDING_OES on WebGL 1.0, which has the same value
+func (c *RenderingContext) GetParameterVertexArrayBinding() *types.VertexArray {
+	vertexArrayJs := c.backend.Call("getParameter", VERTEX_ARRAY_BINDING)
+	if !vertexArrayJs.IsUndefined() && !vertexArrayJs.IsNull() {
+		return types.NewVertexArray(vertexArrayJs)
+	} else {
+		return nil
+	}
 }
 
 func (c *RenderingContext) GetParameterViewport() [4]bool {
-	arrJs := c.js.Call("getParameter", VIEWPORT)
+	arrJs := c.backend.Call("getParameter", VIEWPORT)
 	var arr [4]bool
 	arr[0] = arrJs.Index(0).Bool()
 	arr[1] = arrJs.Index(1).Bool()
@@ -926,11 +1862,11 @@ func (c *RenderingContext) GetParameterViewport() [4]bool {
 // TODO: Add WebGL 2.0 parameters
 
 func (c *RenderingContext) GetProgramInfoLog(program *types.Program) string {
-	return c.js.Call("getProgramInfoLog", program.GetJs()).String()
+	return c.backend.Call("getProgramInfoLog", program).String()
 }
 
-func (c *RenderingContext) GetProgramParameter(program *types.Program, pName types.GLEnum) js.Value {
-	return c.js.Call("getProgramParameter", program.GetJs(), uint32(pName))
+func (c *RenderingContext) GetProgramParameter(program *types.Program, pName types.GLEnum) backend.Value {
+	return c.backend.Call("getProgramParameter", program, pName)
 }
 
 func (c *RenderingContext) GetProgramParameterDeleteStatus(program *types.Program) bool {
@@ -959,70 +1895,209 @@ func (c *RenderingContext) GetProgramParameterActiveUniforms(program *types.Prog
 
 // WebGL 2.0
 func (c *RenderingContext) GetProgramParameterTransformFeedbackBufferMode(program *types.Program) types.GLEnum {
+	if !c.requireWebGL2("GetProgramParameterTransformFeedbackBufferMode") {
+		return 0
+	}
 	return types.GLEnum(c.GetProgramParameter(program, TRANSFORM_FEEDBACK_BUFFER_MODE).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetProgramParameterTransformFeedbackVaryings(program *types.Program) int {
+	if !c.requireWebGL2("GetProgramParameterTransformFeedbackVaryings") {
+		return 0
+	}
 	return c.GetProgramParameter(program, TRANSFORM_FEEDBACK_VARYINGS).Int()
 }
 
 // WebGL 2.0
-func (c *RenderingContext) GetProgramParameterActiveUniformBlocks(program *types.Program) int {
-	return c.GetProgramParameter(program, ACTIVE_UNIFORM_BLOCKS).Int()
-}
-
-func (c *RenderingContext) GetRenderbufferParameter(target types.GLEnum, pName types.GLEnum) js.Value {
-	return c.js.Call("getRenderbufferParameter", uint32(target), uint32(pName))
+func (c *RenderingContext) GetProgramParameterActiveUniformBlocks(program *types.Program) int {
+	if !c.requireWebGL2("GetProgramParameterActiveUniformBlocks") {
+		return 0
+	}
+	return c.GetProgramParameter(program, ACTIVE_UNIFORM_BLOCKS).Int()
+}
+
+// WebGL 2.0
+// Returns the active query for the target, pName must be CURRENT_QUERY
+func (c *RenderingContext) GetQuery(target types.GLEnum, pName types.GLEnum) *types.Query {
+	if !c.requireWebGL2("GetQuery") {
+		return nil
+	}
+	queryJs := c.backend.Call("getQuery", target, pName)
+	if !queryJs.IsUndefined() && !queryJs.IsNull() {
+		return types.NewQuery(queryJs)
+	} else {
+		return nil
+	}
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetQueryParameter(query *types.Query, pName types.GLEnum) backend.Value {
+	if !c.requireWebGL2("GetQueryParameter") {
+		return backend.Null()
+	}
+	return c.backend.Call("getQueryParameter", query, pName)
+}
+
+// WebGL 2.0
+// Number of samples or primitives, or nanoseconds for timer queries. Boolean results are 0 or 1.
+func (c *RenderingContext) GetQueryParameterResult(query *types.Query) uint64 {
+	if !c.requireWebGL2("GetQueryParameterResult") {
+		return 0
+	}
+	return uint64(c.GetQueryParameter(query, QUERY_RESULT).Float())
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetQueryParameterResultAvailable(query *types.Query) bool {
+	if !c.requireWebGL2("GetQueryParameterResultAvailable") {
+		return false
+	}
+	return c.GetQueryParameter(query, QUERY_RESULT_AVAILABLE).Bool()
+}
+
+func (c *RenderingContext) GetRenderbufferParameter(target types.GLEnum, pName types.GLEnum) backend.Value {
+	return c.backend.Call("getRenderbufferParameter", target, pName)
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferWidth(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_WIDTH).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferHeight(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_HEIGHT).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferInternalFormat(target types.GLEnum) types.GLEnum {
+	return types.GLEnum(c.GetRenderbufferParameter(target, RENDERBUFFER_INTERNAL_FORMAT).Int())
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferGreenSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_GREEN_SIZE).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferBlueSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_BLUE_SIZE).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferRedSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_RED_SIZE).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferAlphaSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_ALPHA_SIZE).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferDepthSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_DEPTH_SIZE).Int()
+}
+
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferStencilSize(target types.GLEnum) int {
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_STENCIL_SIZE).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetRenderbufferParameterRenderBufferSamples(target types.GLEnum) int {
+	if !c.requireWebGL2("GetRenderbufferParameterRenderBufferSamples") {
+		return 0
+	}
+	return c.GetRenderbufferParameter(target, RENDERBUFFER_SAMPLES).Int()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameter(sampler *types.Sampler, pName types.GLEnum) backend.Value {
+	if !c.requireWebGL2("GetSamplerParameter") {
+		return backend.Null()
+	}
+	return c.backend.Call("getSamplerParameter", sampler, pName)
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferWidth(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_WIDTH).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterMagFilter(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterMagFilter") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_MAG_FILTER).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferHeight(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_HEIGHT).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterMinFilter(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterMinFilter") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_MIN_FILTER).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferInternalFormat(target types.GLEnum) types.GLEnum {
-	return types.GLEnum(c.GetRenderbufferParameter(target, RENDERBUFFER_INTERNAL_FORMAT).Int())
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterWrapS(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterWrapS") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_WRAP_S).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferGreenSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_GREEN_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterWrapT(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterWrapT") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_WRAP_T).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferBlueSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_BLUE_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterWrapR(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterWrapR") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_WRAP_R).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferRedSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_RED_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterCompareFunc(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterCompareFunc") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_COMPARE_FUNC).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferAlphaSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_ALPHA_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterCompareMode(sampler *types.Sampler) types.GLEnum {
+	if !c.requireWebGL2("GetSamplerParameterCompareMode") {
+		return 0
+	}
+	return types.GLEnum(c.GetSamplerParameter(sampler, TEXTURE_COMPARE_MODE).Int())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferDepthSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_DEPTH_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterMaxLOD(sampler *types.Sampler) float32 {
+	if !c.requireWebGL2("GetSamplerParameterMaxLOD") {
+		return 0
+	}
+	return float32(c.GetSamplerParameter(sampler, TEXTURE_MAX_LOD).Float())
 }
 
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferStencilSize(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_STENCIL_SIZE).Int()
+// WebGL 2.0
+func (c *RenderingContext) GetSamplerParameterMinLOD(sampler *types.Sampler) float32 {
+	if !c.requireWebGL2("GetSamplerParameterMinLOD") {
+		return 0
+	}
+	return float32(c.GetSamplerParameter(sampler, TEXTURE_MIN_LOD).Float())
 }
 
 // WebGL 2.0
-func (c *RenderingContext) GetRenderbufferParameterRenderBufferSamples(target types.GLEnum) int {
-	return c.GetRenderbufferParameter(target, RENDERBUFFER_SAMPLES).Int()
+func (c *RenderingContext) GetSamplerParameterMaxAnisotropyExt(sampler *types.Sampler) float32 {
+	if !c.requireWebGL2("GetSamplerParameterMaxAnisotropyExt") {
+		return 0
+	}
+	return float32(c.GetSamplerParameter(sampler, extensions.TEXTURE_MAX_ANISOTROPY_EXT).Float())
 }
 
 func (c *RenderingContext) GetShaderInfoLog(shader *types.Shader) string {
-	return c.js.Call("getShaderInfoLog", shader.GetJs()).String()
+	return c.backend.Call("getShaderInfoLog", shader).String()
 }
 
-func (c *RenderingContext) GetShaderParameter(shader *types.Shader, pName types.GLEnum) js.Value {
-	return c.js.Call("getShaderParameter", shader.GetJs(), uint32(pName))
+func (c *RenderingContext) GetShaderParameter(shader *types.Shader, pName types.GLEnum) backend.Value {
+	return c.backend.Call("getShaderParameter", shader, pName)
 }
 
 func (c *RenderingContext) GetShaderParameterDeleteStatus(shader *types.Shader) bool {
@@ -1038,7 +2113,7 @@ func (c *RenderingContext) GetShaderParameterShaderType(shader *types.Shader) ty
 }
 
 func (c *RenderingContext) GetShaderPrecisionFormat(shaderType types.GLEnum, precisionType types.GLEnum) *types.ShaderPrecisionFormat {
-	pFormatJs := c.js.Call("getShaderPrecisionFormat", uint32(shaderType), uint32(precisionType))
+	pFormatJs := c.backend.Call("getShaderPrecisionFormat", shaderType, precisionType)
 	return types.NewShaderPrecisionFormat(
 		pFormatJs.Get("rangeMin").Int(),
 		pFormatJs.Get("rangeMax").Int(),
@@ -1047,11 +2122,11 @@ func (c *RenderingContext) GetShaderPrecisionFormat(shaderType types.GLEnum, pre
 }
 
 func (c *RenderingContext) GetShaderSource(shader *types.Shader) string {
-	return c.js.Call("getShaderSource", shader.GetJs()).String()
+	return c.backend.Call("getShaderSource", shader).String()
 }
 
 func (c *RenderingContext) GetSupportedExtensions() []extensions.Name {
-	arrJs := c.js.Call("getSupportedExtensions")
+	arrJs := c.backend.Call("getSupportedExtensions")
 	arr := make([]extensions.Name, arrJs.Length())
 	for i := 0; i < arrJs.Length(); i++ {
 		arr[i] = extensions.Name(arrJs.Index(i).String())
@@ -1059,8 +2134,25 @@ func (c *RenderingContext) GetSupportedExtensions() []extensions.Name {
 	return arr
 }
 
-func (c *RenderingContext) GetTexParameter(target types.GLEnum, pName types.GLEnum) js.Value {
-	return c.js.Call("getTexParameter", uint32(target), uint32(pName))
+// WebGL 2.0
+func (c *RenderingContext) GetSyncParameter(sync *types.Sync, pName types.GLEnum) backend.Value {
+	if !c.requireWebGL2("GetSyncParameter") {
+		return backend.Null()
+	}
+	return c.backend.Call("getSyncParameter", sync, pName)
+}
+
+// WebGL 2.0
+// Returns SIGNALED or UNSIGNALED
+func (c *RenderingContext) GetSyncParameterStatus(sync *types.Sync) types.GLEnum {
+	if !c.requireWebGL2("GetSyncParameterStatus") {
+		return 0
+	}
+	return types.GLEnum(c.GetSyncParameter(sync, SYNC_STATUS).Int())
+}
+
+func (c *RenderingContext) GetTexParameter(target types.GLEnum, pName types.GLEnum) backend.Value {
+	return c.backend.Call("getTexParameter", target, pName)
 }
 
 func (c *RenderingContext) GetTexParameterMagFilter(target types.GLEnum) types.GLEnum {
@@ -1085,87 +2177,156 @@ func (c *RenderingContext) GetTexParameterMaxAnisotropyExt(target types.GLEnum)
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterBaseLevel(target types.GLEnum) int {
+	if !c.requireWebGL2("GetTexParameterBaseLevel") {
+		return 0
+	}
 	return c.GetTexParameter(target, TEXTURE_BASE_LEVEL).Int()
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterCompareFunc(target types.GLEnum) types.GLEnum {
+	if !c.requireWebGL2("GetTexParameterCompareFunc") {
+		return 0
+	}
 	return types.GLEnum(c.GetTexParameter(target, TEXTURE_COMPARE_FUNC).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterCompareMode(target types.GLEnum) types.GLEnum {
+	if !c.requireWebGL2("GetTexParameterCompareMode") {
+		return 0
+	}
 	return types.GLEnum(c.GetTexParameter(target, TEXTURE_COMPARE_MODE).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterImmutableFormat(target types.GLEnum) bool {
+	if !c.requireWebGL2("GetTexParameterImmutableFormat") {
+		return false
+	}
 	return c.GetTexParameter(target, TEXTURE_IMMUTABLE_FORMAT).Bool()
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterImmutableLevels(target types.GLEnum) uint {
+	if !c.requireWebGL2("GetTexParameterImmutableLevels") {
+		return 0
+	}
 	return uint(c.GetTexParameter(target, TEXTURE_IMMUTABLE_LEVELS).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterMaxLever(target types.GLEnum) int {
+	if !c.requireWebGL2("GetTexParameterMaxLever") {
+		return 0
+	}
 	return c.GetTexParameter(target, TEXTURE_MAX_LEVEL).Int()
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterMaxLOD(target types.GLEnum) float32 {
+	if !c.requireWebGL2("GetTexParameterMaxLOD") {
+		return 0
+	}
 	return float32(c.GetTexParameter(target, TEXTURE_MAX_LOD).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterMinLOD(target types.GLEnum) float32 {
+	if !c.requireWebGL2("GetTexParameterMinLOD") {
+		return 0
+	}
 	return float32(c.GetTexParameter(target, TEXTURE_MIN_LOD).Int())
 }
 
 // WebGL 2.0
 func (c *RenderingContext) GetTexParameterWrapR(target types.GLEnum) types.GLEnum {
+	if !c.requireWebGL2("GetTexParameterWrapR") {
+		return 0
+	}
 	return types.GLEnum(c.GetTexParameter(target, TEXTURE_WRAP_R).Int())
 }
 
-func (c *RenderingContext) GetUniform(program *types.Program, location *types.UniformLocation) js.Value {
-	return c.js.Call("getUniform", program.GetJs(), location.GetJs())
+// WebGL 2.0
+func (c *RenderingContext) GetTransformFeedbackVarying(program *types.Program, index uint) *types.ActiveInfo {
+	if !c.requireWebGL2("GetTransformFeedbackVarying") {
+		return nil
+	}
+	info := c.backend.Call("getTransformFeedbackVarying", program, index)
+	return types.NewActiveInfo(
+		info.Get("name").String(),
+		info.Get("size").Int(),
+		types.GLEnum(info.Get("type").Int()),
+	)
+}
+
+func (c *RenderingContext) GetUniform(program *types.Program, location *types.UniformLocation) backend.Value {
+	return c.backend.Call("getUniform", program, location)
+}
+
+// WebGL 2.0
+// Returns INVALID_INDEX when the program has no active block with that name
+func (c *RenderingContext) GetUniformBlockIndex(program *types.Program, uniformBlockName string) uint {
+	if !c.requireWebGL2("GetUniformBlockIndex") {
+		return uint(INVALID_INDEX)
+	}
+	return uint(c.backend.Call("getUniformBlockIndex", program, uniformBlockName).Int())
+}
+
+// WebGL 2.0
+// Inactive uniforms get INVALID_INDEX
+func (c *RenderingContext) GetUniformIndices(program *types.Program, uniformNames []string) []uint {
+	if !c.requireWebGL2("GetUniformIndices") {
+		return nil
+	}
+	namesJs := make([]interface{}, len(uniformNames))
+	for i, name := range uniformNames {
+		namesJs[i] = name
+	}
+	arrJs := c.backend.Call("getUniformIndices", program, namesJs)
+	arr := make([]uint, arrJs.Length())
+	for i := 0; i < arrJs.Length(); i++ {
+		arr[i] = uint(arrJs.Index(i).Int())
+	}
+	return arr
 }
 
 func (c *RenderingContext) GetUniformLocation(program *types.Program, name string) *types.UniformLocation {
-	return types.NewUniformLocation(c.js.Call("getUniformLocation", program.GetJs(), name))
+	location := types.NewUniformLocation(c.backend.Call("getUniformLocation", program, name))
+	c.adopt(&location.Object)
+	return location
 }
 
-func (c *RenderingContext) GetVertexAttrib(index int, pName types.GLEnum) js.Value {
-	return c.js.Call("getVertexAttrib", index, uint32(pName))
+func (c *RenderingContext) GetVertexAttrib(index int, pName types.GLEnum) backend.Value {
+	return c.backend.Call("getVertexAttrib", index, pName)
 }
 
 func (c *RenderingContext) GetVertexAttribArrayBufferBinding(index int) *types.Buffer {
-	return types.NewBuffer(c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_BUFFER_BINDING))
+	return types.NewBuffer(c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_BUFFER_BINDING))
 }
 
 func (c *RenderingContext) GetVertexAttribArrayBufferEnabled(index int) bool {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_ENABLED).Bool()
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_ENABLED).Bool()
 }
 
 func (c *RenderingContext) GetVertexAttribArraySize(index int) int {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_SIZE).Int()
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_SIZE).Int()
 }
 
 func (c *RenderingContext) GetVertexAttribArrayStride(index int) int {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_STRIDE).Int()
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_STRIDE).Int()
 }
 
 func (c *RenderingContext) GetVertexAttribArrayType(index int) types.GLEnum {
-	return types.GLEnum(c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_TYPE).Int())
+	return types.GLEnum(c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_TYPE).Int())
 }
 
 func (c *RenderingContext) GetVertexAttribArrayNormalized(index int) bool {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_NORMALIZED).Bool()
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_NORMALIZED).Bool()
 }
 
 func (c *RenderingContext) GetVertexAttribCurrentVertexAttrib(index int) [4]float32 {
-	arrJs := c.js.Call("getVertexAttrib", index, CURRENT_VERTEX_ATTRIB)
+	arrJs := c.backend.Call("getVertexAttrib", index, CURRENT_VERTEX_ATTRIB)
 	var arr [4]float32
 	arr[0] = float32(arrJs.Index(0).Float())
 	arr[1] = float32(arrJs.Index(1).Float())
@@ -1176,189 +2337,451 @@ func (c *RenderingContext) GetVertexAttribCurrentVertexAttrib(index int) [4]floa
 
 // WebGL 2.0
 func (c *RenderingContext) GetVertexAttribArrayInteger(index int) bool {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_INTEGER).Bool()
+	if !c.requireWebGL2("GetVertexAttribArrayInteger") {
+		return false
+	}
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_INTEGER).Bool()
 }
 
 func (c *RenderingContext) GetVertexAttribArrayDivisor(index int) int {
-	return c.js.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_DIVISOR).Int()
+	return c.backend.Call("getVertexAttrib", index, VERTEX_ATTRIB_ARRAY_DIVISOR).Int()
 }
 
 func (c *RenderingContext) GetVertexAttribArrayDivisorAngle(index int) int {
-	return c.js.Call("getVertexAttrib", index, extensions.VERTEX_ATTRIB_ARRAY_DIVISOR_ANGLE).Int()
+	return c.backend.Call("getVertexAttrib", index, extensions.VERTEX_ATTRIB_ARRAY_DIVISOR_ANGLE).Int()
 }
 
 func (c *RenderingContext) GetVertexAttribOffset(index int, pName types.GLEnum) int {
-	return c.js.Call("getVertexAttribOffset", index, uint32(pName)).Int()
+	return c.backend.Call("getVertexAttribOffset", index, pName).Int()
 }
 
 func (c *RenderingContext) Hint(target types.GLEnum, mode types.GLEnum) {
-	c.js.Call("hint", uint32(target), uint32(mode))
+	c.backend.Call("hint", target, mode)
+}
+
+// WebGL 2.0
+// Tells the browser the attachments content is no longer needed, which saves bandwidth on tiled GPUs
+func (c *RenderingContext) InvalidateFrameBuffer(target types.GLEnum, attachments []types.GLEnum) {
+	if !c.requireWebGL2("InvalidateFrameBuffer") {
+		return
+	}
+	c.backend.Call("invalidateFramebuffer", target, attachments)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) InvalidateSubFrameBuffer(target types.GLEnum, attachments []types.GLEnum, x, y int, width, height int) {
+	if !c.requireWebGL2("InvalidateSubFrameBuffer") {
+		return
+	}
+	c.backend.Call("invalidateSubFramebuffer", target, attachments, x, y, width, height)
 }
 
-func (c *RenderingContext) IsBuffer(buffer js.Value) bool {
-	return c.js.Call("isBuffer", buffer).Bool()
+func (c *RenderingContext) IsBuffer(buffer *types.Buffer) bool {
+	return c.backend.Call("isBuffer", buffer).Bool()
 }
 
 func (c *RenderingContext) IsContextLost() bool {
-	return c.js.Call("isContextLost").Bool()
+	return c.backend.Call("isContextLost").Bool()
 }
 
 func (c *RenderingContext) IsEnabled(cap types.GLEnum) bool {
-	return c.js.Call("isEnabled", uint32(cap)).Bool()
+	return c.backend.Call("isEnabled", cap).Bool()
+}
+
+func (c *RenderingContext) IsFrameBuffer(framebuffer *types.FrameBuffer) bool {
+	return c.backend.Call("isFramebuffer", framebuffer).Bool()
+}
+
+func (c *RenderingContext) IsProgram(program *types.Program) bool {
+	return c.backend.Call("isProgram", program).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) IsQuery(query *types.Query) bool {
+	if !c.requireWebGL2("IsQuery") {
+		return false
+	}
+	return c.backend.Call("isQuery", query).Bool()
+}
+
+func (c *RenderingContext) IsRenderbuffer(renderbuffer *types.RenderBuffer) bool {
+	return c.backend.Call("isRenderbuffer", renderbuffer).Bool()
+}
+
+// WebGL 2.0
+func (c *RenderingContext) IsSampler(sampler *types.Sampler) bool {
+	if !c.requireWebGL2("IsSampler") {
+		return false
+	}
+	return c.backend.Call("isSampler", sampler).Bool()
 }
 
-func (c *RenderingContext) IsFrameBuffer(framebuffer js.Value) bool {
-	return c.js.Call("isFramebuffer", framebuffer).Bool()
+func (c *RenderingContext) IsShader(shader *types.Shader) bool {
+	return c.backend.Call("isShader", shader).Bool()
 }
 
-func (c *RenderingContext) IsProgram(program js.Value) bool {
-	return c.js.Call("isProgram", program).Bool()
+// WebGL 2.0
+func (c *RenderingContext) IsSync(sync *types.Sync) bool {
+	if !c.requireWebGL2("IsSync") {
+		return false
+	}
+	return c.backend.Call("isSync", sync).Bool()
 }
 
-func (c *RenderingContext) IsRenderbuffer(renderbuffer js.Value) bool {
-	return c.js.Call("isRenderbuffer", renderbuffer).Bool()
+func (c *RenderingContext) IsTexture(texture *types.Texture) bool {
+	return c.backend.Call("isTexture", texture).Bool()
 }
 
-func (c *RenderingContext) IsShader(shader js.Value) bool {
-	return c.js.Call("isShader", shader).Bool()
+// WebGL 2.0
+func (c *RenderingContext) IsTransformFeedback(transformFeedback *types.TransformFeedback) bool {
+	if !c.requireWebGL2("IsTransformFeedback") {
+		return false
+	}
+	return c.backend.Call("isTransformFeedback", transformFeedback).Bool()
 }
 
-func (c *RenderingContext) IsTexture(texture js.Value) bool {
-	return c.js.Call("isTexture", texture).Bool()
+// Uses the native WebGL 2.0 call or the OES_vertex_array_object extension
+func (c *RenderingContext) IsVertexArray(vertexArray *types.VertexArray) bool {
+	if c.IsWebGL2() {
+		return c.backend.Call("isVertexArray", vertexArray).Bool()
+	} else if ext := c.vertexArrayObject("IsVertexArray"); ext != nil {
+		return ext.IsVertexArrayOES(vertexArray)
+	}
+	return false
 }
 
 // Deprecated: Most browsers only support 1.0 value
 func (c *RenderingContext) LineWidth(width float32) {
-	c.js.Call("lineWidth", width)
+	c.backend.Call("lineWidth", width)
 }
 
 func (c *RenderingContext) LinkProgram(program *types.Program) {
-	c.js.Call("linkProgram", program.GetJs())
+	c.backend.Call("linkProgram", program)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) PauseTransformFeedback() {
+	if !c.requireWebGL2("PauseTransformFeedback") {
+		return
+	}
+	c.backend.Call("pauseTransformFeedback")
 }
 
 func (c *RenderingContext) PixelStorei(pName types.GLEnum, param int) {
-	c.js.Call("pixelStorei", uint32(pName), param)
+	c.backend.Call("pixelStorei", pName, param)
 }
 
 func (c *RenderingContext) PolygonOffset(factor float32, units float32) {
-	c.js.Call("polygonOffset", factor, units)
+	c.appliedState = nil
+	c.backend.Call("polygonOffset", factor, units)
+}
+
+// WebGL 2.0
+// Selects the color buffer used by ReadPixels, CopyTexImage2D and as BlitFramebuffer source
+func (c *RenderingContext) ReadBuffer(src types.GLEnum) {
+	if !c.requireWebGL2("ReadBuffer") {
+		return
+	}
+	c.backend.Call("readBuffer", src)
 }
 
-func (c *RenderingContext) ReadPixels(x, y int, width, height int, format types.GLEnum, pixels js.TypedArray) {
-	c.js.Call("readPixels", x, y, width, height, uint32(format), pixels)
+// Reads a block of pixels of UNSIGNED_BYTE or implementation chosen data type into pixels
+func (c *RenderingContext) ReadPixels(x, y int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels []byte) {
+	c.backend.Call("readPixels", x, y, width, height, format, dataType, pixels)
+}
+
+func (c *RenderingContext) ReadPixelsf(x, y int, width, height int, format types.GLEnum, pixels []float32) {
+	c.backend.Call("readPixels", x, y, width, height, format, FLOAT, pixels)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) ReadPixelsOffset(x, y int, width, height int, format types.GLEnum, pixels js.TypedArray, dstOffset uint) {
-	c.js.Call("readPixels", x, y, width, height, uint32(format), pixels, dstOffset)
+func (c *RenderingContext) ReadPixelsOffset(x, y int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels []byte, dstOffset uint) {
+	if !c.requireWebGL2("ReadPixelsOffset") {
+		return
+	}
+	c.backend.Call("readPixels", x, y, width, height, format, dataType, pixels, dstOffset)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) ReadPixelsOffsetPointer(x, y int, width, height int, format types.GLEnum, offset int) {
-	c.js.Call("readPixels", x, y, width, height, uint32(format), offset)
+// Reads into the buffer bound to PIXEL_PACK_BUFFER, starting at offset bytes
+func (c *RenderingContext) ReadPixelsOffsetPointer(x, y int, width, height int, format types.GLEnum, dataType types.GLEnum, offset int) {
+	if !c.requireWebGL2("ReadPixelsOffsetPointer") {
+		return
+	}
+	c.backend.Call("readPixels", x, y, width, height, format, dataType, offset)
 }
 
 func (c *RenderingContext) RenderbufferStorage(target types.GLEnum, internalFormat types.GLEnum, width, height int) {
-	c.js.Call("renderbufferStorage", uint32(target), uint32(internalFormat), width, height)
+	c.backend.Call("renderbufferStorage", target, internalFormat, width, height)
+	c.memory.specifyRenderbuffer(internalFormat, 1, width, height)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) RenderbufferStorageMultisample(target types.GLEnum, samples int, internalFormat types.GLEnum, width, height int) {
+	if !c.requireWebGL2("RenderbufferStorageMultisample") {
+		return
+	}
+	c.backend.Call("renderbufferStorageMultisample", target, samples, internalFormat, width, height)
+	c.memory.specifyRenderbuffer(internalFormat, samples, width, height)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) ResumeTransformFeedback() {
+	if !c.requireWebGL2("ResumeTransformFeedback") {
+		return
+	}
+	c.backend.Call("resumeTransformFeedback")
 }
 
 func (c *RenderingContext) SampleCoverage(value float32, invert bool) {
-	c.js.Call("sampleCoverage", value, invert)
+	c.backend.Call("sampleCoverage", value, invert)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterf(sampler *types.Sampler, pName types.GLEnum, param float32) {
+	if !c.requireWebGL2("SamplerParameterf") {
+		return
+	}
+	c.backend.Call("samplerParameterf", sampler, pName, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameteri(sampler *types.Sampler, pName types.GLEnum, param int) {
+	if !c.requireWebGL2("SamplerParameteri") {
+		return
+	}
+	c.backend.Call("samplerParameteri", sampler, pName, param)
+}
+
+// Sets a parameter for a typed setter, method names the setter in the VersionError
+func (c *RenderingContext) samplerParameterEnum(method string, sampler *types.Sampler, pName types.GLEnum, param types.GLEnum) {
+	if !c.requireWebGL2(method) {
+		return
+	}
+	c.backend.Call("samplerParameteri", sampler, pName, param)
+}
+
+func (c *RenderingContext) samplerParameterFloat(method string, sampler *types.Sampler, pName types.GLEnum, param float32) {
+	if !c.requireWebGL2(method) {
+		return
+	}
+	c.backend.Call("samplerParameterf", sampler, pName, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterMagFilter(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterMagFilter", sampler, TEXTURE_MAG_FILTER, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterMinFilter(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterMinFilter", sampler, TEXTURE_MIN_FILTER, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterWrapS(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterWrapS", sampler, TEXTURE_WRAP_S, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterWrapT(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterWrapT", sampler, TEXTURE_WRAP_T, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterWrapR(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterWrapR", sampler, TEXTURE_WRAP_R, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterCompareFunc(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterCompareFunc", sampler, TEXTURE_COMPARE_FUNC, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterCompareMode(sampler *types.Sampler, param types.GLEnum) {
+	c.samplerParameterEnum("SamplerParameterCompareMode", sampler, TEXTURE_COMPARE_MODE, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterMaxLOD(sampler *types.Sampler, param float32) {
+	c.samplerParameterFloat("SamplerParameterMaxLOD", sampler, TEXTURE_MAX_LOD, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterMinLOD(sampler *types.Sampler, param float32) {
+	c.samplerParameterFloat("SamplerParameterMinLOD", sampler, TEXTURE_MIN_LOD, param)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) SamplerParameterMaxAnisotropyExt(sampler *types.Sampler, param float32) {
+	c.samplerParameterFloat("SamplerParameterMaxAnisotropyExt", sampler, extensions.TEXTURE_MAX_ANISOTROPY_EXT, param)
 }
 
 func (c *RenderingContext) Scissor(x int, y int, width int, height int) {
-	c.js.Call("scissor", x, y, width, height)
+	c.backend.Call("scissor", x, y, width, height)
 }
 
 func (c *RenderingContext) ShaderSource(shader *types.Shader, source string) {
-	c.js.Call("shaderSource", shader.GetJs(), source)
+	c.backend.Call("shaderSource", shader, source)
 }
 
 func (c *RenderingContext) StencilFunc(function types.GLEnum, ref int, mask uint32) {
-	c.js.Call("stencilFunc", uint32(function), ref, mask)
+	c.appliedState = nil
+	c.backend.Call("stencilFunc", function, ref, mask)
 }
 
 func (c *RenderingContext) StencilFuncSeparate(face types.GLEnum, function types.GLEnum, ref int, mask uint32) {
-	c.js.Call("stencilFuncSeparate", uint32(face), function, ref, mask)
+	c.appliedState = nil
+	c.backend.Call("stencilFuncSeparate", face, function, ref, mask)
 }
 
 func (c *RenderingContext) StencilMask(mask uint32) {
-	c.js.Call("stencilMask", mask)
+	c.appliedState = nil
+	c.backend.Call("stencilMask", mask)
 }
 
 func (c *RenderingContext) StencilMaskSeparate(face types.GLEnum, mask uint32) {
-	c.js.Call("stencilMaskSeparate", uint32(face), mask)
+	c.appliedState = nil
+	c.backend.Call("stencilMaskSeparate", face, mask)
 }
 
 func (c *RenderingContext) StencilOp(fail types.GLEnum, zFail types.GLEnum, zPass types.GLEnum) {
-	c.js.Call("stencilOp", uint32(fail), uint32(zFail), zPass)
+	c.appliedState = nil
+	c.backend.Call("stencilOp", fail, zFail, zPass)
 }
 
 func (c *RenderingContext) StencilOpSeparate(face types.GLEnum, fail types.GLEnum, zFail types.GLEnum, zPass types.GLEnum) {
-	c.js.Call("stencilOpSeparate", face, uint32(fail), uint32(zFail), zPass)
+	c.appliedState = nil
+	c.backend.Call("stencilOpSeparate", face, fail, zFail, zPass)
 }
 
 func (c *RenderingContext) TexImage2Db(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, pixels []byte) {
 	if pixels == nil {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(UNSIGNED_BYTE), js.Null())
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, UNSIGNED_BYTE, nil)
 	} else {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(UNSIGNED_BYTE), js.TypedArrayOf(pixels))
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, UNSIGNED_BYTE, pixels)
 	}
+	c.memory.specifyImage(target, level, internalFormat, UNSIGNED_BYTE, width, height, 1)
 }
 
 func (c *RenderingContext) TexImage2Dui16(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, pixels []uint16) {
 	if pixels == nil {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), js.Null())
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, nil)
 	} else {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), js.TypedArrayOf(pixels))
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, pixels)
 	}
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
 }
 
 func (c *RenderingContext) TexImage2Dui32(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, pixels []uint32) {
 	if pixels == nil {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), js.Null())
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, nil)
 	} else {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), js.TypedArrayOf(pixels))
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, pixels)
 	}
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
 }
 
 func (c *RenderingContext) TexImage2Df(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, pixels []float32) {
 	if pixels == nil {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(FLOAT), js.Null())
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, FLOAT, nil)
 	} else {
-		c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(FLOAT), js.TypedArrayOf(pixels))
+		c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, FLOAT, pixels)
 	}
+	c.memory.specifyImage(target, level, internalFormat, FLOAT, width, height, 1)
 }
 
-func (c *RenderingContext) TexImage2DHtmlElement(target types.GLEnum, level int, internalFormat types.GLEnum, format types.GLEnum, dataType types.GLEnum, pixels js.Value) {
-	c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), uint32(format), uint32(dataType), pixels)
+// WebGL 2.0
+func (c *RenderingContext) TexImage2DOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, offset int) {
+	if !c.requireWebGL2("TexImage2DOffset") {
+		return
+	}
+	c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, offset)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) TexImage2DOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, offset int) {
-	c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), offset)
+func (c *RenderingContext) TexImage2D2(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, srcData []float32, srcOffset int) {
+	if !c.requireWebGL2("TexImage2D2") {
+		return
+	}
+	c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, srcData, srcOffset)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) TexImage2DHtmlElement2(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
-	c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), source)
+func (c *RenderingContext) TexImage3Db(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, pixels []byte) {
+	if !c.requireWebGL2("TexImage3Db") {
+		return
+	}
+	if pixels == nil {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, UNSIGNED_BYTE, nil)
+	} else {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, UNSIGNED_BYTE, pixels)
+	}
+	c.memory.specifyImage(target, level, internalFormat, UNSIGNED_BYTE, width, height, depth)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) TexImage2D2(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, srcData []float32, srcOffset int) {
-	c.js.Call("texImage2D", uint32(target), level, uint32(internalFormat), width, height, border, uint32(format), uint32(dataType), js.TypedArrayOf(srcData), srcOffset)
+func (c *RenderingContext) TexImage3Dui16(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, dataType types.GLEnum, pixels []uint16) {
+	if !c.requireWebGL2("TexImage3Dui16") {
+		return
+	}
+	if pixels == nil {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, nil)
+	} else {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, pixels)
+	}
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, depth)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexImage3Dui32(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, dataType types.GLEnum, pixels []uint32) {
+	if !c.requireWebGL2("TexImage3Dui32") {
+		return
+	}
+	if pixels == nil {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, nil)
+	} else {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, pixels)
+	}
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, depth)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexImage3Df(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, pixels []float32) {
+	if !c.requireWebGL2("TexImage3Df") {
+		return
+	}
+	if pixels == nil {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, FLOAT, nil)
+	} else {
+		c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, FLOAT, pixels)
+	}
+	c.memory.specifyImage(target, level, internalFormat, FLOAT, width, height, depth)
+}
+
+// WebGL 2.0
+// Reads the pixels from the buffer bound to PIXEL_UNPACK_BUFFER, starting at offset bytes
+func (c *RenderingContext) TexImage3DOffset(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, dataType types.GLEnum, offset int) {
+	if !c.requireWebGL2("TexImage3DOffset") {
+		return
+	}
+	c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, offset)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, depth)
 }
 
 func (c *RenderingContext) TexParameterf(target types.GLEnum, pName types.GLEnum, param float32) {
-	c.js.Call("texParameterf", uint32(target), uint32(pName), param)
+	c.backend.Call("texParameterf", target, pName, param)
 }
 
 func (c *RenderingContext) TexParameteri(target types.GLEnum, pName types.GLEnum, param int) {
-	c.js.Call("texParameteri", uint32(target), uint32(pName), param)
+	c.backend.Call("texParameteri", target, pName, param)
 }
 
 func (c *RenderingContext) texParameterEnum(target types.GLEnum, pName types.GLEnum, param types.GLEnum) {
-	c.js.Call("texParameteri", uint32(target), uint32(pName), uint32(param))
+	c.backend.Call("texParameteri", target, pName, param)
 }
 
 func (c *RenderingContext) TexParameterMagFilter(target types.GLEnum, param types.GLEnum) {
@@ -1409,149 +2832,259 @@ func (c *RenderingContext) TexParameterWrapR(target types.GLEnum, param types.GL
 	c.texParameterEnum(target, TEXTURE_WRAP_R, param)
 }
 
-func (c *RenderingContext) TexSubImage2D(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels js.TypedArray) {
-	c.js.Call("texSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), uint32(dataType), pixels)
+// WebGL 2.0
+// Allocates immutable storage for every level of a 2D or cube map texture
+func (c *RenderingContext) TexStorage2D(target types.GLEnum, levels int, internalFormat types.GLEnum, width, height int) {
+	if !c.requireWebGL2("TexStorage2D") {
+		return
+	}
+	c.backend.Call("texStorage2D", target, levels, internalFormat, width, height)
+	c.memory.specifyStorage(target, levels, internalFormat, width, height, 1)
+}
+
+// WebGL 2.0
+// Allocates immutable storage for every level of a 3D or 2D array texture
+func (c *RenderingContext) TexStorage3D(target types.GLEnum, levels int, internalFormat types.GLEnum, width, height, depth int) {
+	if !c.requireWebGL2("TexStorage3D") {
+		return
+	}
+	c.backend.Call("texStorage3D", target, levels, internalFormat, width, height, depth)
+	c.memory.specifyStorage(target, levels, internalFormat, width, height, depth)
+}
+
+func (c *RenderingContext) TexSubImage2D(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels []byte) {
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, dataType, pixels)
+}
+
+func (c *RenderingContext) TexSubImage2Dui16(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels []uint16) {
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, dataType, pixels)
+}
+
+func (c *RenderingContext) TexSubImage2Dui32(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, pixels []uint32) {
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, dataType, pixels)
 }
 
-func (c *RenderingContext) TexSubImage2DHtmlElement(target types.GLEnum, level int, xOffset, yOffset int, format types.GLEnum, dataType types.GLEnum, pixels js.Value) {
-	c.js.Call("texSubImage2D", uint32(target), level, xOffset, yOffset, uint32(format), uint32(dataType), pixels)
+func (c *RenderingContext) TexSubImage2Df(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, pixels []float32) {
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, FLOAT, pixels)
 }
 
 // WebGL 2.0
 func (c *RenderingContext) TexSubImage2DOffset(target types.GLEnum, level int, xOffset, yOffset int, format types.GLEnum, dataType types.GLEnum, offset int) {
-	c.js.Call("texSubImage2D", uint32(target), level, xOffset, yOffset, uint32(format), uint32(dataType), offset)
+	if !c.requireWebGL2("TexSubImage2DOffset") {
+		return
+	}
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, format, dataType, offset)
 }
 
 // WebGL 2.0
 func (c *RenderingContext) TexSubImage2DOffset2(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, offset int) {
-	c.js.Call("texSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), uint32(dataType), offset)
+	if !c.requireWebGL2("TexSubImage2DOffset2") {
+		return
+	}
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, dataType, offset)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage3Db(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, pixels []byte) {
+	if !c.requireWebGL2("TexSubImage3Db") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, UNSIGNED_BYTE, pixels)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage3Dui16(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, dataType types.GLEnum, pixels []uint16) {
+	if !c.requireWebGL2("TexSubImage3Dui16") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, dataType, pixels)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage3Dui32(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, dataType types.GLEnum, pixels []uint32) {
+	if !c.requireWebGL2("TexSubImage3Dui32") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, dataType, pixels)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage3Df(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, pixels []float32) {
+	if !c.requireWebGL2("TexSubImage3Df") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, FLOAT, pixels)
+}
+
+// WebGL 2.0
+// Reads the pixels from the buffer bound to PIXEL_UNPACK_BUFFER, starting at offset bytes
+func (c *RenderingContext) TexSubImage3DOffset(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, dataType types.GLEnum, offset int) {
+	if !c.requireWebGL2("TexSubImage3DOffset") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, dataType, offset)
 }
 
 // WebGL 2.0
-func (c *RenderingContext) TexSubImage2DHtmlElement2(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
-	c.js.Call("texSubImage2D", uint32(target), level, xOffset, yOffset, width, height, uint32(format), uint32(dataType), source)
+// Must be called before LinkProgram, bufferMode is INTERLEAVED_ATTRIBS or SEPARATE_ATTRIBS
+func (c *RenderingContext) TransformFeedbackVaryings(program *types.Program, varyings []string, bufferMode types.GLEnum) {
+	if !c.requireWebGL2("TransformFeedbackVaryings") {
+		return
+	}
+	varyingsJs := make([]interface{}, len(varyings))
+	for i, varying := range varyings {
+		varyingsJs[i] = varying
+	}
+	c.backend.Call("transformFeedbackVaryings", program, varyingsJs, bufferMode)
 }
 
 func (c *RenderingContext) Uniform1f(location *types.UniformLocation, v0 float32) {
-	c.js.Call("uniform1f", location.GetJs(), v0)
+	c.backend.Call("uniform1f", location, v0)
 }
 
 func (c *RenderingContext) Uniform1fv(location *types.UniformLocation, value []float32) {
-	c.js.Call("uniform1fv", location.GetJs(), js.TypedArrayOf(value))
+	c.backend.Call("uniform1fv", location, value)
 }
 
 func (c *RenderingContext) Uniform1i(location *types.UniformLocation, v0 int) {
-	c.js.Call("uniform1i", location.GetJs(), v0)
+	c.backend.Call("uniform1i", location, v0)
 }
 
 func (c *RenderingContext) Uniform1iv(location *types.UniformLocation, value []int) {
-	c.js.Call("uniform1iv", location.GetJs(), value)
+	c.backend.Call("uniform1iv", location, value)
 }
 
 func (c *RenderingContext) Uniform2f(location *types.UniformLocation, v0 float32, v1 float32) {
-	c.js.Call("uniform2f", location.GetJs(), v0, v1)
+	c.backend.Call("uniform2f", location, v0, v1)
 }
 
 func (c *RenderingContext) Uniform2fv(location *types.UniformLocation, value []float32) {
-	c.js.Call("uniform2fv", location.GetJs(), js.TypedArrayOf(value))
+	c.backend.Call("uniform2fv", location, value)
 }
 
 func (c *RenderingContext) Uniform2i(location *types.UniformLocation, v0 int, v1 int) {
-	c.js.Call("uniform2i", location.GetJs(), v0, v1)
+	c.backend.Call("uniform2i", location, v0, v1)
 }
 
 func (c *RenderingContext) Uniform2iv(location *types.UniformLocation, value []int) {
-	c.js.Call("uniform2iv", location.GetJs(), value)
+	c.backend.Call("uniform2iv", location, value)
 }
 
 func (c *RenderingContext) Uniform3f(location *types.UniformLocation, v0 float32, v1 float32, v2 float32) {
-	c.js.Call("uniform3f", location.GetJs(), v0, v1, v2)
+	c.backend.Call("uniform3f", location, v0, v1, v2)
 }
 
 func (c *RenderingContext) Uniform3fv(location *types.UniformLocation, value []float32) {
-	c.js.Call("uniform3fv", location.GetJs(), js.TypedArrayOf(value))
+	c.backend.Call("uniform3fv", location, value)
 }
 
 func (c *RenderingContext) Uniform3i(location *types.UniformLocation, v0 int, v1 int, v2 int) {
-	c.js.Call("uniform3i", location.GetJs(), v0, v1, v2)
+	c.backend.Call("uniform3i", location, v0, v1, v2)
 }
 
 func (c *RenderingContext) Uniform3iv(location *types.UniformLocation, value []int) {
-	c.js.Call("uniform3iv", location.GetJs(), value)
+	c.backend.Call("uniform3iv", location, value)
 }
 
 func (c *RenderingContext) Uniform4f(location *types.UniformLocation, v0 float32, v1 float32, v2 float32, v3 float32) {
-	c.js.Call("uniform4f", location.GetJs(), v0, v1, v2, v3)
+	c.backend.Call("uniform4f", location, v0, v1, v2, v3)
 }
 
 func (c *RenderingContext) Uniform4fv(location *types.UniformLocation, value []float32) {
-	c.js.Call("uniform4fv", location.GetJs(), js.TypedArrayOf(value))
+	c.backend.Call("uniform4fv", location, value)
 }
 
 func (c *RenderingContext) Uniform4i(location *types.UniformLocation, v0 int, v1 int, v2 int, v3 int) {
-	c.js.Call("uniform4i", location.GetJs(), v0, v1, v2, v3)
+	c.backend.Call("uniform4i", location, v0, v1, v2, v3)
 }
 
 func (c *RenderingContext) Uniform4iv(location *types.UniformLocation, value []int) {
-	c.js.Call("uniform4iv", location.GetJs(), value)
+	c.backend.Call("uniform4iv", location, value)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) UniformBlockBinding(program *types.Program, uniformBlockIndex uint, uniformBlockBinding uint) {
+	if !c.requireWebGL2("UniformBlockBinding") {
+		return
+	}
+	c.backend.Call("uniformBlockBinding", program, uniformBlockIndex, uniformBlockBinding)
 }
 
 func (c *RenderingContext) UniformMatrix2fv(location *types.UniformLocation, transpose bool, value []float32) {
-	c.js.Call("uniformMatrix2fv", location.GetJs(), transpose, js.TypedArrayOf(value))
+	c.backend.Call("uniformMatrix2fv", location, transpose, value)
 }
 
 func (c *RenderingContext) UniformMatrix3fv(location *types.UniformLocation, transpose bool, value []float32) {
-	c.js.Call("uniformMatrix3fv", location.GetJs(), transpose, js.TypedArrayOf(value))
+	c.backend.Call("uniformMatrix3fv", location, transpose, value)
 }
 
 func (c *RenderingContext) UniformMatrix4fv(location *types.UniformLocation, transpose bool, value []float32) {
-	c.js.Call("uniformMatrix4fv", location.GetJs(), transpose, js.TypedArrayOf(value))
+	c.backend.Call("uniformMatrix4fv", location, transpose, value)
 }
 
 func (c *RenderingContext) UseProgram(program *types.Program) {
-	c.js.Call("useProgram", program.GetJs())
+	c.backend.Call("useProgram", program)
 }
 
 func (c *RenderingContext) ValidateProgram(program *types.Program) {
-	c.js.Call("validateProgram", program.GetJs())
+	c.backend.Call("validateProgram", program)
 }
 
 func (c *RenderingContext) VertexAttrib1f(index int, v0 float32) {
-	c.js.Call("vertexAttrib1f", index, v0)
+	c.backend.Call("vertexAttrib1f", index, v0)
 }
 
 func (c *RenderingContext) VertexAttrib2f(index int, v0, v1 float32) {
-	c.js.Call("vertexAttrib2f", index, v0, v1)
+	c.backend.Call("vertexAttrib2f", index, v0, v1)
 }
 
 func (c *RenderingContext) VertexAttrib3f(index int, v0, v1, v2 float32) {
-	c.js.Call("vertexAttrib3f", index, v0, v1, v2)
+	c.backend.Call("vertexAttrib3f", index, v0, v1, v2)
 }
 
 func (c *RenderingContext) VertexAttrib4f(index int, v0, v1, v2, v3 float32) {
-	c.js.Call("vertexAttrib4f", index, v0, v1, v2, v3)
+	c.backend.Call("vertexAttrib4f", index, v0, v1, v2, v3)
 }
 
 func (c *RenderingContext) VertexAttrib1fv(index int, value []float32) {
-	c.js.Call("vertexAttrib1fv", index, js.TypedArrayOf(value))
+	c.backend.Call("vertexAttrib1fv", index, value)
 }
 
 func (c *RenderingContext) VertexAttrib2fv(index int, value []float32) {
-	c.js.Call("vertexAttrib2fv", index, js.TypedArrayOf(value))
+	c.backend.Call("vertexAttrib2fv", index, value)
 }
 
 func (c *RenderingContext) VertexAttrib3fv(index int, value []float32) {
-	c.js.Call("vertexAttrib3fv", index, js.TypedArrayOf(value))
+	c.backend.Call("vertexAttrib3fv", index, value)
 }
 
 func (c *RenderingContext) VertexAttrib4fv(index int, value []float32) {
-	c.js.Call("vertexAttrib4fv", index, js.TypedArrayOf(value))
+	c.backend.Call("vertexAttrib4fv", index, value)
+}
+
+// Uses the native WebGL 2.0 call or the ANGLE_instanced_arrays extension
+func (c *RenderingContext) VertexAttribDivisor(index int, divisor int) {
+	if c.IsWebGL2() {
+		c.backend.Call("vertexAttribDivisor", index, divisor)
+	} else if ext := c.instancedArrays("VertexAttribDivisor"); ext != nil {
+		ext.VertexAttribDivisorANGLE(index, divisor)
+	}
 }
 
 func (c *RenderingContext) VertexAttribPointer(index int, size int, aType types.GLEnum, normalized bool, stride int, offset int) {
-	c.js.Call("vertexAttribPointer", index, size, uint32(aType), normalized, stride, offset)
+	c.backend.Call("vertexAttribPointer", index, size, aType, normalized, stride, offset)
+}
+
+// WebGL 2.0
+// Makes the GPU wait for the fence before executing further commands, flags must be 0 and
+// timeout -1, the WebGL value of TIMEOUT_IGNORED
+func (c *RenderingContext) WaitSync(sync *types.Sync, flags uint32, timeout int64) {
+	if !c.requireWebGL2("WaitSync") {
+		return
+	}
+	c.backend.Call("waitSync", sync, flags, timeout)
 }
 
 func (c *RenderingContext) Viewport(x int, y int, width int, height int) {
-	c.js.Call("viewport", x, y, width, height)
+	c.backend.Call("viewport", x, y, width, height)
 }
@@ -0,0 +1,161 @@
+package webgl
+
+import (
+	"errors"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"syscall/js"
+)
+
+func WrapContext(jsContext js.Value) *RenderingContext {
+	context := WrapBackend(backend.FromJs(jsContext), contextVersion(jsContext))
+	context.listenContextEvents(jsContext)
+	context.pollFencesFromEventLoop()
+	return context
+}
+
+// Wraps a context whose calls are encoded into a command buffer, replayed by FlushCommands.
+// Queries flush the buffer first, so results stay the same as with WrapContext.
+func WrapContextBatched(jsContext js.Value) *RenderingContext {
+	context := WrapBackend(backend.NewCommandBuffer(jsContext), contextVersion(jsContext))
+	context.listenContextEvents(jsContext)
+	context.pollFencesFromEventLoop()
+	return context
+}
+
+func contextVersion(jsContext js.Value) uint {
+	webgl2Class := js.Global().Get("WebGL2RenderingContext")
+	if !webgl2Class.IsUndefined() && jsContext.InstanceOf(webgl2Class) {
+		return WebGL2
+	}
+	return WebGL1
+}
+
+func FromCanvas(canvasEl js.Value) (*RenderingContext, error) {
+	jsContext := canvasEl.Call("getContext", "webgl")
+	if jsContext.IsUndefined() || jsContext.IsNull() {
+		jsContext = canvasEl.Call("getContext", "experimental-webgl")
+	}
+	if jsContext.IsUndefined() || jsContext.IsNull() {
+		return nil, errors.New("browser might not support webgl")
+	}
+	return WrapContext(jsContext), nil
+}
+
+// Creates a WebGL 2.0 context when available, falling back to WebGL 1.0 otherwise.
+// The attributes are passed to getContext, a nil value uses the browser defaults.
+func FromCanvasWithOptions(canvasEl js.Value, attributes *types.Attributes) (*RenderingContext, error) {
+	attrJs := js.Undefined()
+	if attributes != nil {
+		attrJs = attributes.ToJs()
+	}
+
+	version := WebGL2
+	jsContext := canvasEl.Call("getContext", "webgl2", attrJs)
+	if jsContext.IsUndefined() || jsContext.IsNull() {
+		version = WebGL1
+		jsContext = canvasEl.Call("getContext", "webgl", attrJs)
+	}
+	if jsContext.IsUndefined() || jsContext.IsNull() {
+		jsContext = canvasEl.Call("getContext", "experimental-webgl", attrJs)
+	}
+	if jsContext.IsUndefined() || jsContext.IsNull() {
+		return nil, errors.New("browser might not support webgl")
+	}
+
+	context := WrapContext(jsContext)
+	context.version = version
+	return context, nil
+}
+
+// Forwards the context loss events of the canvas. The default handling of the loss is prevented,
+// so the browser can restore the context. Handlers run in the event listener, between the calls of
+// the application, and must not block.
+func (c *RenderingContext) listenContextEvents(jsContext js.Value) {
+	canvas := jsContext.Get("canvas")
+	if canvas.IsUndefined() || canvas.IsNull() || canvas.Get("addEventListener").IsUndefined() {
+		return
+	}
+	lost := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		args[0].Call("preventDefault")
+		c.NotifyContextLost()
+		return nil
+	})
+	restored := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		c.NotifyContextRestored()
+		return nil
+	})
+	canvas.Call("addEventListener", "webglcontextlost", lost)
+	canvas.Call("addEventListener", "webglcontextrestored", restored)
+	c.loss.removeListeners = func() {
+		canvas.Call("removeEventListener", "webglcontextlost", lost)
+		canvas.Call("removeEventListener", "webglcontextrestored", restored)
+		lost.Release()
+		restored.Release()
+	}
+}
+
+// Returns the JavaScript context, or undefined when the context does not use the browser backend
+func (c *RenderingContext) GetJs() js.Value {
+	return backend.ToJs(backend.Root(c.backend))
+}
+
+func (c *RenderingContext) GetCanvas() js.Value {
+	return c.GetJs().Get("canvas")
+}
+
+func (c *RenderingContext) TexImage2DHtmlElement(target types.GLEnum, level int, internalFormat types.GLEnum, format types.GLEnum, dataType types.GLEnum, pixels js.Value) {
+	c.backend.Call("texImage2D", target, level, internalFormat, format, dataType, pixels)
+	width, height := elementSize(pixels)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexImage2DHtmlElement2(target types.GLEnum, level int, internalFormat types.GLEnum, width, height int, border int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
+	if !c.requireWebGL2("TexImage2DHtmlElement2") {
+		return
+	}
+	c.backend.Call("texImage2D", target, level, internalFormat, width, height, border, format, dataType, source)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, 1)
+}
+
+// WebGL 2.0
+// Source is an image, canvas, video or ImageData element holding the layers stacked vertically
+func (c *RenderingContext) TexImage3DHtmlElement(target types.GLEnum, level int, internalFormat types.GLEnum, width, height, depth int, border int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
+	if !c.requireWebGL2("TexImage3DHtmlElement") {
+		return
+	}
+	c.backend.Call("texImage3D", target, level, internalFormat, width, height, depth, border, format, dataType, source)
+	c.memory.specifyImage(target, level, internalFormat, dataType, width, height, depth)
+}
+
+func (c *RenderingContext) TexSubImage2DHtmlElement(target types.GLEnum, level int, xOffset, yOffset int, format types.GLEnum, dataType types.GLEnum, pixels js.Value) {
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, format, dataType, pixels)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage2DHtmlElement2(target types.GLEnum, level int, xOffset, yOffset int, width, height int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
+	if !c.requireWebGL2("TexSubImage2DHtmlElement2") {
+		return
+	}
+	c.backend.Call("texSubImage2D", target, level, xOffset, yOffset, width, height, format, dataType, source)
+}
+
+// WebGL 2.0
+func (c *RenderingContext) TexSubImage3DHtmlElement(target types.GLEnum, level int, xOffset, yOffset, zOffset int, width, height, depth int, format types.GLEnum, dataType types.GLEnum, source js.Value) {
+	if !c.requireWebGL2("TexSubImage3DHtmlElement") {
+		return
+	}
+	c.backend.Call("texSubImage3D", target, level, xOffset, yOffset, zOffset, width, height, depth, format, dataType, source)
+}
+
+// Returns the size of the pixels of an image, canvas, video, ImageData or ImageBitmap
+func elementSize(element js.Value) (int, int) {
+	for _, names := range [][2]string{{"videoWidth", "videoHeight"}, {"naturalWidth", "naturalHeight"}, {"width", "height"}} {
+		width, height := element.Get(names[0]), element.Get(names[1])
+		if width.Type() == js.TypeNumber && height.Type() == js.TypeNumber {
+			return width.Int(), height.Int()
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,63 @@
+package webgl
+
+import "github.com/nuberu/webgl/backend"
+
+// Backend recovering the exceptions thrown by calls, installed by EnableSafeCalls
+type safeBackend struct {
+	backend.Backend
+	context *RenderingContext
+}
+
+// Recovers the JavaScript exceptions thrown by calls, like for a handle of another context or an
+// argument of the wrong type, instead of letting them crash the module. The exception is returned
+// by the next GetError call as an ExceptionError, and the call returns undefined. Other panics
+// are not recovered.
+func (c *RenderingContext) EnableSafeCalls() {
+	if !c.IsSafeCallsEnabled() {
+		c.addLayer(&safeBackend{context: c})
+	}
+}
+
+func (c *RenderingContext) DisableSafeCalls() {
+	c.removeLayer((*safeBackend)(nil))
+}
+
+func (c *RenderingContext) IsSafeCallsEnabled() bool {
+	return c.findLayer((*safeBackend)(nil)) != nil
+}
+
+func (b *safeBackend) Unwrap() backend.Backend {
+	return b.Backend
+}
+
+func (b *safeBackend) wrap(inner backend.Backend) {
+	b.Backend = inner
+}
+
+func (b *safeBackend) Call(method string, args ...interface{}) (result backend.Value) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.recover(method, recovered)
+			result = backend.Undefined()
+		}
+	}()
+	return b.Backend.Call(method, args...)
+}
+
+// Replays the calls of a batched context, its exceptions name the buffered call that threw
+func (b *safeBackend) Flush() {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.recover("FlushCommands", recovered)
+		}
+	}()
+	backend.Flush(b.Backend)
+}
+
+func (b *safeBackend) recover(method string, recovered interface{}) {
+	err, ok := exception(recovered)
+	if !ok {
+		panic(recovered)
+	}
+	b.context.raise(&ExceptionError{Method: method, Err: err})
+}
@@ -0,0 +1,376 @@
+package webgl
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/types"
+	"reflect"
+	"strings"
+)
+
+// Texture binding of a texture unit, the cache key of bindTexture
+type textureBinding struct {
+	unit   float64
+	target float64
+}
+
+// Cached state set by a call, keyed by getParameter name or textureBinding
+type stateChange struct {
+	key   interface{}
+	value interface{}
+}
+
+// Backend mirroring the GL state, installed by EnableStateCache. Calls setting the state to its
+// cached value are skipped, and getParameter and isEnabled are answered from the cache when the
+// state is known. Numbers are cached as float64 and arrays as []interface{}.
+type stateCache struct {
+	backend.Backend
+	values map[interface{}]interface{}
+}
+
+// Binding names of the buffer targets
+var bufferBindings = map[float64]types.GLEnum{
+	float64(ARRAY_BUFFER):              ARRAY_BUFFER_BINDING,
+	float64(ELEMENT_ARRAY_BUFFER):      ELEMENT_ARRAY_BUFFER_BINDING,
+	float64(COPY_READ_BUFFER):          COPY_READ_BUFFER_BINDING,
+	float64(COPY_WRITE_BUFFER):         COPY_WRITE_BUFFER_BINDING,
+	float64(PIXEL_PACK_BUFFER):         PIXEL_PACK_BUFFER_BINDING,
+	float64(PIXEL_UNPACK_BUFFER):       PIXEL_UNPACK_BUFFER_BINDING,
+	float64(TRANSFORM_FEEDBACK_BUFFER): TRANSFORM_FEEDBACK_BUFFER_BINDING,
+	float64(UNIFORM_BUFFER):            UNIFORM_BUFFER_BINDING,
+}
+
+// Texture targets of the texture binding names
+var textureBindings = map[types.GLEnum]types.GLEnum{
+	TEXTURE_BINDING_2D:       TEXTURE_2D,
+	TEXTURE_BINDING_CUBE_MAP: TEXTURE_CUBE_MAP,
+	TEXTURE_BINDING_3D:       TEXTURE_3D,
+	TEXTURE_BINDING_2D_ARRAY: TEXTURE_2D_ARRAY,
+}
+
+var stencilState = []types.GLEnum{
+	STENCIL_FUNC, STENCIL_REF, STENCIL_VALUE_MASK, STENCIL_FAIL, STENCIL_PASS_DEPTH_FAIL, STENCIL_PASS_DEPTH_PASS,
+	STENCIL_WRITEMASK, STENCIL_BACK_FUNC, STENCIL_BACK_REF, STENCIL_BACK_VALUE_MASK, STENCIL_BACK_FAIL,
+	STENCIL_BACK_PASS_DEPTH_FAIL, STENCIL_BACK_PASS_DEPTH_PASS, STENCIL_BACK_WRITEMASK,
+}
+
+// Skips the calls leaving the state unchanged, like binding the bound buffer again, and answers the
+// GetParameter* getters of the known state without asking the backend. Changing the state through
+// GetJs, or with calls raising errors, leaves the cache out of date until InvalidateStateCache.
+func (c *RenderingContext) EnableStateCache() {
+	if c.stateCache() == nil {
+		c.addLayer(&stateCache{values: make(map[interface{}]interface{})})
+	}
+}
+
+func (c *RenderingContext) DisableStateCache() {
+	c.removeLayer((*stateCache)(nil))
+}
+
+func (c *RenderingContext) IsStateCacheEnabled() bool {
+	return c.stateCache() != nil
+}
+
+// Forgets the whole cached state and the state set by ApplyState, to be called after changing the
+// state through GetJs
+func (c *RenderingContext) InvalidateStateCache() {
+	c.appliedState = nil
+	if cache := c.stateCache(); cache != nil {
+		cache.values = make(map[interface{}]interface{})
+	}
+}
+
+// Forgets the cached state of getParameter names, like ARRAY_BUFFER_BINDING or BLEND.
+// TEXTURE_BINDING_* names forget the bindings of every texture unit.
+func (c *RenderingContext) InvalidateState(pNames ...types.GLEnum) {
+	cache := c.stateCache()
+	if cache == nil {
+		return
+	}
+	for _, pName := range pNames {
+		delete(cache.values, float64(pName))
+		if target, ok := textureBindings[pName]; ok {
+			for key := range cache.values {
+				if binding, ok := key.(textureBinding); ok && binding.target == float64(target) {
+					delete(cache.values, key)
+				}
+			}
+		}
+	}
+}
+
+// Returns the cache among the wrappers of the backend, nil when disabled
+func (c *RenderingContext) stateCache() *stateCache {
+	cache, _ := c.findLayer((*stateCache)(nil)).(*stateCache)
+	return cache
+}
+
+func (cache *stateCache) Unwrap() backend.Backend {
+	return cache.Backend
+}
+
+func (cache *stateCache) wrap(inner backend.Backend) {
+	cache.Backend = inner
+}
+
+func (cache *stateCache) Call(method string, args ...interface{}) backend.Value {
+	switch method {
+	case "getParameter":
+		if value, ok := cache.parameter(stateArg(args, 0)); ok {
+			return backend.ValueOf(value)
+		}
+	case "isEnabled":
+		if value, ok := cache.values[stateArg(args, 0)]; ok {
+			return backend.ValueOf(value)
+		}
+	}
+
+	changes, forget := cache.changes(method, args)
+	if len(changes) > 0 && cache.unchanged(changes) {
+		return backend.Undefined()
+	}
+	result := cache.Backend.Call(method, args...)
+	for _, key := range forget {
+		delete(cache.values, key)
+	}
+	for _, change := range changes {
+		cache.values[change.key] = change.value
+	}
+	if strings.HasPrefix(method, "delete") && len(args) > 0 {
+		cache.forgetObject(stateArg(args, 0))
+	}
+	return result
+}
+
+func (cache *stateCache) parameter(pName interface{}) (interface{}, bool) {
+	if target, ok := textureBindings[types.GLEnum(toFloat(pName))]; ok {
+		unit, ok := cache.values[float64(ACTIVE_TEXTURE)]
+		if !ok {
+			return nil, false
+		}
+		pName = textureBinding{unit: unit.(float64), target: float64(target)}
+	}
+	value, ok := cache.values[pName]
+	if !ok {
+		return nil, false
+	}
+	if value == nil {
+		// Unbound objects read as null, like from WebGL
+		return backend.Null(), true
+	}
+	if handle, ok := value.(backend.Handle); ok {
+		// The getters wrap the value of the bound object into a new handle
+		return handle.GetValue(), true
+	}
+	return value, true
+}
+
+func (cache *stateCache) unchanged(changes []stateChange) bool {
+	for _, change := range changes {
+		cached, ok := cache.values[change.key]
+		if !ok || !stateEqual(cached, change.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Deleted objects are unbound by WebGL
+func (cache *stateCache) forgetObject(obj interface{}) {
+	if obj == nil {
+		return
+	}
+	for key, value := range cache.values {
+		if value == obj {
+			delete(cache.values, key)
+		}
+	}
+}
+
+// Returns the state set by a call, and the state it changes in ways the cache does not follow
+func (cache *stateCache) changes(method string, args []interface{}) ([]stateChange, []interface{}) {
+	set := func(pNames ...types.GLEnum) []stateChange {
+		changes := make([]stateChange, len(pNames))
+		for i, pName := range pNames {
+			changes[i] = stateChange{key: float64(pName), value: stateArg(args, i)}
+		}
+		return changes
+	}
+	setAll := func(value interface{}, pNames ...types.GLEnum) []stateChange {
+		changes := make([]stateChange, len(pNames))
+		for i, pName := range pNames {
+			changes[i] = stateChange{key: float64(pName), value: value}
+		}
+		return changes
+	}
+	keys := func(pNames ...types.GLEnum) []interface{} {
+		keys := make([]interface{}, len(pNames))
+		for i, pName := range pNames {
+			keys[i] = float64(pName)
+		}
+		return keys
+	}
+
+	switch method {
+	case "activeTexture":
+		return set(ACTIVE_TEXTURE), nil
+	case "bindBuffer":
+		if binding, ok := bufferBindings[toFloat(stateArg(args, 0))]; ok {
+			return setAll(stateArg(args, 1), binding), nil
+		}
+	case "bindBufferBase", "bindBufferRange":
+		if binding, ok := bufferBindings[toFloat(stateArg(args, 0))]; ok {
+			return nil, keys(binding)
+		}
+	case "bindTexture":
+		unit, ok := cache.values[float64(ACTIVE_TEXTURE)]
+		if !ok {
+			unit = float64(cache.Backend.Call("getParameter", ACTIVE_TEXTURE).Int())
+			cache.values[float64(ACTIVE_TEXTURE)] = unit
+		}
+		key := textureBinding{unit: unit.(float64), target: toFloat(stateArg(args, 0))}
+		return []stateChange{{key: key, value: stateArg(args, 1)}}, nil
+	case "bindFramebuffer":
+		switch types.GLEnum(toFloat(stateArg(args, 0))) {
+		case FRAMEBUFFER:
+			return setAll(stateArg(args, 1), FRAMEBUFFER_BINDING, READ_FRAMEBUFFER_BINDING), nil
+		case DRAW_FRAMEBUFFER:
+			return setAll(stateArg(args, 1), FRAMEBUFFER_BINDING), nil
+		case READ_FRAMEBUFFER:
+			return setAll(stateArg(args, 1), READ_FRAMEBUFFER_BINDING), nil
+		}
+	case "bindRenderbuffer":
+		return setAll(stateArg(args, 1), RENDERBUFFER_BINDING), nil
+	case "bindVertexArray":
+		return set(VERTEX_ARRAY_BINDING), keys(ELEMENT_ARRAY_BUFFER_BINDING)
+	case "useProgram":
+		return set(CURRENT_PROGRAM), nil
+	case "enable":
+		return []stateChange{{key: stateArg(args, 0), value: true}}, nil
+	case "disable":
+		return []stateChange{{key: stateArg(args, 0), value: false}}, nil
+	case "blendColor":
+		return setAll(stateArgs(args), BLEND_COLOR), nil
+	case "blendEquation":
+		return setAll(stateArg(args, 0), BLEND_EQUATION_RGB, BLEND_EQUATION_ALPHA), nil
+	case "blendEquationSeparate":
+		return set(BLEND_EQUATION_RGB, BLEND_EQUATION_ALPHA), nil
+	case "blendFunc":
+		return append(setAll(stateArg(args, 0), BLEND_SRC_RGB, BLEND_SRC_ALPHA),
+			setAll(stateArg(args, 1), BLEND_DST_RGB, BLEND_DST_ALPHA)...), nil
+	case "blendFuncSeparate":
+		return set(BLEND_SRC_RGB, BLEND_DST_RGB, BLEND_SRC_ALPHA, BLEND_DST_ALPHA), nil
+	case "clearColor":
+		return setAll(stateArgs(args), COLOR_CLEAR_VALUE), nil
+	case "clearDepth":
+		return set(DEPTH_CLEAR_VALUE), nil
+	case "clearStencil":
+		return set(STENCIL_CLEAR_VALUE), nil
+	case "colorMask":
+		// WebGL reads the mask back as booleans
+		mask := stateArgs(args)
+		for i, value := range mask {
+			if number, ok := value.(float64); ok {
+				mask[i] = number != 0
+			}
+		}
+		return setAll(mask, COLOR_WRITEMASK), nil
+	case "cullFace":
+		return set(CULL_FACE_MODE), nil
+	case "depthFunc":
+		return set(DEPTH_FUNC), nil
+	case "depthMask":
+		return set(DEPTH_WRITEMASK), nil
+	case "depthRange":
+		return setAll(stateArgs(args), DEPTH_RANGE), nil
+	case "frontFace":
+		return set(FRONT_FACE), nil
+	case "lineWidth":
+		return set(LINE_WIDTH), nil
+	case "pixelStorei":
+		return []stateChange{{key: stateArg(args, 0), value: stateArg(args, 1)}}, nil
+	case "polygonOffset":
+		return set(POLYGON_OFFSET_FACTOR, POLYGON_OFFSET_UNITS), nil
+	case "scissor":
+		return setAll(stateArgs(args), SCISSOR_BOX), nil
+	case "viewport":
+		return setAll(stateArgs(args), VIEWPORT), nil
+	case "stencilFunc":
+		return append(set(STENCIL_FUNC, STENCIL_REF, STENCIL_VALUE_MASK), set(STENCIL_BACK_FUNC, STENCIL_BACK_REF, STENCIL_BACK_VALUE_MASK)...), nil
+	case "stencilMask":
+		return setAll(stateArg(args, 0), STENCIL_WRITEMASK, STENCIL_BACK_WRITEMASK), nil
+	case "stencilOp":
+		return append(set(STENCIL_FAIL, STENCIL_PASS_DEPTH_FAIL, STENCIL_PASS_DEPTH_PASS), set(STENCIL_BACK_FAIL, STENCIL_BACK_PASS_DEPTH_FAIL, STENCIL_BACK_PASS_DEPTH_PASS)...), nil
+	case "stencilFuncSeparate", "stencilMaskSeparate", "stencilOpSeparate":
+		return nil, keys(stencilState...)
+	}
+	return nil, nil
+}
+
+// Normalizes an argument for comparisons: numbers of any type give float64 and null handles nil
+func stateArg(args []interface{}, i int) interface{} {
+	if i >= len(args) {
+		return nil
+	}
+	switch value := args[i].(type) {
+	case nil:
+		return nil
+	case bool:
+		return value
+	case backend.Handle:
+		if value.GetValue() == nil {
+			return nil
+		}
+		return value
+	}
+	if number, ok := toNumber(args[i]); ok {
+		return number
+	}
+	return args[i]
+}
+
+func stateArgs(args []interface{}) []interface{} {
+	values := make([]interface{}, len(args))
+	for i := range args {
+		values[i] = stateArg(args, i)
+	}
+	return values
+}
+
+func toNumber(arg interface{}) (float64, bool) {
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+func toFloat(arg interface{}) float64 {
+	number, _ := toNumber(arg)
+	return number
+}
+
+// Handles compare by identity, arrays by element
+func stateEqual(a, b interface{}) bool {
+	listA, okA := a.([]interface{})
+	listB, okB := b.([]interface{})
+	if okA != okB {
+		return false
+	}
+	if !okA {
+		return a == b
+	}
+	if len(listA) != len(listB) {
+		return false
+	}
+	for i := range listA {
+		if listA[i] != listB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package webgl_test
+
+import (
+	"github.com/nuberu/webgl"
+	"github.com/nuberu/webgl/backend"
+	"github.com/nuberu/webgl/backend/mock"
+	"testing"
+)
+
+func newCachedContext(version uint) (*webgl.RenderingContext, *mock.Recorder) {
+	recorder := mock.New()
+	gl := webgl.WrapBackend(recorder, version)
+	gl.EnableStateCache()
+	return gl, recorder
+}
+
+func TestStateCacheSkipsRepeatedCalls(t *testing.T) {
+	gl, recorder := newCachedContext(webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	recorder.Reset()
+
+	for i := 0; i < 2; i++ {
+		gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+		gl.Enable(webgl.BLEND)
+		gl.Viewport(0, 0, 640, 480)
+	}
+	gl.Viewport(0, 0, 320, 240)
+	gl.Disable(webgl.BLEND)
+	err := recorder.ExpectCalls(
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, buffer),
+		mock.NewCall("enable", webgl.BLEND),
+		mock.NewCall("viewport", 0, 0, 640, 480),
+		mock.NewCall("viewport", 0, 0, 320, 240),
+		mock.NewCall("disable", webgl.BLEND),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStateCacheAnswersGetters(t *testing.T) {
+	gl, recorder := newCachedContext(webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.Enable(webgl.DEPTH_TEST)
+	gl.Viewport(1, 2, 30, 40)
+	recorder.Reset()
+
+	if bound := gl.GetParameterArrayBufferBinding(); backend.Unwrap(bound) != backend.Unwrap(buffer) {
+		t.Errorf("got ARRAY_BUFFER_BINDING %v, expected the bound buffer", bound)
+	}
+	if !gl.GetParameter(webgl.DEPTH_TEST).Bool() || !gl.IsEnabled(webgl.DEPTH_TEST) {
+		t.Error("DEPTH_TEST read as disabled")
+	}
+	viewport := gl.GetParameter(webgl.VIEWPORT)
+	for i, expected := range []int{1, 2, 30, 40} {
+		if value := viewport.Index(i).Int(); value != expected {
+			t.Errorf("got VIEWPORT[%d] %d, expected %d", i, value, expected)
+		}
+	}
+	if calls := recorder.CallsTo("getParameter", "isEnabled"); len(calls) != 0 {
+		t.Errorf("the getters reached the backend: %v", calls)
+	}
+
+	// Unknown state is still asked
+	gl.GetParameterCullFace()
+	if err := recorder.ExpectCalls(mock.NewCall("getParameter", webgl.CULL_FACE)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStateCacheForgetsDeletedBindings(t *testing.T) {
+	gl, recorder := newCachedContext(webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.DeleteBuffer(buffer)
+	recorder.Reset()
+
+	gl.GetParameterArrayBufferBinding()
+	replacement := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, replacement)
+	err := recorder.ExpectCalls(
+		mock.NewCall("getParameter", webgl.ARRAY_BUFFER_BINDING),
+		mock.NewCall("createBuffer"),
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, replacement),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStateCacheInvalidation(t *testing.T) {
+	gl, recorder := newCachedContext(webgl.WebGL2)
+	buffer := gl.CreateBuffer()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.Enable(webgl.BLEND)
+	recorder.Reset()
+
+	gl.InvalidateState(webgl.ARRAY_BUFFER_BINDING)
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.Enable(webgl.BLEND)
+	gl.InvalidateStateCache()
+	gl.BindBuffer(webgl.ARRAY_BUFFER, buffer)
+	gl.Enable(webgl.BLEND)
+	err := recorder.ExpectCalls(
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, buffer),
+		mock.NewCall("bindBuffer", webgl.ARRAY_BUFFER, buffer),
+		mock.NewCall("enable", webgl.BLEND),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// The element buffer binding belongs to the vertex array, binding another one forgets it
+func TestStateCacheVertexArrayOES(t *testing.T) {
+	gl, recorder := newCachedContext(webgl.WebGL1)
+	extension := mock.New()
+	recorder.Results["getExtension"] = extension
+	buffer := gl.CreateBuffer()
+	vertexArray := gl.CreateVertexArray()
+	gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, buffer)
+	recorder.Reset()
+
+	gl.BindVertexArray(vertexArray)
+	gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, buffer)
+	gl.BindBuffer(webgl.ELEMENT_ARRAY_BUFFER, buffer)
+	if err := recorder.ExpectCalls(mock.NewCall("bindBuffer", webgl.ELEMENT_ARRAY_BUFFER, buffer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := extension.ExpectCallsInOrder(mock.NewCall("bindVertexArrayOES", vertexArray)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.GetError(); err != nil {
+		t.Fatal(err)
+	}
+}
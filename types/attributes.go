@@ -12,3 +12,14 @@ type Attributes struct {
 	Storage                      string // Only Chromium
 	WillReadFrequently           bool   // Only Firefox
 }
+
+// Attributes with the same values the browser uses when none are given
+func NewDefaultAttributes() *Attributes {
+	return &Attributes{
+		Alpha:              true,
+		Antialias:          true,
+		Depth:              true,
+		PowerPreference:    "default",
+		PremultipliedAlpha: true,
+	}
+}
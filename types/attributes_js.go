@@ -0,0 +1,23 @@
+package types
+
+import "syscall/js"
+
+// Builds the context attributes dictionary expected by getContext
+func (attr *Attributes) ToJs() js.Value {
+	attrJs := js.Global().Get("Object").New()
+	attrJs.Set("alpha", attr.Alpha)
+	attrJs.Set("antialias", attr.Antialias)
+	attrJs.Set("depth", attr.Depth)
+	attrJs.Set("failIfMajorPerformanceCaveat", attr.FailIfMajorPerformanceCaveat)
+	if attr.PowerPreference != "" {
+		attrJs.Set("powerPreference", string(attr.PowerPreference))
+	}
+	attrJs.Set("premultipliedAlpha", attr.PremultipliedAlpha)
+	attrJs.Set("preserveDrawingBuffer", attr.PreserveDrawingBuffer)
+	attrJs.Set("stencil", attr.Stencil)
+	if attr.Storage != "" {
+		attrJs.Set("storage", attr.Storage)
+	}
+	attrJs.Set("willReadFrequently", attr.WillReadFrequently)
+	return attrJs
+}
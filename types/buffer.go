@@ -1,45 +1,57 @@
 package types
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type Buffer struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewBuffer(pointer js.Value) *Buffer {
+func NewBuffer(value backend.Value) *Buffer {
 	return &Buffer{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (buffer *Buffer) GetJs() js.Value {
-	return buffer.js
+func (buffer *Buffer) GetValue() backend.Value {
+	if buffer == nil {
+		return nil
+	}
+	return buffer.value
 }
 
 type FrameBuffer struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewFrameBuffer(pointer js.Value) *FrameBuffer {
+func NewFrameBuffer(value backend.Value) *FrameBuffer {
 	return &FrameBuffer{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (buffer *FrameBuffer) GetJs() js.Value {
-	return buffer.js
+func (buffer *FrameBuffer) GetValue() backend.Value {
+	if buffer == nil {
+		return nil
+	}
+	return buffer.value
 }
 
 type RenderBuffer struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewRenderBuffer(pointer js.Value) *RenderBuffer {
+func NewRenderBuffer(value backend.Value) *RenderBuffer {
 	return &RenderBuffer{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (buffer *RenderBuffer) GetJs() js.Value {
-	return buffer.js
+func (buffer *RenderBuffer) GetValue() backend.Value {
+	if buffer == nil {
+		return nil
+	}
+	return buffer.value
 }
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"github.com/nuberu/webgl/backend"
+	"syscall/js"
+)
+
+func (buffer *Buffer) GetJs() js.Value {
+	return backend.ToJs(buffer)
+}
+
+func (buffer *FrameBuffer) GetJs() js.Value {
+	return backend.ToJs(buffer)
+}
+
+func (buffer *RenderBuffer) GetJs() js.Value {
+	return backend.ToJs(buffer)
+}
+
+func (program *Program) GetJs() js.Value {
+	return backend.ToJs(program)
+}
+
+func (query *Query) GetJs() js.Value {
+	return backend.ToJs(query)
+}
+
+func (sampler *Sampler) GetJs() js.Value {
+	return backend.ToJs(sampler)
+}
+
+func (shader *Shader) GetJs() js.Value {
+	return backend.ToJs(shader)
+}
+
+func (sync *Sync) GetJs() js.Value {
+	return backend.ToJs(sync)
+}
+
+func (tex *Texture) GetJs() js.Value {
+	return backend.ToJs(tex)
+}
+
+func (tf *TransformFeedback) GetJs() js.Value {
+	return backend.ToJs(tf)
+}
+
+func (ul *UniformLocation) GetJs() js.Value {
+	return backend.ToJs(ul)
+}
+
+func (vao *VertexArray) GetJs() js.Value {
+	return backend.ToJs(vao)
+}
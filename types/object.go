@@ -0,0 +1,86 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+// Lifetime of the objects created by a context, ended by a context loss. The objects of a lost
+// generation stay invalid once the context is restored, they must be created again.
+type Generation struct {
+	lost bool
+}
+
+func NewGeneration() *Generation {
+	return &Generation{}
+}
+
+func (g *Generation) Lose() {
+	g.lost = true
+}
+
+func (g *Generation) IsLost() bool {
+	return g != nil && g.lost
+}
+
+// Handle of a WebGL object, implemented by every handle through its embedded Object
+type Tracked interface {
+	backend.Handle
+	GetObject() *Object
+}
+
+// State shared by the WebGL object handles, embedded in each of them
+type Object struct {
+	generation *Generation
+
+	id      uint64
+	label   string
+	stack   []byte
+	deleted bool
+}
+
+func (obj *Object) GetObject() *Object {
+	return obj
+}
+
+// Called by the context creating the object. The stack is only kept in webgldebug builds.
+func (obj *Object) Track(id uint64, stack []byte) {
+	obj.id = id
+	obj.stack = stack
+}
+
+// Identifier given by the context, unique among its objects. Handles returned by the getters,
+// like GetParameterArrayBufferBinding, are not tracked and have the identifier 0.
+func (obj *Object) ID() uint64 {
+	return obj.id
+}
+
+// Sets a name shown in the leak reports and errors about the object
+func (obj *Object) SetLabel(label string) {
+	obj.label = label
+}
+
+func (obj *Object) Label() string {
+	return obj.label
+}
+
+// Go stack of the creation of the object, nil unless built with the webgldebug tag
+func (obj *Object) Stack() []byte {
+	return obj.stack
+}
+
+// Called by the context deleting the object
+func (obj *Object) MarkDeleted() {
+	obj.deleted = true
+}
+
+func (obj *Object) IsDeleted() bool {
+	return obj.deleted
+}
+
+// Called by the context creating the object
+func (obj *Object) SetGeneration(g *Generation) {
+	obj.generation = g
+}
+
+// Whether the object was created before a context loss, and cannot be used anymore
+func (obj *Object) IsLost() bool {
+	return obj.generation.IsLost()
+}
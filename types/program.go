@@ -1,17 +1,21 @@
 package types
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type Program struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewProgram(pointer js.Value) *Program {
+func NewProgram(value backend.Value) *Program {
 	return &Program{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (program *Program) GetJs() js.Value {
-	return program.js
+func (program *Program) GetValue() backend.Value {
+	if program == nil {
+		return nil
+	}
+	return program.value
 }
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+type Query struct {
+	Object
+	value backend.Value
+}
+
+func NewQuery(value backend.Value) *Query {
+	return &Query{
+		value: value,
+	}
+}
+
+func (query *Query) GetValue() backend.Value {
+	if query == nil {
+		return nil
+	}
+	return query.value
+}
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+type Sampler struct {
+	Object
+	value backend.Value
+}
+
+func NewSampler(value backend.Value) *Sampler {
+	return &Sampler{
+		value: value,
+	}
+}
+
+func (sampler *Sampler) GetValue() backend.Value {
+	if sampler == nil {
+		return nil
+	}
+	return sampler.value
+}
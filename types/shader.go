@@ -1,19 +1,23 @@
 package types
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type Shader struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewShader(pointer js.Value) *Shader {
+func NewShader(value backend.Value) *Shader {
 	return &Shader{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (shader *Shader) GetJs() js.Value {
-	return shader.js
+func (shader *Shader) GetValue() backend.Value {
+	if shader == nil {
+		return nil
+	}
+	return shader.value
 }
 
 type ShaderPrecisionFormat struct {
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+type Sync struct {
+	Object
+	value backend.Value
+}
+
+func NewSync(value backend.Value) *Sync {
+	return &Sync{
+		value: value,
+	}
+}
+
+func (sync *Sync) GetValue() backend.Value {
+	if sync == nil {
+		return nil
+	}
+	return sync.value
+}
@@ -1,17 +1,21 @@
 package types
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type Texture struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewTexture(pointer js.Value) *Texture {
+func NewTexture(value backend.Value) *Texture {
 	return &Texture{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (tex *Texture) GetJs() js.Value {
-	return tex.js
+func (tex *Texture) GetValue() backend.Value {
+	if tex == nil {
+		return nil
+	}
+	return tex.value
 }
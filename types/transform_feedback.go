@@ -0,0 +1,21 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+type TransformFeedback struct {
+	Object
+	value backend.Value
+}
+
+func NewTransformFeedback(value backend.Value) *TransformFeedback {
+	return &TransformFeedback{
+		value: value,
+	}
+}
+
+func (tf *TransformFeedback) GetValue() backend.Value {
+	if tf == nil {
+		return nil
+	}
+	return tf.value
+}
@@ -1,17 +1,21 @@
 package types
 
-import "syscall/js"
+import "github.com/nuberu/webgl/backend"
 
 type UniformLocation struct {
-	js js.Value
+	Object
+	value backend.Value
 }
 
-func NewUniformLocation(pointer js.Value) *UniformLocation {
+func NewUniformLocation(value backend.Value) *UniformLocation {
 	return &UniformLocation{
-		js: pointer,
+		value: value,
 	}
 }
 
-func (ul *UniformLocation) GetJs() js.Value {
-	return ul.js
+func (ul *UniformLocation) GetValue() backend.Value {
+	if ul == nil {
+		return nil
+	}
+	return ul.value
 }
@@ -0,0 +1,23 @@
+package types
+
+import "github.com/nuberu/webgl/backend"
+
+// Vertex array object, either a WebGL 2.0 WebGLVertexArrayObject or an
+// OES_vertex_array_object WebGLVertexArrayObjectOES
+type VertexArray struct {
+	Object
+	value backend.Value
+}
+
+func NewVertexArray(value backend.Value) *VertexArray {
+	return &VertexArray{
+		value: value,
+	}
+}
+
+func (vao *VertexArray) GetValue() backend.Value {
+	if vao == nil {
+		return nil
+	}
+	return vao.value
+}
@@ -0,0 +1,260 @@
+package webgl
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/nuberu/webgl/types"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Layout of an active uniform inside a uniform block, as reported by the driver
+type UniformBlockMember struct {
+	Name         string
+	Type         types.GLEnum
+	Size         int // Array length, 1 for non array uniforms
+	Offset       int
+	ArrayStride  int
+	MatrixStride int
+	RowMajor     bool
+}
+
+// Layout of a uniform block, used to encode Go values into the block's buffer
+// without computing the std140 padding by hand.
+type UniformBlockLayout struct {
+	Name     string
+	Index    uint
+	DataSize int
+	Members  []*UniformBlockMember
+}
+
+// WebGL 2.0
+// Introspects the offsets, array strides and matrix strides of every member of a block
+func (c *RenderingContext) GetUniformBlockLayout(program *types.Program, uniformBlockName string) (*UniformBlockLayout, error) {
+	if !c.IsWebGL2() {
+		return nil, &VersionError{Method: "GetUniformBlockLayout", Version: c.version, Required: WebGL2}
+	}
+
+	blockIndex := c.GetUniformBlockIndex(program, uniformBlockName)
+	if blockIndex == uint(INVALID_INDEX) {
+		return nil, fmt.Errorf("uniform block %q is not active", uniformBlockName)
+	}
+
+	indices := c.GetActiveUniformBlockParameterActiveUniformIndices(program, blockIndex)
+	uniformTypes := c.GetActiveUniformsType(program, indices)
+	sizes := c.GetActiveUniformsSize(program, indices)
+	offsets := c.GetActiveUniformsOffset(program, indices)
+	arrayStrides := c.GetActiveUniformsArrayStride(program, indices)
+	matrixStrides := c.GetActiveUniformsMatrixStride(program, indices)
+	rowMajor := c.GetActiveUniformsIsRowMajor(program, indices)
+
+	layout := &UniformBlockLayout{
+		Name:     uniformBlockName,
+		Index:    blockIndex,
+		DataSize: c.GetActiveUniformBlockParameterDataSize(program, blockIndex),
+		Members:  make([]*UniformBlockMember, len(indices)),
+	}
+	for i, index := range indices {
+		layout.Members[i] = &UniformBlockMember{
+			Name:         c.GetActiveUniform(program, index).GetName(),
+			Type:         uniformTypes[i],
+			Size:         sizes[i],
+			Offset:       offsets[i],
+			ArrayStride:  arrayStrides[i],
+			MatrixStride: matrixStrides[i],
+			RowMajor:     rowMajor[i],
+		}
+	}
+	return layout, nil
+}
+
+// Finds a member by its GLSL name. Array members may be given with or without
+// the "[0]" suffix and block qualified names also match their unqualified form.
+func (l *UniformBlockLayout) GetMember(name string) *UniformBlockMember {
+	for _, member := range l.Members {
+		memberName := strings.TrimSuffix(member.Name, "[0]")
+		if memberName == name || strings.TrimPrefix(memberName, l.Name+".") == name {
+			return member
+		}
+	}
+	return nil
+}
+
+// Encodes a struct into a new buffer of DataSize bytes, ready for BufferDataB or BufferSubDataB.
+// Fields are matched by their `glsl:"name"` tag or their Go name. Scalars are float32, int32,
+// uint32 or bool, vectors are arrays of those and matrices are column major float32 arrays
+// ([16]float32 or [4][4]float32 for a mat4). Nested structs and arrays of structs map to
+// GLSL structs. Fields without an active member are skipped, arrays may be shorter than their
+// GLSL array but not longer.
+func (l *UniformBlockLayout) Encode(value interface{}) ([]byte, error) {
+	data := make([]byte, l.DataSize)
+	if err := l.EncodeInto(data, value); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Same as Encode, writing into an existing buffer of at least DataSize bytes
+func (l *UniformBlockLayout) EncodeInto(data []byte, value interface{}) error {
+	if len(data) < l.DataSize {
+		return fmt.Errorf("buffer of %d bytes is smaller than block %s (%d bytes)", len(data), l.Name, l.DataSize)
+	}
+	structValue := reflect.Indirect(reflect.ValueOf(value))
+	if structValue.Kind() != reflect.Struct {
+		return errors.New("uniform block value must be a struct")
+	}
+	return l.encodeStruct(data, "", structValue)
+}
+
+func (l *UniformBlockLayout) encodeStruct(data []byte, prefix string, structValue reflect.Value) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Tag.Get("glsl")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if err := l.encodeField(data, prefix+name, structValue.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *UniformBlockLayout) encodeField(data []byte, name string, fieldValue reflect.Value) error {
+	if fieldValue.Kind() == reflect.Struct {
+		return l.encodeStruct(data, name+".", fieldValue)
+	}
+	if isListKind(fieldValue.Kind()) && fieldValue.Len() > 0 && fieldValue.Index(0).Kind() == reflect.Struct {
+		for i := 0; i < fieldValue.Len(); i++ {
+			if err := l.encodeStruct(data, fmt.Sprintf("%s[%d].", name, i), fieldValue.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	member := l.GetMember(name)
+	if member == nil {
+		return nil
+	}
+
+	columns, rows := uniformTypeShape(member.Type)
+	if columns == 0 {
+		return fmt.Errorf("uniform %s has unsupported type 0x%04X", member.Name, uint32(member.Type))
+	}
+	components := columns * rows
+
+	elements := []reflect.Value{fieldValue}
+	if member.Size > 1 || strings.HasSuffix(member.Name, "]") {
+		if !isListKind(fieldValue.Kind()) {
+			return fmt.Errorf("uniform %s is an array, got %s", member.Name, fieldValue.Type())
+		}
+		if fieldValue.Len() > member.Size {
+			return fmt.Errorf("uniform %s has %d elements, got %d", member.Name, member.Size, fieldValue.Len())
+		}
+		elements = make([]reflect.Value, 0, fieldValue.Len())
+		for i := 0; i < fieldValue.Len(); i++ {
+			elements = append(elements, fieldValue.Index(i))
+		}
+	}
+
+	for e, element := range elements {
+		words := make([]uint32, 0, components)
+		words, err := flattenUniformValue(words, element)
+		if err != nil {
+			return fmt.Errorf("uniform %s: %v", member.Name, err)
+		}
+		if len(words) != components {
+			return fmt.Errorf("uniform %s needs %d components, got %d", member.Name, components, len(words))
+		}
+
+		base := member.Offset + e*member.ArrayStride
+		for column := 0; column < columns; column++ {
+			for row := 0; row < rows; row++ {
+				offset := base + row*4
+				if columns > 1 {
+					if member.RowMajor {
+						offset = base + row*member.MatrixStride + column*4
+					} else {
+						offset = base + column*member.MatrixStride + row*4
+					}
+				}
+				if offset+4 > len(data) {
+					return fmt.Errorf("uniform %s overflows block %s", member.Name, l.Name)
+				}
+				binary.LittleEndian.PutUint32(data[offset:], words[column*rows+row])
+			}
+		}
+	}
+	return nil
+}
+
+func isListKind(kind reflect.Kind) bool {
+	return kind == reflect.Array || kind == reflect.Slice
+}
+
+// Flattens scalars and (nested) arrays of scalars into their 32 bits representation
+func flattenUniformValue(words []uint32, value reflect.Value) ([]uint32, error) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return append(words, math.Float32bits(float32(value.Float()))), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return append(words, uint32(int32(value.Int()))), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(words, uint32(value.Uint())), nil
+	case reflect.Bool:
+		if value.Bool() {
+			return append(words, 1), nil
+		}
+		return append(words, 0), nil
+	case reflect.Array, reflect.Slice:
+		var err error
+		for i := 0; i < value.Len() && err == nil; i++ {
+			words, err = flattenUniformValue(words, value.Index(i))
+		}
+		return words, err
+	}
+	return words, fmt.Errorf("unsupported Go type %s", value.Type())
+}
+
+// Returns the number of columns and rows of a uniform type, vectors and scalars have one column
+func uniformTypeShape(uniformType types.GLEnum) (columns int, rows int) {
+	switch uniformType {
+	case FLOAT, INT, UNSIGNED_INT, BOOL:
+		return 1, 1
+	case FLOAT_VEC2, INT_VEC2, UNSIGNED_INT_VEC2, BOOL_VEC2:
+		return 1, 2
+	case FLOAT_VEC3, INT_VEC3, UNSIGNED_INT_VEC3, BOOL_VEC3:
+		return 1, 3
+	case FLOAT_VEC4, INT_VEC4, UNSIGNED_INT_VEC4, BOOL_VEC4:
+		return 1, 4
+	case FLOAT_MAT2:
+		return 2, 2
+	case FLOAT_MAT2x3:
+		return 2, 3
+	case FLOAT_MAT2x4:
+		return 2, 4
+	case FLOAT_MAT3x2:
+		return 3, 2
+	case FLOAT_MAT3:
+		return 3, 3
+	case FLOAT_MAT3x4:
+		return 3, 4
+	case FLOAT_MAT4x2:
+		return 4, 2
+	case FLOAT_MAT4x3:
+		return 4, 3
+	case FLOAT_MAT4:
+		return 4, 4
+	}
+	return 0, 0
+}
@@ -0,0 +1,146 @@
+package webgl_test
+
+import (
+	"encoding/binary"
+	"github.com/nuberu/webgl"
+	"math"
+	"strings"
+	"testing"
+)
+
+// std140 layout of
+//
+//	uniform Params {
+//		vec3 color; float intensity; mat3 normalMatrix; float w[3]; bool enabled;
+//		Light light; Light lights[2];
+//	};
+//
+// with struct Light { vec4 position; float intensity; }
+func newParamsLayout() *webgl.UniformBlockLayout {
+	return &webgl.UniformBlockLayout{
+		Name:     "Params",
+		DataSize: 240,
+		Members: []*webgl.UniformBlockMember{
+			{Name: "Params.color", Type: webgl.FLOAT_VEC3, Size: 1, Offset: 0},
+			{Name: "intensity", Type: webgl.FLOAT, Size: 1, Offset: 12},
+			{Name: "normalMatrix", Type: webgl.FLOAT_MAT3, Size: 1, Offset: 16, MatrixStride: 16},
+			{Name: "w[0]", Type: webgl.FLOAT, Size: 3, Offset: 64, ArrayStride: 16},
+			{Name: "enabled", Type: webgl.BOOL, Size: 1, Offset: 112},
+			{Name: "light.position", Type: webgl.FLOAT_VEC4, Size: 1, Offset: 128},
+			{Name: "light.intensity", Type: webgl.FLOAT, Size: 1, Offset: 144},
+			{Name: "lights[0].position", Type: webgl.FLOAT_VEC4, Size: 1, Offset: 160},
+			{Name: "lights[0].intensity", Type: webgl.FLOAT, Size: 1, Offset: 176},
+			{Name: "lights[1].position", Type: webgl.FLOAT_VEC4, Size: 1, Offset: 192},
+			{Name: "lights[1].intensity", Type: webgl.FLOAT, Size: 1, Offset: 208},
+		},
+	}
+}
+
+type light struct {
+	Position  [4]float32 `glsl:"position"`
+	Intensity float32    `glsl:"intensity"`
+}
+
+type params struct {
+	Color        [3]float32    `glsl:"color"`
+	Intensity    float32       `glsl:"intensity"`
+	NormalMatrix [3][3]float32 `glsl:"normalMatrix"`
+	W            []float32     `glsl:"w"`
+	Enabled      bool          `glsl:"enabled"`
+	Light        light         `glsl:"light"`
+	Lights       [2]light      `glsl:"lights"`
+	Ignored      float32       `glsl:"-"`
+}
+
+func TestUniformBlockEncode(t *testing.T) {
+	data, err := newParamsLayout().Encode(&params{
+		Color:        [3]float32{1, 2, 3},
+		Intensity:    4,
+		NormalMatrix: [3][3]float32{{5, 6, 7}, {8, 9, 10}, {11, 12, 13}},
+		W:            []float32{14, 15, 16},
+		Enabled:      true,
+		Light:        light{Position: [4]float32{17, 18, 19, 20}, Intensity: 21},
+		Lights:       [2]light{{Intensity: 22}, {Position: [4]float32{23, 24, 25, 26}, Intensity: 27}},
+		Ignored:      99,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 240 {
+		t.Fatalf("got %d bytes, expected the 240 bytes of the block", len(data))
+	}
+
+	expected := make([]float32, 60)
+	// vec3 then the float packed in its fourth component
+	copy(expected[0:], []float32{1, 2, 3, 4})
+	// mat3 columns padded to 16 bytes
+	copy(expected[4:], []float32{5, 6, 7, 0, 8, 9, 10, 0, 11, 12, 13, 0})
+	// float array elements every 16 bytes
+	expected[16], expected[20], expected[24] = 14, 15, 16
+	copy(expected[32:], []float32{17, 18, 19, 20, 21})
+	expected[44] = 22
+	copy(expected[48:], []float32{23, 24, 25, 26, 27})
+	for i, value := range expected {
+		word := binary.LittleEndian.Uint32(data[i*4:])
+		if i == 28 {
+			if word != 1 {
+				t.Errorf("got bool word %d at offset 112, expected 1", word)
+			}
+			continue
+		}
+		if got := math.Float32frombits(word); got != value {
+			t.Errorf("got %v at offset %d, expected %v", got, i*4, value)
+		}
+	}
+}
+
+// Row major matrices store their rows every MatrixStride bytes
+func TestUniformBlockEncodeRowMajor(t *testing.T) {
+	layout := &webgl.UniformBlockLayout{
+		Name:     "Transform",
+		DataSize: 32,
+		Members:  []*webgl.UniformBlockMember{{Name: "m", Type: webgl.FLOAT_MAT2, Size: 1, MatrixStride: 16, RowMajor: true}},
+	}
+	data, err := layout.Encode(struct {
+		M [4]float32 `glsl:"m"`
+	}{[4]float32{1, 2, 3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for offset, expected := range map[int]float32{0: 1, 4: 3, 16: 2, 20: 4} {
+		if got := math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])); got != expected {
+			t.Errorf("got %v at offset %d, expected %v", got, offset, expected)
+		}
+	}
+}
+
+func TestUniformBlockEncodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		value interface{}
+		error string
+	}{
+		{struct {
+			Color [2]float32 `glsl:"color"`
+		}{}, "uniform Params.color needs 3 components, got 2"},
+		{struct {
+			W [5]float32 `glsl:"w"`
+		}{}, "uniform w[0] has 3 elements, got 5"},
+		{struct {
+			W float32 `glsl:"w"`
+		}{}, "uniform w[0] is an array, got float32"},
+		{struct {
+			Enabled string `glsl:"enabled"`
+		}{}, "uniform enabled: unsupported Go type string"},
+		{[]float32{1}, "uniform block value must be a struct"},
+	} {
+		_, err := newParamsLayout().Encode(test.value)
+		if err == nil || !strings.Contains(err.Error(), test.error) {
+			t.Errorf("got error %v, expected %q", err, test.error)
+		}
+	}
+
+	err := newParamsLayout().EncodeInto(make([]byte, 16), params{})
+	if err == nil || !strings.Contains(err.Error(), "smaller than block Params") {
+		t.Errorf("got error %v for a small buffer", err)
+	}
+}